@@ -0,0 +1,147 @@
+package authentic
+
+import (
+	"chatroombackend/api/chatroom"
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/middleware"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// HandleDeleteAccount 注销当前账号（软删除），需要重新输入密码确认。
+// 担任房主的聊天室转让给各自加入最早的管理员后退出，任一聊天室没有管理员时拒绝注销
+func HandleDeleteAccount(c *gin.Context) {
+	var req DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请求参数错误",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	userID := c.GetString("userId")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "用户未认证",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	user, err := queries.GetUserByID(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取用户信息失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.HashedPassword), []byte(req.Password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "密码错误",
+		})
+		return
+	}
+
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 转让聊天室、注销账号与吊销会话在同一事务中完成
+	var transfers []chatroom.OwnershipTransfer
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		var txErr error
+		transfers, txErr = chatroom.HandOverOwnedRooms(ctx, qtx, userID)
+		if txErr != nil {
+			return txErr
+		}
+		if txErr = qtx.DeleteUserAccount(ctx, userID); txErr != nil {
+			return txErr
+		}
+		_, txErr = qtx.RevokeAllUserSessions(ctx, userID)
+		return txErr
+	})
+	if err != nil {
+		var noSuccessor *chatroom.NoSuccessorError
+		if errors.As(err, &noSuccessor) {
+			rooms := make([]gin.H, 0, len(noSuccessor.Rooms))
+			for _, r := range noSuccessor.Rooms {
+				rooms = append(rooms, gin.H{
+					"roomId": r.RoomID,
+					"name":   r.RoomName,
+				})
+			}
+			c.JSON(http.StatusConflict, gin.H{
+				"code":    409,
+				"message": "以下聊天室没有可接任的管理员，请先设置管理员、转让房主或解散聊天室",
+				"data": gin.H{
+					"rooms": rooms,
+				},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "注销账号失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Auth", fmt.Sprintf("User %s deleted account, %d rooms handed over", userID, len(transfers)))
+	for _, t := range transfers {
+		chatroom.AnnounceOwnershipTransfer(ctx, queries, t)
+	}
+	websocketmsg.CloseUserConnections(userID)
+
+	if err := queries.UpdateUserOnlineStatus(ctx, sqlcdb.UpdateUserOnlineStatusParams{
+		UserID: userID,
+		OnlineStatus: sqlcdb.NullUserOnlineStatus{
+			UserOnlineStatus: sqlcdb.UserOnlineStatusOffline,
+			Valid:            true,
+		},
+	}); err != nil {
+		logger.Error("Auth", fmt.Sprintf("Failed to set user %s offline after account deletion", userID), err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "账号已注销",
+		"data": gin.H{
+			"transferredRooms": transfers,
+		},
+	})
+}
@@ -107,8 +107,8 @@ func HandleLogin(c *gin.Context) {
 		},
 	})
 
-	// 生成JWT token
-	token, err := middleware.GenerateToken(user.UserID, user.Username)
+	// 创建登录会话并签发 token
+	token, refreshToken, err := middleware.CreateSession(c, queries, user.UserID, user.Username)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -118,17 +118,6 @@ func HandleLogin(c *gin.Context) {
 		return
 	}
 
-	// 生成Refresh Token
-	refreshToken, err := middleware.GenerateRefreshToken(user.UserID, user.Username)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
-			"message": "生成RefreshToken失败",
-			"error":   err.Error(),
-		})
-		return
-	}
-
 	// 构建响应
 	response := LoginResponse{
 		UserId:        user.UserID,
@@ -1,6 +1,7 @@
 package authentic
 
 import (
+	"chatroombackend/api/websocketmsg"
 	sqlcdb "chatroombackend/db"
 	"chatroombackend/middleware"
 	"github.com/gin-gonic/gin"
@@ -27,21 +28,21 @@ func HandleLogout(c *gin.Context) {
 		return
 	}
 
-	err = queries.UpdateUserOnlineStatus(c.Request.Context(), sqlcdb.UpdateUserOnlineStatusParams{
-		UserID: userID,
-		OnlineStatus: sqlcdb.NullUserOnlineStatus{
-			UserOnlineStatus: sqlcdb.UserOnlineStatusOffline,
-			Valid:            true,
-		},
-	})
-	if err != nil {
+	// 吊销当前会话，使其签发的 token 立即失效
+	sessionID := c.GetString("sessionId")
+	if _, err := queries.RevokeUserSession(c.Request.Context(), sqlcdb.RevokeUserSessionParams{
+		SessionID: sessionID,
+		UserID:    userID,
+	}); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "更新用户在线状态失败",
+			"message": "吊销登录会话失败",
 			"error":   err.Error(),
 		})
 		return
 	}
+	// 在线状态由 WebSocket 断开流程维护：用户的最后一个连接断开时才设置离线，其他设备仍在线时保持在线
+	websocketmsg.CloseSessionConnections(userID, sessionID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
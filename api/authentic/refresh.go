@@ -1,14 +1,25 @@
 package authentic
 
 import (
+	"chatroombackend/api/websocketmsg"
 	"chatroombackend/middleware"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
 func HandleRefresh(c *gin.Context) {
-	refreshToken := c.Query("refresh_Token")
+	var req RefreshRequest
+	_ = c.ShouldBindJSON(&req)
+	refreshToken := req.RefreshToken
+	if refreshToken == "" {
+		refreshToken = c.Query("refresh_Token")
+	}
 	if refreshToken == "" {
 		refreshToken = c.PostForm("refresh_Token")
 	}
@@ -25,7 +36,8 @@ func HandleRefresh(c *gin.Context) {
 		})
 		return
 	}
-	claims, err := middleware.ParseToken(refreshToken)
+	// 仅接受 refresh token，access token 无法用于刷新
+	claims, err := middleware.ParseRefreshToken(refreshToken)
 	if err != nil {
 		c.JSON(http.StatusOK, gin.H{
 			"code":    401,
@@ -34,30 +46,49 @@ func HandleRefresh(c *gin.Context) {
 		})
 		return
 	}
-	_, err = middleware.GenerateToken(claims.UserID, claims.Username)
+
+	queries, err := middleware.GetQueriesFromContext(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
-			"message": "生成access_Token错误",
+			"message": "获取数据库连接失败",
 			"error":   err.Error(),
 		})
 		return
 	}
-	newRefreshToken, err := middleware.GenerateRefreshToken(claims.UserID, claims.Username)
+
+	// 一次性轮换：旧 refresh token 立即失效，重放时吊销整个会话
+	token, newRefreshToken, err := middleware.RotateSession(c.Request.Context(), queries, claims)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
-			"message": "生成refresh_Token错误",
-			"error":   err.Error(),
-		})
+		switch {
+		case errors.Is(err, middleware.ErrRefreshTokenReused):
+			websocketmsg.CloseSessionConnections(claims.UserID, claims.SessionID)
+			c.JSON(http.StatusOK, gin.H{
+				"code":    401,
+				"message": "refresh_Token已被使用，会话已注销，请重新登录",
+			})
+		case errors.Is(err, middleware.ErrSessionRevoked):
+			c.JSON(http.StatusOK, gin.H{
+				"code":    401,
+				"message": "登录已失效，请重新登录",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "刷新Token失败",
+				"error":   err.Error(),
+			})
+		}
 		return
 	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "刷新成功",
 		"data": gin.H{
-			"token":     newRefreshToken,
-			"expiresIn": 86400,
+			"token":        token,
+			"refreshToken": newRefreshToken,
+			"expiresIn":    middleware.JWTExpireHours * 3600,
 		},
 	})
 }
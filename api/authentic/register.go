@@ -156,8 +156,28 @@ func HandleRegister(c *gin.Context) {
 		},
 	}
 
-	// 创建用户
-	user, err := queries.CreateUser(c.Request.Context(), createParams)
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 创建用户及其默认设置
+	var user sqlcdb.User
+	err = middleware.WithTransaction(c.Request.Context(), db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		var txErr error
+		user, txErr = qtx.CreateUser(c.Request.Context(), createParams)
+		if txErr != nil {
+			return txErr
+		}
+		_, txErr = qtx.CreateUserSettings(c.Request.Context(), user.UserID)
+		return txErr
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -182,8 +202,8 @@ func HandleRegister(c *gin.Context) {
 		RegisterTime:  user.RegisteredAt,
 	}
 
-	// 生成JWT token
-	token, err := middleware.GenerateToken(user.UserID, user.Username)
+	// 创建登录会话并签发 token
+	token, refreshToken, err := middleware.CreateSession(c, queries, user.UserID, user.Username)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -193,17 +213,6 @@ func HandleRegister(c *gin.Context) {
 		return
 	}
 
-	// 生成Refresh Token
-	refreshToken, err := middleware.GenerateRefreshToken(user.UserID, user.Username)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
-			"message": "生成RefreshToken失败",
-			"error":   err.Error(),
-		})
-		return
-	}
-
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "注册成功",
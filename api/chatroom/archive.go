@@ -0,0 +1,155 @@
+package chatroom
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/middleware"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// 状态未变化时回滚事务
+var errArchiveUnchanged = errors.New("room archive status unchanged")
+
+// HandleArchiveRoom 房主归档聊天室：归档后只读，不能发送消息和加入，不出现在公开列表中
+func HandleArchiveRoom(c *gin.Context) {
+	handleArchiveChange(c, true)
+}
+
+// HandleUnarchiveRoom 房主取消归档，恢复为正常聊天室
+func HandleUnarchiveRoom(c *gin.Context) {
+	handleArchiveChange(c, false)
+}
+
+func handleArchiveChange(c *gin.Context, archive bool) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	roomID := c.Param("roomid")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "聊天室ID不能为空",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	// 私聊会话没有房主，IsUserOwner 同样返回 false
+	isOwner, err := queries.IsUserOwner(ctx, sqlcdb.IsUserOwnerParams{UserID: currentUserID, RoomID: roomID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "检查成员角色失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if !isOwner {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "只有房主可以归档聊天室",
+		})
+		return
+	}
+
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	operationType := "archive_room"
+	if !archive {
+		operationType = "unarchive_room"
+	}
+
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		var affected int64
+		var txErr error
+		if archive {
+			affected, txErr = qtx.ArchiveChatroom(ctx, roomID)
+		} else {
+			affected, txErr = qtx.UnarchiveChatroom(ctx, roomID)
+		}
+		if txErr != nil {
+			return txErr
+		}
+		if affected == 0 {
+			return errArchiveUnchanged
+		}
+
+		details, _ := json.Marshal(map[string]bool{"archived": archive})
+		_, txErr = qtx.CreateAdminLog(ctx, sqlcdb.CreateAdminLogParams{
+			OperatorUserID: sql.NullString{String: currentUserID, Valid: true},
+			OperationType:  operationType,
+			Details:        pqtype.NullRawMessage{RawMessage: details, Valid: true},
+			IsGlobal:       false,
+			RelatedRoomID:  sql.NullString{String: roomID, Valid: true},
+		})
+		return txErr
+	})
+	if err != nil {
+		if errors.Is(err, errArchiveUnchanged) {
+			message := "聊天室已归档或不存在"
+			if !archive {
+				message = "聊天室未归档"
+			}
+			c.JSON(http.StatusConflict, gin.H{
+				"code":    409,
+				"message": message,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "更新聊天室状态失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Chatroom", fmt.Sprintf("Room %s archived=%t by user %s", roomID, archive, currentUserID))
+	websocketmsg.NotifyRoomArchived(roomID, currentUserID, archive)
+
+	message := "聊天室已归档"
+	if !archive {
+		message = "已取消归档"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": message,
+		"data": gin.H{
+			"roomId":   roomID,
+			"archived": archive,
+		},
+	})
+}
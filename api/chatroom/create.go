@@ -11,11 +11,12 @@ import (
 )
 
 type CreateChatRoomRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
-	Icon        string `json:"icon"`
-	Type        string `json:"type" binding:"required,oneof=public private protected"`
-	Password    string `json:"password"`
+	Name            string `json:"name" binding:"required"`
+	Description     string `json:"description"`
+	Icon            string `json:"icon"`
+	Type            string `json:"type" binding:"required,oneof=public private protected"`
+	Password        string `json:"password"`
+	RequireApproval bool   `json:"requireApproval"` // 加入是否需要管理员审核
 }
 
 type CreateChatRoomResponse struct {
@@ -29,6 +30,7 @@ type CreateChatRoomResponse struct {
 	PeopleCount     int32     `json:"peopleCount"`
 	CreatedTime     time.Time `json:"createdTime"`
 	LastMessageTime time.Time `json:"lastMessageTime"`
+	RequireApproval bool      `json:"requireApproval"`
 }
 
 type MemberInfoResponse struct {
@@ -106,11 +108,16 @@ func HandleCreateRoom(c *gin.Context) {
 			String: req.Icon,
 			Valid:  req.Icon != "",
 		},
-		RoomType: roomType,
-		AccessPassword: sql.NullString{
-			String: req.Password,
-			Valid:  req.Password != "",
-		},
+		RoomType:        roomType,
+		RequireApproval: req.RequireApproval,
+	}
+	// 访问密码只保存 bcrypt 哈希
+	if req.Password != "" {
+		hashed, ok := hashRoomPassword(c, req.Password)
+		if !ok {
+			return
+		}
+		createParams.AccessPassword = hashed
 	}
 
 	// 创建聊天室
@@ -165,6 +172,7 @@ func HandleCreateRoom(c *gin.Context) {
 			}
 			return chatroom.CreatedAt
 		}(),
+		RequireApproval: chatroom.RequireApproval,
 	}
 
 	memberInfo := MemberInfoResponse{
@@ -1,6 +1,7 @@
 package chatroom
 
 import (
+	sqlcdb "chatroombackend/db"
 	"chatroombackend/middleware"
 	"database/sql"
 	"errors"
@@ -21,6 +22,9 @@ type ChatRoomInfoResponse struct {
 	PeopleCount     int32     `json:"peopleCount"`
 	CreatedTime     time.Time `json:"createdTime"`
 	LastMessageTime time.Time `json:"lastMessageTime"`
+	PinnedCount     int64     `json:"pinnedCount"`
+	RequireApproval bool      `json:"requireApproval"` // 加入是否需要管理员审核
+	Archived        bool      `json:"archived"`        // 已归档的聊天室只读
 }
 
 func HandleGetRoomInfo(c *gin.Context) {
@@ -63,6 +67,15 @@ func HandleGetRoomInfo(c *gin.Context) {
 		return
 	}
 
+	// 私聊会话不对外公开
+	if chatroom.RoomType == sqlcdb.ChatroomTypeDirect {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "聊天室不存在",
+		})
+		return
+	}
+
 	// 获取房主信息
 	owner, err := queries.GetChatroomOwner(c.Request.Context(), roomId)
 	creatorId := ""
@@ -70,6 +83,17 @@ func HandleGetRoomInfo(c *gin.Context) {
 		creatorId = owner.UserID
 	}
 
+	// 获取置顶消息数量
+	pinnedCount, err := queries.CountPinnedMessages(c.Request.Context(), roomId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询置顶消息失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
 	// 转换聊天室类型为前端格式
 	var roomType string
 	switch chatroom.RoomType {
@@ -100,6 +124,9 @@ func HandleGetRoomInfo(c *gin.Context) {
 			}
 			return chatroom.CreatedAt
 		}(),
+		PinnedCount:     pinnedCount,
+		RequireApproval: chatroom.RequireApproval,
+		Archived:        chatroom.RoomStatus == sqlcdb.ChatroomStatusArchived,
 	}
 
 	c.JSON(http.StatusOK, gin.H{
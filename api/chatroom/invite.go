@@ -0,0 +1,669 @@
+package chatroom
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/middleware"
+	"chatroombackend/utils"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// errInviteUnavailable 邀请在校验之后被撤销、过期或用尽（并发使用），回滚本次加入
+var errInviteUnavailable = errors.New("invite no longer available")
+
+type CreateInviteRequest struct {
+	Role      string `json:"role" binding:"omitempty,oneof=member admin"` // 加入后的角色，默认 member；admin 仅房主可创建
+	MaxUses   int32  `json:"maxUses" binding:"min=0,max=1000"`            // 最大使用次数，0 表示不限
+	ExpiresIn int64  `json:"expiresIn" binding:"min=0,max=2592000"`       // 有效期（秒），0 表示永不过期，最长30天
+}
+
+type RoomInviteResponse struct {
+	Code        string     `json:"code"`
+	RoomId      string     `json:"roomId"`
+	Role        string     `json:"role"`
+	MaxUses     int32      `json:"maxUses"`   // 0 表示不限
+	UseCount    int32      `json:"useCount"`  // 已使用次数
+	ExpiresAt   *time.Time `json:"expiresAt"` // null 表示永不过期
+	Status      string     `json:"status"`    // active | revoked | expired | exhausted
+	CreatorId   string     `json:"creatorId"`
+	CreatorName string     `json:"creatorName,omitempty"`
+	CreatedTime time.Time  `json:"createdTime"`
+	RevokedTime *time.Time `json:"revokedTime,omitempty"`
+}
+
+type InvitePreviewResponse struct {
+	Code        string     `json:"code"`
+	Role        string     `json:"role"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+	RoomId      string     `json:"roomId"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Icon        string     `json:"icon"`
+	Type        string     `json:"type"`
+	PeopleCount int32      `json:"peopleCount"`
+}
+
+// inviteStatus 邀请的当前状态
+func inviteStatus(inv sqlcdb.RoomInvite) string {
+	switch {
+	case inv.RevokedAt.Valid:
+		return "revoked"
+	case inv.ExpiresAt.Valid && !inv.ExpiresAt.Time.After(time.Now()):
+		return "expired"
+	case inv.MaxUses.Valid && inv.UseCount >= inv.MaxUses.Int32:
+		return "exhausted"
+	default:
+		return "active"
+	}
+}
+
+// inviteUnavailableMessage 邀请不可用时返回提示信息，可用时返回空字符串
+func inviteUnavailableMessage(inv sqlcdb.RoomInvite) string {
+	switch inviteStatus(inv) {
+	case "revoked":
+		return "邀请已被撤销"
+	case "expired":
+		return "邀请已过期"
+	case "exhausted":
+		return "邀请使用次数已达上限"
+	default:
+		return ""
+	}
+}
+
+func roomInviteResponse(inv sqlcdb.RoomInvite, creatorName string) RoomInviteResponse {
+	r := RoomInviteResponse{
+		Code:        inv.InviteCode,
+		RoomId:      inv.RoomID,
+		Role:        string(inv.MemberRole),
+		MaxUses:     inv.MaxUses.Int32,
+		UseCount:    inv.UseCount,
+		Status:      inviteStatus(inv),
+		CreatorId:   inv.CreatedBy.String,
+		CreatorName: creatorName,
+		CreatedTime: inv.CreatedAt,
+	}
+	if inv.ExpiresAt.Valid {
+		r.ExpiresAt = &inv.ExpiresAt.Time
+	}
+	if inv.RevokedAt.Valid {
+		r.RevokedTime = &inv.RevokedAt.Time
+	}
+	return r
+}
+
+// checkInviteManager 检查聊天室存在、不是私聊会话，且当前用户为管理员或房主；失败时已写入响应
+func checkInviteManager(c *gin.Context, queries *sqlcdb.Queries, roomID, userID string) bool {
+	ctx := c.Request.Context()
+	room, err := queries.GetChatroomByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": "聊天室不存在",
+			})
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询聊天室失败",
+			"error":   err.Error(),
+		})
+		return false
+	}
+	if room.RoomType == sqlcdb.ChatroomTypeDirect {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "私聊会话不能邀请成员",
+		})
+		return false
+	}
+
+	isAdmin, err := queries.IsUserAdminOrOwner(ctx, sqlcdb.IsUserAdminOrOwnerParams{UserID: userID, RoomID: roomID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "检查成员角色失败",
+			"error":   err.Error(),
+		})
+		return false
+	}
+	if !isAdmin {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "只有管理员和房主可以管理邀请",
+		})
+		return false
+	}
+	return true
+}
+
+// HandleCreateInvite 创建聊天室邀请码
+func HandleCreateInvite(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	roomID := c.Param("roomid")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "聊天室ID不能为空",
+		})
+		return
+	}
+
+	// 请求体可省略，全部使用默认值
+	var req CreateInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请求参数错误",
+			"error":   err.Error(),
+		})
+		return
+	}
+	role := sqlcdb.MemberRoleMember
+	if req.Role == "admin" {
+		role = sqlcdb.MemberRoleAdmin
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	if !checkInviteManager(c, queries, roomID, currentUserID) {
+		return
+	}
+	// 设置管理员仅房主可操作，以管理员身份加入的邀请同样只能由房主创建
+	if role == sqlcdb.MemberRoleAdmin {
+		isOwner, err := queries.IsUserOwner(ctx, sqlcdb.IsUserOwnerParams{UserID: currentUserID, RoomID: roomID})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "检查成员角色失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		if !isOwner {
+			c.JSON(http.StatusForbidden, gin.H{
+				"code":    403,
+				"message": "只有房主可以创建管理员邀请",
+			})
+			return
+		}
+	}
+
+	params := sqlcdb.CreateRoomInviteParams{
+		InviteCode: utils.GenerateInviteCode(),
+		RoomID:     roomID,
+		CreatedBy:  sql.NullString{String: currentUserID, Valid: true},
+		MemberRole: role,
+		MaxUses:    sql.NullInt32{Int32: req.MaxUses, Valid: req.MaxUses > 0},
+	}
+	if req.ExpiresIn > 0 {
+		params.ExpiresAt = sql.NullTime{Time: time.Now().Add(time.Duration(req.ExpiresIn) * time.Second), Valid: true}
+	}
+
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var invite sqlcdb.RoomInvite
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		var txErr error
+		invite, txErr = qtx.CreateRoomInvite(ctx, params)
+		if txErr != nil {
+			return txErr
+		}
+		resp := roomInviteResponse(invite, "")
+		details, _ := json.Marshal(map[string]any{
+			"inviteCode": resp.Code,
+			"role":       resp.Role,
+			"maxUses":    resp.MaxUses,
+			"expiresAt":  resp.ExpiresAt,
+		})
+		_, txErr = qtx.CreateAdminLog(ctx, sqlcdb.CreateAdminLogParams{
+			OperatorUserID: sql.NullString{String: currentUserID, Valid: true},
+			OperationType:  "create_invite",
+			Details:        pqtype.NullRawMessage{RawMessage: details, Valid: true},
+			IsGlobal:       false,
+			RelatedRoomID:  sql.NullString{String: roomID, Valid: true},
+		})
+		return txErr
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "创建邀请失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Invite", fmt.Sprintf("User %s created invite %s for room %s", currentUserID, invite.InviteCode, roomID))
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "创建成功",
+		"data":    roomInviteResponse(invite, ""),
+	})
+}
+
+// HandleListInvites 获取聊天室的邀请列表，all=true 时包含已撤销、过期和用尽的邀请
+func HandleListInvites(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	roomID := c.Param("roomid")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "聊天室ID不能为空",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if !checkInviteManager(c, queries, roomID, currentUserID) {
+		return
+	}
+
+	rows, err := queries.ListRoomInvites(c.Request.Context(), sqlcdb.ListRoomInvitesParams{
+		RoomID:          roomID,
+		IncludeInactive: c.Query("all") == "true",
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取邀请列表失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	invites := make([]RoomInviteResponse, 0, len(rows))
+	for _, r := range rows {
+		creatorName := r.Nickname.String
+		if creatorName == "" {
+			creatorName = r.Username.String
+		}
+		invites = append(invites, roomInviteResponse(sqlcdb.RoomInvite{
+			InviteCode: r.InviteCode,
+			RoomID:     r.RoomID,
+			CreatedBy:  r.CreatedBy,
+			MemberRole: r.MemberRole,
+			MaxUses:    r.MaxUses,
+			UseCount:   r.UseCount,
+			ExpiresAt:  r.ExpiresAt,
+			RevokedAt:  r.RevokedAt,
+			RevokedBy:  r.RevokedBy,
+			CreatedAt:  r.CreatedAt,
+		}, creatorName))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": gin.H{
+			"invites": invites,
+			"total":   len(invites),
+		},
+	})
+}
+
+// HandleRevokeInvite 撤销邀请，撤销后不能再使用，已通过该邀请加入的成员不受影响
+func HandleRevokeInvite(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	roomID := c.Param("roomid")
+	code := c.Param("code")
+	if roomID == "" || code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "聊天室ID和邀请码不能为空",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	if !checkInviteManager(c, queries, roomID, currentUserID) {
+		return
+	}
+
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	var invite sqlcdb.RoomInvite
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		var txErr error
+		invite, txErr = qtx.RevokeRoomInvite(ctx, sqlcdb.RevokeRoomInviteParams{
+			InviteCode: code,
+			RoomID:     roomID,
+			RevokedBy:  sql.NullString{String: currentUserID, Valid: true},
+		})
+		if txErr != nil {
+			return txErr
+		}
+		details, _ := json.Marshal(map[string]any{
+			"inviteCode": invite.InviteCode,
+			"useCount":   invite.UseCount,
+		})
+		_, txErr = qtx.CreateAdminLog(ctx, sqlcdb.CreateAdminLogParams{
+			OperatorUserID: sql.NullString{String: currentUserID, Valid: true},
+			OperationType:  "revoke_invite",
+			Details:        pqtype.NullRawMessage{RawMessage: details, Valid: true},
+			IsGlobal:       false,
+			RelatedRoomID:  sql.NullString{String: roomID, Valid: true},
+			RelatedUserID:  invite.CreatedBy,
+		})
+		return txErr
+	})
+	if err != nil {
+		// 邀请不存在、不属于该聊天室或已撤销
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": "邀请不存在或已撤销",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "撤销邀请失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Invite", fmt.Sprintf("User %s revoked invite %s of room %s", currentUserID, code, roomID))
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "邀请已撤销",
+		"data":    roomInviteResponse(invite, ""),
+	})
+}
+
+// HandleGetInvite 通过邀请码预览聊天室（不需要登录），用于邀请链接的落地页
+func HandleGetInvite(c *gin.Context) {
+	code := c.Param("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "邀请码不能为空",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	invite, err := queries.GetRoomInvite(ctx, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": "邀请码无效",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询邀请失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if msg := inviteUnavailableMessage(invite); msg != "" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": msg,
+		})
+		return
+	}
+
+	room, err := queries.GetChatroomWithoutPassword(ctx, invite.RoomID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询聊天室失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if room.RoomStatus == sqlcdb.ChatroomStatusArchived {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "聊天室已归档，无法加入",
+		})
+		return
+	}
+
+	// 转换聊天室类型为前端格式
+	var roomType string
+	switch room.RoomType {
+	case sqlcdb.ChatroomTypePublic:
+		roomType = "public"
+	case sqlcdb.ChatroomTypePrivateInviteOnly:
+		roomType = "private"
+	case sqlcdb.ChatroomTypePrivatePassword:
+		roomType = "protected"
+	default:
+		roomType = string(room.RoomType)
+	}
+
+	preview := InvitePreviewResponse{
+		Code:        invite.InviteCode,
+		Role:        string(invite.MemberRole),
+		RoomId:      room.RoomID,
+		Name:        room.RoomName,
+		Description: room.Description.String,
+		Icon:        room.IconUrl.String,
+		Type:        roomType,
+		PeopleCount: room.MemberCount,
+	}
+	if invite.ExpiresAt.Valid {
+		preview.ExpiresAt = &invite.ExpiresAt.Time
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data":    preview,
+	})
+}
+
+// joinByInvite 使用邀请码加入聊天室：无需密码，可加入仅邀请的聊天室，角色为邀请预设的角色。
+// 成员关系与管理日志记录邀请人；失败时已写入响应
+func joinByInvite(c *gin.Context, queries *sqlcdb.Queries, room sqlcdb.Chatroom, userID, code string) (sqlcdb.ChatroomMember, bool) {
+	ctx := c.Request.Context()
+
+	if room.RoomType == sqlcdb.ChatroomTypeDirect {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "私聊会话不能加入",
+		})
+		return sqlcdb.ChatroomMember{}, false
+	}
+
+	invite, err := queries.GetRoomInvite(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && invite.RoomID != room.RoomID) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "邀请码无效",
+		})
+		return sqlcdb.ChatroomMember{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询邀请失败",
+			"error":   err.Error(),
+		})
+		return sqlcdb.ChatroomMember{}, false
+	}
+	if msg := inviteUnavailableMessage(invite); msg != "" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": msg,
+		})
+		return sqlcdb.ChatroomMember{}, false
+	}
+	// 管理员邀请的创建者不再是房主时邀请失效
+	if invite.MemberRole == sqlcdb.MemberRoleAdmin && !inviterIsOwner(ctx, queries, invite) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "邀请已失效",
+		})
+		return sqlcdb.ChatroomMember{}, false
+	}
+
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return sqlcdb.ChatroomMember{}, false
+	}
+
+	// 使用次数、成员关系、成员计数与管理日志需在同一事务中完成
+	var member sqlcdb.ChatroomMember
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		redeemed, txErr := qtx.RedeemRoomInvite(ctx, sqlcdb.RedeemRoomInviteParams{InviteCode: code, RoomID: room.RoomID})
+		if errors.Is(txErr, sql.ErrNoRows) {
+			return errInviteUnavailable
+		}
+		if txErr != nil {
+			return txErr
+		}
+		member, txErr = joinMember(ctx, qtx, sqlcdb.JoinChatroomParams{
+			UserID:     userID,
+			RoomID:     room.RoomID,
+			MemberRole: redeemed.MemberRole,
+			InvitedBy:  redeemed.CreatedBy,
+		})
+		if txErr != nil {
+			return txErr
+		}
+		details, _ := json.Marshal(map[string]any{
+			"inviteCode": redeemed.InviteCode,
+			"role":       redeemed.MemberRole,
+		})
+		_, txErr = qtx.CreateAdminLog(ctx, sqlcdb.CreateAdminLogParams{
+			OperatorUserID: redeemed.CreatedBy,
+			OperationType:  "join_by_invite",
+			Details:        pqtype.NullRawMessage{RawMessage: details, Valid: true},
+			IsGlobal:       false,
+			RelatedRoomID:  sql.NullString{String: room.RoomID, Valid: true},
+			RelatedUserID:  sql.NullString{String: userID, Valid: true},
+		})
+		return txErr
+	})
+	if errors.Is(err, errInviteUnavailable) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "邀请已失效",
+		})
+		return sqlcdb.ChatroomMember{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "加入聊天室失败",
+			"error":   err.Error(),
+		})
+		return sqlcdb.ChatroomMember{}, false
+	}
+
+	logger.Info("Invite", fmt.Sprintf("User %s joined room %s with invite %s from user %s", userID, room.RoomID, code, invite.CreatedBy.String))
+	announceJoined(member)
+	return member, true
+}
+
+func inviterIsOwner(ctx context.Context, queries *sqlcdb.Queries, invite sqlcdb.RoomInvite) bool {
+	if !invite.CreatedBy.Valid {
+		return false
+	}
+	isOwner, err := queries.IsUserOwner(ctx, sqlcdb.IsUserOwnerParams{UserID: invite.CreatedBy.String, RoomID: invite.RoomID})
+	return err == nil && isOwner
+}
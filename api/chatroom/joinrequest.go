@@ -0,0 +1,431 @@
+package chatroom
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/middleware"
+	"chatroombackend/notify"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sqlc-dev/pqtype"
+)
+
+type JoinRequestResponse struct {
+	RequestId   string     `json:"requestId"`
+	RoomId      string     `json:"roomId"`
+	UserId      string     `json:"userId"`
+	Username    string     `json:"username,omitempty"`
+	Nickname    string     `json:"nickname,omitempty"`
+	Avatar      string     `json:"avatar,omitempty"`
+	Message     string     `json:"message"`
+	Status      string     `json:"status"` // pending | approved | rejected
+	CreatedTime time.Time  `json:"createdTime"`
+	HandledBy   string     `json:"handledBy,omitempty"`
+	HandledTime *time.Time `json:"handledTime,omitempty"`
+}
+
+type ProcessJoinRequestRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve reject"`
+}
+
+func joinRequestResponse(r sqlcdb.RoomJoinRequest) JoinRequestResponse {
+	resp := JoinRequestResponse{
+		RequestId:   r.RequestID,
+		RoomId:      r.RoomID,
+		UserId:      r.UserID,
+		Message:     r.Message.String,
+		Status:      r.Status,
+		CreatedTime: r.CreatedAt,
+		HandledBy:   r.HandledBy.String,
+	}
+	if r.HandledAt.Valid {
+		resp.HandledTime = &r.HandledAt.Time
+	}
+	return resp
+}
+
+// roomManagerIDs 获取聊天室房主与管理员的用户ID，用于推送加入申请事件
+func roomManagerIDs(ctx context.Context, queries *sqlcdb.Queries, roomID string) []string {
+	admins, err := queries.GetChatroomAdmins(ctx, roomID)
+	if err != nil {
+		logger.Error("JoinRequest", fmt.Sprintf("Failed to get admins of room %s", roomID), err)
+		return nil
+	}
+	ids := make([]string, 0, len(admins))
+	for _, a := range admins {
+		ids = append(ids, a.UserID)
+	}
+	return ids
+}
+
+// submitJoinRequest 提交加入申请并通知聊天室管理员；已有待处理申请时直接返回该申请
+func submitJoinRequest(c *gin.Context, queries *sqlcdb.Queries, room sqlcdb.Chatroom, userID, message string) {
+	ctx := c.Request.Context()
+
+	message = strings.TrimSpace(message)
+	if len([]rune(message)) > 200 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "申请附言不能超过200个字符",
+		})
+		return
+	}
+
+	request, err := queries.GetPendingJoinRequest(ctx, sqlcdb.GetPendingJoinRequestParams{RoomID: room.RoomID, UserID: userID})
+	created := false
+	if errors.Is(err, sql.ErrNoRows) {
+		request, err = queries.CreateJoinRequest(ctx, sqlcdb.CreateJoinRequestParams{
+			RoomID:  room.RoomID,
+			UserID:  userID,
+			Message: sql.NullString{String: message, Valid: message != ""},
+		})
+		if err != nil {
+			// 并发提交时唯一索引冲突，返回已创建的申请
+			if pending, getErr := queries.GetPendingJoinRequest(ctx, sqlcdb.GetPendingJoinRequestParams{RoomID: room.RoomID, UserID: userID}); getErr == nil {
+				request, err = pending, nil
+			}
+		} else {
+			created = true
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "提交加入申请失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if created {
+		logger.Info("JoinRequest", fmt.Sprintf("User %s requested to join room %s: %s", userID, room.RoomID, request.RequestID))
+		event := websocketmsg.JoinRequestEvent{
+			RequestID: request.RequestID,
+			RoomID:    room.RoomID,
+			RoomName:  room.RoomName,
+			UserID:    userID,
+			Message:   request.Message.String,
+			Status:    request.Status,
+		}
+		if applicant, err := queries.GetUserByID(ctx, userID); err == nil {
+			event.Username = applicant.Username
+			event.Nickname = applicant.Nickname.String
+			event.Avatar = applicant.AvatarUrl.String
+		}
+		websocketmsg.NotifyJoinRequest(roomManagerIDs(ctx, queries, room.RoomID), "new", event)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "已提交加入申请，请等待管理员审核",
+		"data": gin.H{
+			"pending": true,
+			"request": joinRequestResponse(request),
+		},
+	})
+}
+
+// HandleListJoinRequests 获取聊天室待处理的加入申请（最早的在前）
+func HandleListJoinRequests(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	roomID := c.Param("roomid")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "聊天室ID不能为空",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	isAdmin, err := queries.IsUserAdminOrOwner(ctx, sqlcdb.IsUserAdminOrOwnerParams{UserID: currentUserID, RoomID: roomID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "检查成员角色失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if !isAdmin {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "只有管理员和房主可以审核加入申请",
+		})
+		return
+	}
+
+	total, err := queries.CountPendingJoinRequests(ctx, roomID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取申请数量失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	rows, err := queries.ListPendingJoinRequests(ctx, sqlcdb.ListPendingJoinRequestsParams{
+		RoomID: roomID,
+		Limit:  int64(pageSize),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取申请列表失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	requests := make([]JoinRequestResponse, 0, len(rows))
+	for _, r := range rows {
+		requests = append(requests, JoinRequestResponse{
+			RequestId:   r.RequestID,
+			RoomId:      r.RoomID,
+			UserId:      r.UserID,
+			Username:    r.Username,
+			Nickname:    r.Nickname.String,
+			Avatar:      r.AvatarUrl.String,
+			Message:     r.Message.String,
+			Status:      r.Status,
+			CreatedTime: r.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": gin.H{
+			"requests": requests,
+			"total":    total,
+			"page":     page,
+			"pageSize": pageSize,
+		},
+	})
+}
+
+// HandleProcessJoinRequest 审核加入申请（通过/拒绝），通过后按正常流程加入聊天室
+func HandleProcessJoinRequest(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	roomID := c.Param("roomid")
+	requestID := c.Param("requestid")
+	if roomID == "" || requestID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "聊天室ID和申请ID不能为空",
+		})
+		return
+	}
+
+	var req ProcessJoinRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请求参数错误，action 可选值: approve|reject",
+			"error":   err.Error(),
+		})
+		return
+	}
+	approve := req.Action == "approve"
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	room, err := queries.GetChatroomByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": "聊天室不存在",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询聊天室失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	isAdmin, err := queries.IsUserAdminOrOwner(ctx, sqlcdb.IsUserAdminOrOwnerParams{UserID: currentUserID, RoomID: roomID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "检查成员角色失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if !isAdmin {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "只有管理员和房主可以审核加入申请",
+		})
+		return
+	}
+
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	status, operationType := "rejected", "reject_join_request"
+	if approve {
+		status, operationType = "approved", "approve_join_request"
+	}
+
+	// 更新申请状态、加入聊天室与管理日志需在同一事务中完成
+	var handled sqlcdb.RoomJoinRequest
+	var member sqlcdb.ChatroomMember
+	joined := false
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		var txErr error
+		handled, txErr = qtx.HandleJoinRequest(ctx, sqlcdb.HandleJoinRequestParams{
+			RequestID: requestID,
+			RoomID:    roomID,
+			Status:    status,
+			HandledBy: sql.NullString{String: currentUserID, Valid: true},
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		if approve {
+			// 申请人可能已通过邀请码加入，此时只更新申请状态
+			inRoom, txErr := qtx.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{UserID: handled.UserID, RoomID: roomID})
+			if txErr != nil {
+				return txErr
+			}
+			if !inRoom {
+				member, txErr = joinMember(ctx, qtx, sqlcdb.JoinChatroomParams{
+					UserID:     handled.UserID,
+					RoomID:     roomID,
+					MemberRole: sqlcdb.MemberRoleMember,
+				})
+				if txErr != nil {
+					return txErr
+				}
+				joined = true
+			}
+		}
+
+		details, _ := json.Marshal(map[string]string{"requestId": handled.RequestID})
+		_, txErr = qtx.CreateAdminLog(ctx, sqlcdb.CreateAdminLogParams{
+			OperatorUserID: sql.NullString{String: currentUserID, Valid: true},
+			OperationType:  operationType,
+			Details:        pqtype.NullRawMessage{RawMessage: details, Valid: true},
+			IsGlobal:       false,
+			RelatedRoomID:  sql.NullString{String: roomID, Valid: true},
+			RelatedUserID:  sql.NullString{String: handled.UserID, Valid: true},
+		})
+		return txErr
+	})
+	if err != nil {
+		// 申请不存在、不属于该聊天室或已处理
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": "加入申请不存在或已处理",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "处理加入申请失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	logger.Info("JoinRequest", fmt.Sprintf("User %s %s join request %s of user %s in room %s", currentUserID, status, requestID, handled.UserID, roomID))
+	if joined {
+		announceJoined(member)
+	}
+
+	// 通知申请人，并同步其他管理员的审核队列
+	event := websocketmsg.JoinRequestEvent{
+		RequestID: handled.RequestID,
+		RoomID:    roomID,
+		RoomName:  room.RoomName,
+		UserID:    handled.UserID,
+		Message:   handled.Message.String,
+		Status:    handled.Status,
+		HandledBy: currentUserID,
+	}
+	recipients := append(roomManagerIDs(ctx, queries, roomID), handled.UserID)
+	websocketmsg.NotifyJoinRequest(recipients, status, event)
+	notify.JoinRequestHandled(ctx, queries, handled, room)
+
+	message := "已拒绝加入申请"
+	if approve {
+		message = "已通过加入申请"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": message,
+		"data":    joinRequestResponse(handled),
+	})
+}
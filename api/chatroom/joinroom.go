@@ -1,8 +1,10 @@
 package chatroom
 
 import (
+	"chatroombackend/api/websocketmsg"
 	sqlcdb "chatroombackend/db"
 	"chatroombackend/middleware"
+	"context"
 	"database/sql"
 	"errors"
 	"net/http"
@@ -12,8 +14,10 @@ import (
 )
 
 type JoinChatRoomRequest struct {
-	RoomId   string `json:"roomId" binding:"required"`
-	Password string `json:"password"` // 仅protected类型需要
+	RoomId     string `json:"roomId" binding:"required"`
+	Password   string `json:"password"`   // 仅protected类型需要
+	InviteCode string `json:"inviteCode"` // 邀请码，使用邀请码加入时无需密码
+	Message    string `json:"message"`    // 加入申请的附言，仅需要审核的聊天室使用
 }
 
 type JoinChatRoomResponse struct {
@@ -67,10 +71,17 @@ func HandleJoinRoom(c *gin.Context) {
 		return
 	}
 
-	// 查询聊天室信息
+	// 查询聊天室信息（仅正常状态，已归档的聊天室不能加入）
 	chatroom, err := queries.GetChatroomByID(c.Request.Context(), roomId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if archived, _ := queries.IsChatroomArchived(c.Request.Context(), roomId); archived {
+				c.JSON(http.StatusForbidden, gin.H{
+					"code":    403,
+					"message": "聊天室已归档，无法加入",
+				})
+				return
+			}
 			c.JSON(http.StatusNotFound, gin.H{
 				"code":    404,
 				"message": "聊天室不存在",
@@ -106,6 +117,14 @@ func HandleJoinRoom(c *gin.Context) {
 		return
 	}
 
+	// 使用邀请码加入，可加入仅邀请的聊天室
+	if req.InviteCode != "" {
+		if member, ok := joinByInvite(c, queries, chatroom, currentUserID, req.InviteCode); ok {
+			respondJoined(c, queries, member)
+		}
+		return
+	}
+
 	// 根据聊天室类型验证
 	switch chatroom.RoomType {
 	case sqlcdb.ChatroomTypePrivateInviteOnly:
@@ -126,42 +145,50 @@ func HandleJoinRoom(c *gin.Context) {
 			return
 		}
 
-		// 验证密码
-		isValid, err := queries.VerifyChatroomPassword(c.Request.Context(), sqlcdb.VerifyChatroomPasswordParams{
-			RoomID: roomId,
-			AccessPassword: sql.NullString{
-				String: req.Password,
-				Valid:  true,
-			},
-		})
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"code":    500,
-				"message": "验证密码失败",
-				"error":   err.Error(),
-			})
-			return
-		}
-		if !isValid {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"code":    401,
-				"message": "密码错误",
-			})
+		// 验证密码（bcrypt 哈希），连续错误会被暂时锁定
+		if !checkRoomPassword(c, queries, chatroom, currentUserID, req.Password) {
 			return
 		}
 
+	case sqlcdb.ChatroomTypeDirect:
+		// 私聊会话固定两名成员，不允许加入
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "私聊会话不能加入",
+		})
+		return
+
 	case sqlcdb.ChatroomTypePublic:
 		// 公开聊天室，无需验证
 	}
 
-	// 加入聊天室（默认角色为member）
-	joinParams := sqlcdb.JoinChatroomParams{
-		UserID:     currentUserID,
-		RoomID:     roomId,
-		MemberRole: sqlcdb.MemberRoleMember,
+	// 需要审核的聊天室提交加入申请，由管理员或房主审核通过后加入
+	if chatroom.RequireApproval {
+		submitJoinRequest(c, queries, chatroom, currentUserID, req.Message)
+		return
 	}
 
-	member, err := queries.JoinChatroom(c.Request.Context(), joinParams)
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 加入聊天室（默认角色为member）
+	var member sqlcdb.ChatroomMember
+	err = middleware.WithTransaction(c.Request.Context(), db, func(tx *sql.Tx) error {
+		var txErr error
+		member, txErr = joinMember(c.Request.Context(), queries.WithTx(tx), sqlcdb.JoinChatroomParams{
+			UserID:     currentUserID,
+			RoomID:     roomId,
+			MemberRole: sqlcdb.MemberRoleMember,
+		})
+		return txErr
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -171,15 +198,30 @@ func HandleJoinRoom(c *gin.Context) {
 		return
 	}
 
-	// 增加聊天室成员计数
-	err = queries.IncrementChatroomMemberCount(c.Request.Context(), roomId)
+	announceJoined(member)
+	respondJoined(c, queries, member)
+}
+
+// joinMember 加入聊天室的公共流程：创建成员关系并增加成员计数。
+// 直接加入、邀请码加入与审核通过都经过这里，q 应为事务中的查询对象，提交后调用 announceJoined
+func joinMember(ctx context.Context, q *sqlcdb.Queries, params sqlcdb.JoinChatroomParams) (sqlcdb.ChatroomMember, error) {
+	member, err := q.JoinChatroom(ctx, params)
 	if err != nil {
-		// 记录日志但不影响响应
-		c.Error(err)
+		return member, err
 	}
+	return member, q.IncrementChatroomMemberCount(ctx, params.RoomID)
+}
+
+// announceJoined 为新成员订阅房间，并向聊天室广播成员加入（room_member / joined）
+func announceJoined(member sqlcdb.ChatroomMember) {
+	websocketmsg.SubscribeRoom(member.RoomID, member.UserID)
+	websocketmsg.NotifyRoomMemberChange(member.RoomID, member.UserID, "joined")
+}
 
+// respondJoined 返回加入后的聊天室与成员信息
+func respondJoined(c *gin.Context, queries *sqlcdb.Queries, member sqlcdb.ChatroomMember) {
 	// 重新查询聊天室信息以获取更新后的成员数
-	updatedChatroom, err := queries.GetChatroomByID(c.Request.Context(), roomId)
+	updatedChatroom, err := queries.GetChatroomByID(c.Request.Context(), member.RoomID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -11,7 +11,8 @@ import (
 )
 
 type LeaveChatRoomRequest struct {
-	RoomId string `json:"roomId" binding:"required"`
+	RoomId       string `json:"roomId" binding:"required"`
+	AutoTransfer bool   `json:"autoTransfer"` // 房主退出时自动转让给加入最早的管理员
 }
 
 func HandleLeaveRoom(c *gin.Context) {
@@ -47,7 +48,7 @@ func HandleLeaveRoom(c *gin.Context) {
 	}
 
 	// 检查聊天室是否存在
-	chatroom, err := queries.GetChatroomByID(c.Request.Context(), req.RoomId)
+	chatroom, err := queries.GetChatroomIncludingArchived(c.Request.Context(), req.RoomId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -64,6 +65,15 @@ func HandleLeaveRoom(c *gin.Context) {
 		return
 	}
 
+	// 私聊会话固定两名成员，不能退出
+	if chatroom.RoomType == sqlcdb.ChatroomTypeDirect {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "不能退出私聊会话",
+		})
+		return
+	}
+
 	// 检查用户是否在聊天室中
 	isInRoom, err := queries.IsUserInChatroom(c.Request.Context(), sqlcdb.IsUserInChatroomParams{
 		UserID: currentUserID,
@@ -99,12 +109,16 @@ func HandleLeaveRoom(c *gin.Context) {
 		return
 	}
 
-	// 房主不能直接退出聊天室，需要先转让房主或解散聊天室
+	// 房主不能直接退出聊天室，需要先转让房主或解散聊天室，或选择自动转让
 	if membership.MemberRole == sqlcdb.MemberRoleOwner {
-		c.JSON(http.StatusForbidden, gin.H{
-			"code":    403,
-			"message": "房主不能直接退出聊天室，请先转让房主权限或解散聊天室",
-		})
+		if !req.AutoTransfer {
+			c.JSON(http.StatusForbidden, gin.H{
+				"code":    403,
+				"message": "房主不能直接退出聊天室，请先转让房主权限或解散聊天室",
+			})
+			return
+		}
+		leaveAsOwner(c, queries, req.RoomId, currentUserID)
 		return
 	}
 
@@ -129,10 +143,55 @@ func HandleLeaveRoom(c *gin.Context) {
 		c.Error(err)
 	}
 
-	_ = chatroom // 避免未使用变量警告，后续可用于日志等
-
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
 		"message": "退出成功",
 	})
 }
+
+// leaveAsOwner 房主退出聊天室：在同一事务中转让给加入最早的管理员并退出
+func leaveAsOwner(c *gin.Context, queries *sqlcdb.Queries, roomID, userID string) {
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	var transfer OwnershipTransfer
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		var txErr error
+		transfer, txErr = leaveAndTransfer(ctx, queries.WithTx(tx), roomID, userID, TransferOnLeave)
+		return txErr
+	})
+	if err != nil {
+		if errors.Is(err, errNoSuccessor) {
+			c.JSON(http.StatusConflict, gin.H{
+				"code":    409,
+				"message": "聊天室没有可接任的管理员，请先设置管理员或解散聊天室",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "退出聊天室失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	AnnounceOwnershipTransfer(ctx, queries, transfer)
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "退出成功，房主已转让",
+		"data": gin.H{
+			"roomId":  roomID,
+			"ownerId": transfer.ToUserID,
+		},
+	})
+}
@@ -0,0 +1,115 @@
+package chatroom
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// 统计窗口内允许的密码错误次数，达到后锁定
+	maxRoomPasswordFailures = 5
+	// 错误次数的统计窗口
+	roomPasswordWindow = 15 * time.Minute
+	// 锁定时长，期间该用户不能再尝试该聊天室的密码
+	roomPasswordLockout = 15 * time.Minute
+)
+
+// hashRoomPassword 使用 bcrypt 加密聊天室访问密码（与用户登录密码相同），失败时已写入响应
+func hashRoomPassword(c *gin.Context, password string) (sql.NullString, bool) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    400,
+				"message": "聊天室密码不能超过72个字节",
+			})
+			return sql.NullString{}, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "密码加密失败",
+			"error":   err.Error(),
+		})
+		return sql.NullString{}, false
+	}
+	return sql.NullString{String: string(hashed), Valid: true}, true
+}
+
+// checkRoomPassword 校验聊天室访问密码，并按聊天室与用户限制错误次数。
+// 校验前先原子地占用一次尝试次数，超过上限（含锁定期间）直接拒绝，并发请求无法绕过限制；
+// 校验成功后清除错误记录。校验失败时已写入响应
+func checkRoomPassword(c *gin.Context, queries *sqlcdb.Queries, room sqlcdb.Chatroom, userID, password string) bool {
+	ctx := c.Request.Context()
+
+	attempt, err := queries.ReserveRoomPasswordAttempt(ctx, sqlcdb.ReserveRoomPasswordAttemptParams{
+		RoomID:          room.RoomID,
+		UserID:          userID,
+		WindowStartedAt: time.Now().Add(-roomPasswordWindow),
+		MaxFailures:     maxRoomPasswordFailures,
+		LockedUntil:     sql.NullTime{Time: time.Now().Add(roomPasswordLockout), Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "验证密码失败",
+			"error":   err.Error(),
+		})
+		return false
+	}
+	if attempt.FailedCount > maxRoomPasswordFailures {
+		respondPasswordLocked(c, attempt.LockedUntil.Time)
+		return false
+	}
+
+	// 未设置密码时哈希为空，比较必然失败，视为密码错误
+	if bcrypt.CompareHashAndPassword([]byte(room.AccessPassword.String), []byte(password)) == nil {
+		if err := queries.ClearRoomPasswordAttempts(ctx, sqlcdb.ClearRoomPasswordAttemptsParams{RoomID: room.RoomID, UserID: userID}); err != nil {
+			logger.Error("Chatroom", fmt.Sprintf("Failed to clear password attempts of user %s in room %s", userID, room.RoomID), err)
+		}
+		return true
+	}
+
+	if attempt.FailedCount >= maxRoomPasswordFailures {
+		lockedUntil := time.Now().Add(roomPasswordLockout)
+		if err := queries.LockRoomPasswordAttempts(ctx, sqlcdb.LockRoomPasswordAttemptsParams{
+			RoomID:      room.RoomID,
+			UserID:      userID,
+			LockedUntil: sql.NullTime{Time: lockedUntil, Valid: true},
+		}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "验证密码失败",
+				"error":   err.Error(),
+			})
+			return false
+		}
+		logger.Warn("Chatroom", fmt.Sprintf("User %s locked out of room %s after %d wrong passwords", userID, room.RoomID, attempt.FailedCount))
+		respondPasswordLocked(c, lockedUntil)
+		return false
+	}
+
+	c.JSON(http.StatusUnauthorized, gin.H{
+		"code":    401,
+		"message": fmt.Sprintf("密码错误，还可尝试%d次", maxRoomPasswordFailures-attempt.FailedCount),
+	})
+	return false
+}
+
+func respondPasswordLocked(c *gin.Context, lockedUntil time.Time) {
+	minutes := int(time.Until(lockedUntil).Minutes()) + 1
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"code":    429,
+		"message": fmt.Sprintf("密码错误次数过多，请%d分钟后再试", minutes),
+		"data": gin.H{
+			"lockedUntil": lockedUntil.UTC(),
+		},
+	})
+}
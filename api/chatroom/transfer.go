@@ -0,0 +1,324 @@
+package chatroom
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/middleware"
+	"chatroombackend/notify"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sqlc-dev/pqtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 房主转让方式
+const (
+	TransferManual           = "manual"          // 房主主动转让
+	TransferOnLeave          = "leave"           // 房主退出聊天室，自动转让
+	TransferOnAccountDeleted = "account_deleted" // 房主注销账号，自动转让
+)
+
+var (
+	// 事务内的哨兵错误，用于回滚并返回对应的提示
+	errTransferTarget = errors.New("transfer target is not an active member")
+	errNoSuccessor    = errors.New("no admin to take over ownership")
+)
+
+// NoSuccessorError 注销账号时部分聊天室没有可接任的管理员
+type NoSuccessorError struct {
+	Rooms []sqlcdb.ListOwnedChatroomsRow
+}
+
+func (e *NoSuccessorError) Error() string {
+	ids := make([]string, 0, len(e.Rooms))
+	for _, r := range e.Rooms {
+		ids = append(ids, r.RoomID)
+	}
+	return "no admin to take over rooms: " + strings.Join(ids, ", ")
+}
+
+// OwnershipTransfer 一次房主转让，事务提交后用于广播和通知
+type OwnershipTransfer struct {
+	RoomID     string `json:"roomId"`
+	FromUserID string `json:"fromUserId"`
+	ToUserID   string `json:"toUserId"`
+	Mode       string `json:"mode"`
+}
+
+type TransferOwnershipRequest struct {
+	UserId   string `json:"userId" binding:"required"`   // 新房主
+	Password string `json:"password" binding:"required"` // 当前房主的登录密码，用于确认
+}
+
+// transferOwnership 在事务中转让房主（原房主降为管理员）并写入角色变更日志
+func transferOwnership(ctx context.Context, qtx *sqlcdb.Queries, t OwnershipTransfer) error {
+	affected, err := qtx.TransferOwnership(ctx, sqlcdb.TransferOwnershipParams{
+		UserID:   t.FromUserID,
+		UserID_2: t.ToUserID,
+		RoomID:   t.RoomID,
+	})
+	if err != nil {
+		return err
+	}
+	if affected != 2 {
+		return errTransferTarget
+	}
+
+	reason := "房主转让"
+	switch t.Mode {
+	case TransferOnLeave:
+		reason = "房主退出聊天室，自动转让给加入最早的管理员"
+	case TransferOnAccountDeleted:
+		reason = "房主注销账号，自动转让给加入最早的管理员"
+	}
+	details, _ := json.Marshal(map[string]string{
+		"fromUserId":      t.FromUserID,
+		"fromUserNewRole": string(sqlcdb.MemberRoleAdmin),
+		"toUserId":        t.ToUserID,
+		"toUserNewRole":   string(sqlcdb.MemberRoleOwner),
+		"mode":            t.Mode,
+	})
+	_, err = qtx.CreateRoleChangeLog(ctx, sqlcdb.CreateRoleChangeLogParams{
+		OperatorUserID: sql.NullString{String: t.FromUserID, Valid: true},
+		Reason:         sql.NullString{String: reason, Valid: true},
+		Details:        pqtype.NullRawMessage{RawMessage: details, Valid: true},
+		RelatedRoomID:  sql.NullString{String: t.RoomID, Valid: true},
+		RelatedUserID:  sql.NullString{String: t.ToUserID, Valid: true},
+	})
+	return err
+}
+
+// leaveAndTransfer 在事务中将房主转让给加入最早的管理员后退出聊天室；没有管理员时返回 errNoSuccessor
+func leaveAndTransfer(ctx context.Context, qtx *sqlcdb.Queries, roomID, userID, mode string) (OwnershipTransfer, error) {
+	t := OwnershipTransfer{RoomID: roomID, FromUserID: userID, Mode: mode}
+	successor, err := qtx.GetLongestTenuredAdmin(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return t, errNoSuccessor
+		}
+		return t, err
+	}
+	t.ToUserID = successor
+
+	if err := transferOwnership(ctx, qtx, t); err != nil {
+		return t, err
+	}
+	if err := qtx.LeaveChatroom(ctx, sqlcdb.LeaveChatroomParams{UserID: userID, RoomID: roomID}); err != nil {
+		return t, err
+	}
+	return t, qtx.DecrementChatroomMemberCount(ctx, roomID)
+}
+
+// HandOverOwnedRooms 注销账号时在事务中处理用户担任房主的所有聊天室：
+// 转让给各自加入最早的管理员并退出。任一聊天室没有管理员时返回 *NoSuccessorError，调用方应回滚
+func HandOverOwnedRooms(ctx context.Context, qtx *sqlcdb.Queries, userID string) ([]OwnershipTransfer, error) {
+	rooms, err := qtx.ListOwnedChatrooms(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]OwnershipTransfer, 0, len(rooms))
+	var orphaned []sqlcdb.ListOwnedChatroomsRow
+	for _, r := range rooms {
+		t, err := leaveAndTransfer(ctx, qtx, r.RoomID, userID, TransferOnAccountDeleted)
+		if errors.Is(err, errNoSuccessor) {
+			orphaned = append(orphaned, r)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, t)
+	}
+	if len(orphaned) > 0 {
+		return nil, &NoSuccessorError{Rooms: orphaned}
+	}
+	return transfers, nil
+}
+
+// AnnounceOwnershipTransfer 事务提交后向聊天室广播房主变更，并通知新旧房主
+func AnnounceOwnershipTransfer(ctx context.Context, queries *sqlcdb.Queries, t OwnershipTransfer) {
+	logger.Info("Chatroom", fmt.Sprintf("Ownership of room %s transferred from %s to %s (%s)", t.RoomID, t.FromUserID, t.ToUserID, t.Mode))
+	websocketmsg.NotifyOwnerChanged(t.RoomID, t.FromUserID, t.ToUserID, t.Mode)
+
+	room, err := queries.GetChatroomIncludingArchived(ctx, t.RoomID)
+	if err != nil {
+		room = sqlcdb.Chatroom{RoomID: t.RoomID}
+	}
+	notify.OwnershipTransferred(ctx, queries, room, t.FromUserID, t.ToUserID, t.Mode)
+}
+
+// HandleTransferOwnership 房主将聊天室转让给其他成员，需要重新输入登录密码确认
+func HandleTransferOwnership(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	roomID := c.Param("roomid")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "聊天室ID不能为空",
+		})
+		return
+	}
+
+	var req TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请求参数错误",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if req.UserId == currentUserID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "不能将房主转让给自己",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	room, err := queries.GetChatroomIncludingArchived(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": "聊天室不存在",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询聊天室失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if room.RoomType == sqlcdb.ChatroomTypeDirect {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "私聊会话没有房主",
+		})
+		return
+	}
+
+	isOwner, err := queries.IsUserOwner(ctx, sqlcdb.IsUserOwnerParams{UserID: currentUserID, RoomID: roomID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "检查成员角色失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if !isOwner {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "只有房主可以转让聊天室",
+		})
+		return
+	}
+
+	// 重新输入登录密码确认
+	owner, err := queries.GetUserByID(ctx, currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取用户信息失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(owner.HashedPassword), []byte(req.Password)); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "密码错误",
+		})
+		return
+	}
+
+	target, err := queries.GetUserByID(ctx, req.UserId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取用户信息失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if err != nil || target.AccountStatus.UserAccountStatus != sqlcdb.UserAccountStatusActive {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "目标用户不存在或账号不可用",
+		})
+		return
+	}
+
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	t := OwnershipTransfer{RoomID: roomID, FromUserID: currentUserID, ToUserID: req.UserId, Mode: TransferManual}
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		return transferOwnership(ctx, queries.WithTx(tx), t)
+	})
+	if err != nil {
+		if errors.Is(err, errTransferTarget) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    400,
+				"message": "目标用户不是该聊天室成员，或您已不是房主",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "转让房主失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	AnnounceOwnershipTransfer(ctx, queries, t)
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "转让成功",
+		"data": gin.H{
+			"roomId":   roomID,
+			"ownerId":  req.UserId,
+			"roomRole": string(sqlcdb.MemberRoleAdmin), // 原房主的新角色
+		},
+	})
+}
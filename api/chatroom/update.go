@@ -12,11 +12,12 @@ import (
 )
 
 type UpdateChatRoomRequest struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	Icon        *string `json:"icon"`
-	Type        *string `json:"type"`     // "public" | "private" | "protected"
-	Password    *string `json:"password"` // 可选
+	Name            *string `json:"name"`
+	Description     *string `json:"description"`
+	Icon            *string `json:"icon"`
+	Type            *string `json:"type"`            // "public" | "private" | "protected"
+	Password        *string `json:"password"`        // 可选
+	RequireApproval *bool   `json:"requireApproval"` // 加入是否需要管理员审核
 }
 
 type UpdateChatRoomResponse struct {
@@ -29,6 +30,7 @@ type UpdateChatRoomResponse struct {
 	PeopleCount     int32     `json:"peopleCount"`
 	CreatedTime     time.Time `json:"createdTime"`
 	LastMessageTime time.Time `json:"lastMessageTime"`
+	RequireApproval bool      `json:"requireApproval"`
 }
 
 func HandleUpdateRoom(c *gin.Context) {
@@ -158,7 +160,26 @@ func HandleUpdateRoom(c *gin.Context) {
 		updateParams.RoomType = roomType
 	}
 	if req.Password != nil {
-		updateParams.AccessPassword = sql.NullString{String: *req.Password, Valid: true}
+		hashed, ok := hashRoomPassword(c, *req.Password)
+		if !ok {
+			return
+		}
+		updateParams.AccessPassword = hashed
+	}
+
+	// 审核设置单独更新，需在 UpdateChatroom 之前以便返回最新值
+	if req.RequireApproval != nil {
+		if err := queries.SetChatroomRequireApproval(c.Request.Context(), sqlcdb.SetChatroomRequireApprovalParams{
+			RoomID:          roomId,
+			RequireApproval: *req.RequireApproval,
+		}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "更新审核设置失败",
+				"error":   err.Error(),
+			})
+			return
+		}
 	}
 
 	// 执行更新
@@ -201,6 +222,7 @@ func HandleUpdateRoom(c *gin.Context) {
 			}
 			return updatedRoom.CreatedAt
 		}(),
+		RequireApproval: updatedRoom.RequireApproval,
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -0,0 +1,81 @@
+package friend
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleDeleteFriend 删除好友（双向解除好友关系）
+func HandleDeleteFriend(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	friendID := c.Param("userid")
+	if friendID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "用户ID不能为空",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	isFriend, err := queries.IsFriend(ctx, sqlcdb.IsFriendParams{
+		UserID:   currentUserID,
+		FriendID: friendID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "检查好友关系失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if !isFriend {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "对方不是您的好友",
+		})
+		return
+	}
+
+	if err := queries.DeleteFriendship(ctx, sqlcdb.DeleteFriendshipParams{
+		UserID:   currentUserID,
+		FriendID: friendID,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "删除好友失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "已删除好友",
+		"data": gin.H{
+			"userId": friendID,
+		},
+	})
+}
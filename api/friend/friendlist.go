@@ -0,0 +1,171 @@
+package friend
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"chatroombackend/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FriendItem struct {
+	UserId      string    `json:"userId"`
+	Username    string    `json:"username"`
+	Nickname    string    `json:"nickname"`
+	Avatar      string    `json:"avatar"`
+	Bio         string    `json:"bio"`
+	Status      string    `json:"status"`
+	FriendSince time.Time `json:"friendSince"`
+}
+
+type FriendListResponse struct {
+	Friends  []FriendItem `json:"friends"`
+	Total    int64        `json:"total"`
+	Page     int          `json:"page"`
+	PageSize int          `json:"pageSize"`
+}
+
+// HandleListFriends 获取好友列表
+func HandleListFriends(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	// 解析分页参数
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	statusFilter := c.DefaultQuery("status", "all")
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	var onlineStatus sqlcdb.UserOnlineStatus
+	switch statusFilter {
+	case "all":
+	case "online":
+		onlineStatus = sqlcdb.UserOnlineStatusOnline
+	case "away":
+		onlineStatus = sqlcdb.UserOnlineStatusAway
+	case "offline":
+		onlineStatus = sqlcdb.UserOnlineStatusOffline
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "status参数无效，可选值: online|away|offline|all",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	var total int64
+	friends := make([]FriendItem, 0)
+
+	if onlineStatus == "" {
+		total, err = queries.CountFriends(ctx, currentUserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取好友数量失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		rows, err := queries.GetFriends(ctx, sqlcdb.GetFriendsParams{
+			UserID: currentUserID,
+			Limit:  int64(pageSize),
+			Offset: int64(offset),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取好友列表失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		for _, f := range rows {
+			friends = append(friends, FriendItem{
+				UserId:      f.FriendID,
+				Username:    f.Username,
+				Nickname:    f.Nickname.String,
+				Avatar:      f.AvatarUrl.String,
+				Bio:         f.Bio.String,
+				Status:      utils.VisibleOnlineStatus(string(f.OnlineStatus.UserOnlineStatus), f.ShowOnlineStatus),
+				FriendSince: f.FriendSince,
+			})
+		}
+	} else {
+		status := sqlcdb.NullUserOnlineStatus{UserOnlineStatus: onlineStatus, Valid: true}
+		total, err = queries.CountFriendsByStatus(ctx, sqlcdb.CountFriendsByStatusParams{
+			UserID:       currentUserID,
+			OnlineStatus: status,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取好友数量失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		rows, err := queries.GetFriendsByStatus(ctx, sqlcdb.GetFriendsByStatusParams{
+			UserID:       currentUserID,
+			OnlineStatus: status,
+			Limit:        int64(pageSize),
+			Offset:       int64(offset),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取好友列表失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		for _, f := range rows {
+			friends = append(friends, FriendItem{
+				UserId:      f.FriendID,
+				Username:    f.Username,
+				Nickname:    f.Nickname.String,
+				Avatar:      f.AvatarUrl.String,
+				Bio:         f.Bio.String,
+				Status:      utils.VisibleOnlineStatus(string(f.OnlineStatus.UserOnlineStatus), f.ShowOnlineStatus),
+				FriendSince: f.FriendSince,
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": FriendListResponse{
+			Friends:  friends,
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+		},
+	})
+}
@@ -0,0 +1,138 @@
+package friend
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"chatroombackend/notify"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HandleFriendRequestRequest struct {
+	Action string `json:"action" binding:"required,oneof=accept reject"`
+}
+
+// HandleProcessFriendRequest 处理好友请求（接受/拒绝）
+func HandleProcessFriendRequest(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	requestID := c.Param("requestid")
+	if requestID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "好友请求ID不能为空",
+		})
+		return
+	}
+
+	var req HandleFriendRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请求参数错误，action 可选值: accept|reject",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	var handled sqlcdb.FriendRequest
+	if req.Action == "accept" {
+		db, dbErr := middleware.GetDBFromContext(c)
+		if dbErr != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取数据库连接失败",
+				"error":   dbErr.Error(),
+			})
+			return
+		}
+		// 更新请求状态与建立双向好友关系需在同一事务中完成
+		err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+			qtx := queries.WithTx(tx)
+			var txErr error
+			handled, txErr = qtx.AcceptFriendRequest(ctx, sqlcdb.AcceptFriendRequestParams{
+				RequestID:  requestID,
+				ReceiverID: currentUserID,
+			})
+			if txErr != nil {
+				return txErr
+			}
+			return qtx.CreateFriendship(ctx, sqlcdb.CreateFriendshipParams{
+				UserID:   handled.SenderID,
+				FriendID: handled.ReceiverID,
+			})
+		})
+	} else {
+		handled, err = queries.RejectFriendRequest(ctx, sqlcdb.RejectFriendRequestParams{
+			RequestID:  requestID,
+			ReceiverID: currentUserID,
+		})
+	}
+	if err != nil {
+		// 请求不存在、不是发给当前用户的或已处理
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": "好友请求不存在或已处理",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "处理好友请求失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	message := "已拒绝好友请求"
+	if req.Action == "accept" {
+		message = "已接受好友请求"
+		// 通知请求发送者
+		if receiver, err := queries.GetUserByID(ctx, currentUserID); err == nil {
+			websocketmsg.NotifyFriendRequest(handled.SenderID, "accepted", websocketmsg.FriendRequestEvent{
+				RequestID:  handled.RequestID,
+				FromUserID: handled.SenderID,
+				ToUserID:   handled.ReceiverID,
+				UserID:     receiver.UserID,
+				Username:   receiver.Username,
+				Nickname:   receiver.Nickname.String,
+				Avatar:     receiver.AvatarUrl.String,
+				Status:     handled.Status,
+			})
+			notify.FriendRequestAccepted(ctx, queries, handled, receiver)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": message,
+		"data": gin.H{
+			"requestId": handled.RequestID,
+			"status":    handled.Status,
+			"userId":    handled.SenderID,
+		},
+	})
+}
@@ -0,0 +1,287 @@
+package friend
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"chatroombackend/utils"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 好友请求中对方用户的信息
+type FriendRequestUserInfo struct {
+	UserId   string `json:"userId"`
+	Username string `json:"username"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+	Status   string `json:"status"`
+}
+
+type FriendRequestItem struct {
+	RequestId  string                `json:"requestId"`
+	FromUserId string                `json:"fromUserId"`
+	ToUserId   string                `json:"toUserId"`
+	Message    string                `json:"message"`
+	Status     string                `json:"status"`
+	CreatedAt  time.Time             `json:"createdAt"`
+	HandledAt  *time.Time            `json:"handledAt"`
+	User       FriendRequestUserInfo `json:"user"`
+}
+
+type FriendRequestListResponse struct {
+	Requests []FriendRequestItem `json:"requests"`
+	Total    int64               `json:"total"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"pageSize"`
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if t.Valid {
+		return &t.Time
+	}
+	return nil
+}
+
+// parsePagination 解析分页参数
+func parsePagination(c *gin.Context) (page, pageSize, offset int) {
+	page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	return page, pageSize, (page - 1) * pageSize
+}
+
+// HandleListFriendRequests 获取好友请求列表
+func HandleListFriendRequests(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	requestType := c.DefaultQuery("type", "received")
+	if requestType != "received" && requestType != "sent" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "type参数无效，可选值: received|sent",
+		})
+		return
+	}
+	status := c.Query("status")
+	if status != "" && status != "pending" && status != "accepted" && status != "rejected" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "status参数无效，可选值: pending|accepted|rejected",
+		})
+		return
+	}
+	page, pageSize, offset := parsePagination(c)
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	var total int64
+	requests := make([]FriendRequestItem, 0)
+
+	if requestType == "received" {
+		total, err = queries.CountReceivedFriendRequests(ctx, sqlcdb.CountReceivedFriendRequestsParams{
+			ReceiverID: currentUserID,
+			Status:     status,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取好友请求数量失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		rows, err := queries.GetReceivedFriendRequests(ctx, sqlcdb.GetReceivedFriendRequestsParams{
+			ReceiverID: currentUserID,
+			Status:     status,
+			Limit:      int64(pageSize),
+			Offset:     int64(offset),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取好友请求列表失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		for _, r := range rows {
+			requests = append(requests, FriendRequestItem{
+				RequestId:  r.RequestID,
+				FromUserId: r.SenderID,
+				ToUserId:   r.ReceiverID,
+				Message:    r.Message.String,
+				Status:     r.Status,
+				CreatedAt:  r.CreatedAt,
+				HandledAt:  nullTimePtr(r.HandledAt),
+				User: FriendRequestUserInfo{
+					UserId:   r.SenderID,
+					Username: r.SenderUsername,
+					Nickname: r.SenderNickname.String,
+					Avatar:   r.SenderAvatar.String,
+					Status:   utils.VisibleOnlineStatus(string(r.SenderOnlineStatus.UserOnlineStatus), r.ShowOnlineStatus),
+				},
+			})
+		}
+	} else {
+		total, err = queries.CountSentFriendRequests(ctx, sqlcdb.CountSentFriendRequestsParams{
+			SenderID: currentUserID,
+			Status:   status,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取好友请求数量失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		rows, err := queries.GetSentFriendRequests(ctx, sqlcdb.GetSentFriendRequestsParams{
+			SenderID: currentUserID,
+			Status:   status,
+			Limit:    int64(pageSize),
+			Offset:   int64(offset),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取好友请求列表失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		for _, r := range rows {
+			requests = append(requests, FriendRequestItem{
+				RequestId:  r.RequestID,
+				FromUserId: r.SenderID,
+				ToUserId:   r.ReceiverID,
+				Message:    r.Message.String,
+				Status:     r.Status,
+				CreatedAt:  r.CreatedAt,
+				HandledAt:  nullTimePtr(r.HandledAt),
+				User: FriendRequestUserInfo{
+					UserId:   r.ReceiverID,
+					Username: r.ReceiverUsername,
+					Nickname: r.ReceiverNickname.String,
+					Avatar:   r.ReceiverAvatar.String,
+					Status:   utils.VisibleOnlineStatus(string(r.ReceiverOnlineStatus.UserOnlineStatus), r.ShowOnlineStatus),
+				},
+			})
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": FriendRequestListResponse{
+			Requests: requests,
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+		},
+	})
+}
+
+// HandleListPendingFriendRequests 获取待处理的好友请求（收到的）
+func HandleListPendingFriendRequests(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	page, pageSize, offset := parsePagination(c)
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	total, err := queries.CountPendingReceivedRequests(ctx, currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取好友请求数量失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	rows, err := queries.GetPendingReceivedRequests(ctx, sqlcdb.GetPendingReceivedRequestsParams{
+		ReceiverID: currentUserID,
+		Limit:      int64(pageSize),
+		Offset:     int64(offset),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取好友请求列表失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	requests := make([]FriendRequestItem, 0, len(rows))
+	for _, r := range rows {
+		requests = append(requests, FriendRequestItem{
+			RequestId:  r.RequestID,
+			FromUserId: r.SenderID,
+			ToUserId:   r.ReceiverID,
+			Message:    r.Message.String,
+			Status:     r.Status,
+			CreatedAt:  r.CreatedAt,
+			HandledAt:  nullTimePtr(r.HandledAt),
+			User: FriendRequestUserInfo{
+				UserId:   r.SenderID,
+				Username: r.SenderUsername,
+				Nickname: r.SenderNickname.String,
+				Avatar:   r.SenderAvatar.String,
+				Status:   utils.VisibleOnlineStatus(string(r.SenderOnlineStatus.UserOnlineStatus), r.ShowOnlineStatus),
+			},
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": FriendRequestListResponse{
+			Requests: requests,
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+		},
+	})
+}
@@ -0,0 +1,204 @@
+package friend
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"chatroombackend/notify"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+type SendFriendRequestRequest struct {
+	TargetUserId string `json:"targetUserId" binding:"required"`
+	Message      string `json:"message"`
+}
+
+// HandleSendFriendRequest 发送好友请求
+func HandleSendFriendRequest(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	var req SendFriendRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请求参数错误",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	req.Message = strings.TrimSpace(req.Message)
+	if len([]rune(req.Message)) > 200 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "验证消息不能超过200个字符",
+		})
+		return
+	}
+
+	if req.TargetUserId == currentUserID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "不能添加自己为好友",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	// 检查目标用户是否存在且状态正常
+	target, err := queries.GetUserByID(ctx, req.TargetUserId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": "用户不存在",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询用户失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if target.AccountStatus.Valid && target.AccountStatus.UserAccountStatus != sqlcdb.UserAccountStatusActive {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "该用户账号状态异常，无法添加好友",
+		})
+		return
+	}
+
+	// 检查是否已经是好友
+	isFriend, err := queries.IsFriend(ctx, sqlcdb.IsFriendParams{
+		UserID:   currentUserID,
+		FriendID: req.TargetUserId,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "检查好友关系失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if isFriend {
+		c.JSON(http.StatusConflict, gin.H{
+			"code":    409,
+			"message": "对方已经是您的好友",
+		})
+		return
+	}
+
+	// 检查双方之间是否已有待处理的请求
+	pending, err := queries.GetPendingRequestBetweenUsers(ctx, sqlcdb.GetPendingRequestBetweenUsersParams{
+		SenderID:   currentUserID,
+		ReceiverID: req.TargetUserId,
+	})
+	if err == nil {
+		respondPendingRequest(c, pending, currentUserID)
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "检查好友请求失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	request, err := queries.CreateFriendRequest(ctx, sqlcdb.CreateFriendRequestParams{
+		SenderID:   currentUserID,
+		ReceiverID: req.TargetUserId,
+		Message:    sql.NullString{String: req.Message, Valid: req.Message != ""},
+	})
+	// 并发发送时唯一索引兜底，返回与上面检查相同的 409
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		pending, err := queries.GetPendingRequestBetweenUsers(ctx, sqlcdb.GetPendingRequestBetweenUsersParams{
+			SenderID:   currentUserID,
+			ReceiverID: req.TargetUserId,
+		})
+		if err != nil {
+			c.JSON(http.StatusConflict, gin.H{
+				"code":    409,
+				"message": "已发送过好友请求，请等待对方处理",
+			})
+			return
+		}
+		respondPendingRequest(c, pending, currentUserID)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "发送好友请求失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 实时通知接收者
+	if sender, err := queries.GetUserByID(ctx, currentUserID); err == nil {
+		websocketmsg.NotifyFriendRequest(req.TargetUserId, "received", websocketmsg.FriendRequestEvent{
+			RequestID:  request.RequestID,
+			FromUserID: request.SenderID,
+			ToUserID:   request.ReceiverID,
+			UserID:     sender.UserID,
+			Username:   sender.Username,
+			Nickname:   sender.Nickname.String,
+			Avatar:     sender.AvatarUrl.String,
+			Message:    request.Message.String,
+			Status:     request.Status,
+		})
+		notify.FriendRequestReceived(ctx, queries, request, sender)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "好友请求已发送",
+		"data": gin.H{
+			"requestId": request.RequestID,
+		},
+	})
+}
+
+// respondPendingRequest 双方之间已有待处理的请求时返回 409，并附带该请求的编号
+func respondPendingRequest(c *gin.Context, pending sqlcdb.FriendRequest, currentUserID string) {
+	message := "已发送过好友请求，请等待对方处理"
+	if pending.SenderID != currentUserID {
+		message = "对方已向您发送好友请求，请直接处理"
+	}
+	c.JSON(http.StatusConflict, gin.H{
+		"code":    409,
+		"message": message,
+		"data": gin.H{
+			"requestId": pending.RequestID,
+		},
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"chatroombackend/api/websocketmsg"
 	sqlcdb "chatroombackend/db"
 	"chatroombackend/middleware"
+	"chatroombackend/notify"
 	"fmt"
 	"net/http"
 
@@ -42,11 +43,18 @@ func HandleKickRoomMember(c *gin.Context) {
 	}
 
 	// 检查房间存在
-	if _, err := queries.GetChatroomByID(c.Request.Context(), roomID); err != nil {
+	room, err := queries.GetChatroomByID(c.Request.Context(), roomID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "chatroom not found"})
 		return
 	}
 
+	// 私聊会话固定两名成员，不能踢出
+	if room.RoomType == sqlcdb.ChatroomTypeDirect {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "cannot kick members of a direct conversation"})
+		return
+	}
+
 	// 权限检查：必须是管理员或房主
 	isAdmin, err := queries.IsUserAdminOrOwner(c.Request.Context(), sqlcdb.IsUserAdminOrOwnerParams{UserID: currentUser, RoomID: roomID})
 	if err != nil || !isAdmin {
@@ -76,6 +84,7 @@ func HandleKickRoomMember(c *gin.Context) {
 
 	// WebSocket 通知: 通知被踢出用户
 	websocketmsg.NotifyUserKicked(member.UserID, roomID, req.Reason)
+	notify.MemberKicked(c.Request.Context(), queries, member.UserID, room, req.Reason, currentUser)
 
 	// WebSocket 通知: 向聊天室广播踢出消息
 	// 获取被踢出用户的昵称用于系统消息
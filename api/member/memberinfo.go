@@ -22,6 +22,7 @@ type MemberInfoResponse struct {
 	JoinedAt   time.Time  `json:"joinedAt"`
 	LastReadAt *time.Time `json:"lastReadAt"`
 	IsActive   bool       `json:"isActive"`
+	InvitedBy  string     `json:"invitedBy,omitempty"` // 通过邀请码加入时为邀请人ID
 }
 
 // HandleGetRoomMemberInfo 获取用户在聊天室的成员信息
@@ -68,7 +69,7 @@ func HandleGetRoomMemberInfo(c *gin.Context) {
 	}
 
 	// 检查聊天室是否存在
-	_, err = queries.GetChatroomByID(c.Request.Context(), roomId)
+	_, err = queries.GetChatroomIncludingArchived(c.Request.Context(), roomId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -163,7 +164,8 @@ func HandleGetRoomMemberInfo(c *gin.Context) {
 			}
 			return nil
 		}(),
-		IsActive: membership.IsActive,
+		IsActive:  membership.IsActive,
+		InvitedBy: membership.InvitedBy.String,
 	}
 
 	c.JSON(http.StatusOK, gin.H{
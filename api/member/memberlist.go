@@ -3,6 +3,7 @@ package member
 import (
 	sqlcdb "chatroombackend/db"
 	"chatroombackend/middleware"
+	"chatroombackend/utils"
 	"database/sql"
 	"errors"
 	"net/http"
@@ -95,7 +96,7 @@ func HandleListRoomMembers(c *gin.Context) {
 	}
 
 	// 检查聊天室是否存在
-	_, err = queries.GetChatroomByID(c.Request.Context(), roomId)
+	_, err = queries.GetChatroomIncludingArchived(c.Request.Context(), roomId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -164,7 +165,8 @@ func HandleListRoomMembers(c *gin.Context) {
 	onlineCount := int64(0)
 
 	for _, m := range members {
-		status := string(m.OnlineStatus.UserOnlineStatus)
+		// 成员关闭"显示在线状态"时按离线处理，过滤与在线人数统计同样适用
+		status := utils.VisibleOnlineStatus(string(m.OnlineStatus.UserOnlineStatus), m.ShowOnlineStatus)
 
 		// 根据状态过滤
 		if statusFilter != "all" {
@@ -4,6 +4,7 @@ import (
 	"chatroombackend/api/websocketmsg"
 	sqlcdb "chatroombackend/db"
 	"chatroombackend/middleware"
+	"chatroombackend/notify"
 	"database/sql"
 	"fmt"
 	"net/http"
@@ -45,7 +46,8 @@ func HandleMuteRoomMember(c *gin.Context) {
 	}
 
 	// 检查房间存在
-	if _, err := queries.GetChatroomByID(c.Request.Context(), roomID); err != nil {
+	room, err := queries.GetChatroomByID(c.Request.Context(), roomID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "chatroom not found"})
 		return
 	}
@@ -120,6 +122,7 @@ func HandleMuteRoomMember(c *gin.Context) {
 		duration = time.Duration(req.Duration) * time.Second
 	}
 	websocketmsg.NotifyUserMuted(member.UserID, roomID, duration)
+	notify.MemberMuted(c.Request.Context(), queries, member.UserID, room, muteUntil, req.Reason, currentUser)
 
 	// 获取被禁言用户的昵称用于系统消息
 	mutedUser, err := queries.GetUserByID(c.Request.Context(), member.UserID)
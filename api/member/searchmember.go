@@ -3,6 +3,7 @@ package member
 import (
 	sqlcdb "chatroombackend/db"
 	"chatroombackend/middleware"
+	"chatroombackend/utils"
 	"database/sql"
 	"errors"
 	"net/http"
@@ -81,7 +82,7 @@ func HandleSearchRoomMembers(c *gin.Context) {
 	}
 
 	// 检查聊天室是否存在
-	_, err = queries.GetChatroomByID(c.Request.Context(), roomId)
+	_, err = queries.GetChatroomIncludingArchived(c.Request.Context(), roomId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			c.JSON(http.StatusNotFound, gin.H{
@@ -157,7 +158,7 @@ func HandleSearchRoomMembers(c *gin.Context) {
 			Username:     m.Username,
 			Nickname:     m.Nickname.String,
 			Avatar:       m.AvatarUrl.String,
-			OnlineStatus: string(m.OnlineStatus.UserOnlineStatus),
+			OnlineStatus: utils.VisibleOnlineStatus(string(m.OnlineStatus.UserOnlineStatus), m.ShowOnlineStatus),
 		}
 		userList = append(userList, item)
 	}
@@ -3,6 +3,7 @@ package member
 import (
 	sqlcdb "chatroombackend/db"
 	"chatroombackend/middleware"
+	"chatroombackend/notify"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -38,6 +39,17 @@ func HandleSetAdminRoomMember(c *gin.Context) {
 		return
 	}
 
+	// 私聊会话没有管理员
+	isDirect, err := queries.IsDirectChatroom(c.Request.Context(), roomID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "db error", "error": err.Error()})
+		return
+	}
+	if isDirect {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "direct conversations have no admins"})
+		return
+	}
+
 	// 检查房主权限
 	isOwner, err := queries.IsUserOwner(c.Request.Context(), sqlcdb.IsUserOwnerParams{UserID: currentUser, RoomID: roomID})
 	if err != nil || !isOwner {
@@ -60,5 +72,12 @@ func HandleSetAdminRoomMember(c *gin.Context) {
 		return
 	}
 
+	// 通知被设置为管理员的成员
+	room, err := queries.GetChatroomByID(c.Request.Context(), roomID)
+	if err != nil {
+		room = sqlcdb.Chatroom{RoomID: roomID}
+	}
+	notify.AdminPromoted(c.Request.Context(), queries, member.UserID, room, currentUser)
+
 	c.JSON(http.StatusOK, gin.H{"code": 200, "message": "设置成功", "data": gin.H{"roomRole": "admin"}})
 }
@@ -4,7 +4,8 @@ import (
 	"chatroombackend/api/websocketmsg"
 	sqlcdb "chatroombackend/db"
 	"context"
-	"encoding/json"
+	"database/sql"
+	"errors"
 	"net/http"
 	"time"
 
@@ -40,6 +41,10 @@ func HandleDeleteMessage(c *gin.Context) {
 		return
 	}
 
+	if !ensureWritable(c, ctx, queries, roomID) {
+		return
+	}
+
 	// 获取原消息信息
 	originalMsg, err := queries.GetMessageByID(ctx, messageID)
 	if err != nil {
@@ -74,23 +79,18 @@ func HandleDeleteMessage(c *gin.Context) {
 	}
 
 	// 软删除消息（将内容置为系统提示）
-	_, err = queries.DeleteMessageSoft(ctx, messageID)
+	deletedMsg, err := queries.DeleteMessageSoft(ctx, messageID)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusConflict, gin.H{"code": 409, "message": "消息已被撤回"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "消息删除失败", "error": err.Error()})
 		return
 	}
 
 	// 通过 WebSocket 广播消息删除事件
-	wsMsg := websocketmsg.WSMessage{
-		Type:   "message",
-		Action: "delete",
-	}
-	wsData, _ := json.Marshal(gin.H{
-		"roomId":    roomID,
-		"messageId": messageID,
-	})
-	wsMsg.Data = wsData
-	websocketmsg.BroadcastToRoom(roomID, wsMsg)
+	websocketmsg.NotifyMessageDeleted(roomID, messageID, deletedMsg.DeletedAt.Time, deletedMsg.ChangeSeq)
 
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
@@ -0,0 +1,87 @@
+package messages
+
+import (
+	sqlcdb "chatroombackend/db"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetMessageEditHistory 处理获取消息修订历史请求 GET /chatroom/:roomid/messages/:messageid/edits
+// 仅聊天室管理员和房主可查看
+func HandleGetMessageEditHistory(c *gin.Context) {
+	roomID := c.Param("roomid")
+	messageID := c.Param("messageid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// 检查权限：是否是管理员或房主
+	memberInfo, err := queries.GetUserChatroomMembership(ctx, sqlcdb.GetUserChatroomMembershipParams{
+		UserID: userID.(string),
+		RoomID: roomID,
+	})
+	if err != nil || !memberInfo.IsActive {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "您不在该聊天室中"})
+		return
+	}
+	if memberInfo.MemberRole != sqlcdb.MemberRoleAdmin && memberInfo.MemberRole != sqlcdb.MemberRoleOwner {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "只有管理员可以查看编辑历史"})
+		return
+	}
+
+	msg, err := queries.GetMessageByID(ctx, messageID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "消息不存在"})
+		return
+	}
+	if msg.RoomID != roomID {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "消息不属于该聊天室"})
+		return
+	}
+
+	edits, err := queries.GetMessageEdits(ctx, messageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取编辑历史失败", "error": err.Error()})
+		return
+	}
+
+	revisions := make([]gin.H, 0, len(edits))
+	for _, e := range edits {
+		editorName := e.Nickname.String
+		if editorName == "" && e.Username.Valid {
+			editorName = e.Username.String
+		}
+		revisions = append(revisions, gin.H{
+			"revision":   e.Revision,
+			"oldText":    e.OldContent,
+			"newText":    e.NewContent,
+			"editorId":   e.EditedBy.String,
+			"editorName": editorName,
+			"editedAt":   e.EditedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 200,
+		"data": gin.H{
+			"messageId": msg.MessageID,
+			"text":      msg.Content,
+			"time":      msg.SentAt.UTC().Format(time.RFC3339),
+			"revisions": revisions,
+		},
+	})
+}
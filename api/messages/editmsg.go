@@ -3,15 +3,24 @@ package messages
 import (
 	"chatroombackend/api/websocketmsg"
 	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"chatroombackend/msgservice"
 	"context"
-	"encoding/json"
+	"database/sql"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 )
 
+// errEditRecalled 编辑与撤回并发，消息在事务内已被撤回
+var errEditRecalled = errors.New("message recalled during edit")
+
 // HandleEditMessage 处理编辑消息请求 POST /chatrooms/:roomid/messages/:messageid/edit
+// 编辑后的内容与发送消息使用相同的校验；编辑不会重新解析 @提及，也不会推送提及通知
 func HandleEditMessage(c *gin.Context) {
 	roomID := c.Param("roomid")
 	messageID := c.Param("messageid")
@@ -49,6 +58,10 @@ func HandleEditMessage(c *gin.Context) {
 		return
 	}
 
+	if !ensureWritable(c, ctx, queries, roomID) {
+		return
+	}
+
 	// 获取原消息信息
 	originalMsg, err := queries.GetMessageByID(ctx, messageID)
 	if err != nil {
@@ -62,6 +75,24 @@ func HandleEditMessage(c *gin.Context) {
 		return
 	}
 
+	// 已撤回的消息不能编辑
+	if originalMsg.DeletedAt.Valid {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "消息已被撤回，无法编辑"})
+		return
+	}
+
+	// 与发送消息相同的内容校验：文本消息不能为空或全为空白，长度不超过上限
+	req.Text = strings.TrimSpace(req.Text)
+	var validateErr *msgservice.Error
+	if errors.As(msgservice.Validate(msgservice.Input{
+		MessageType: string(originalMsg.MessageType),
+		Text:        req.Text,
+		MediaURL:    originalMsg.MediaUrl.String,
+	}), &validateErr) {
+		c.JSON(validateErr.Status, gin.H{"code": validateErr.Status, "message": validateErr.Message})
+		return
+	}
+
 	// 检查权限：是否是消息发送者或管理员
 	isOwner := originalMsg.SenderID.Valid && originalMsg.SenderID.String == userID.(string)
 	isAdmin := false
@@ -82,37 +113,66 @@ func HandleEditMessage(c *gin.Context) {
 		return
 	}
 
-	// 更新消息
-	updatedMsg, err := queries.UpdateMessage(ctx, sqlcdb.UpdateMessageParams{
-		MessageID: messageID,
-		Content:   req.Text,
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取数据库连接失败", "error": err.Error()})
+		return
+	}
+
+	// 先记录修订（保存编辑前内容），再更新消息；发送时间保持不变
+	// 两条语句都只作用于未撤回的消息，上面的检查之后被撤回时返回 409
+	var updatedMsg sqlcdb.Message
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		if _, txErr := qtx.CreateMessageEdit(ctx, sqlcdb.CreateMessageEditParams{
+			NewContent: req.Text,
+			EditedBy:   sql.NullString{String: userID.(string), Valid: true},
+			MessageID:  messageID,
+		}); txErr != nil {
+			if errors.Is(txErr, sql.ErrNoRows) {
+				return errEditRecalled
+			}
+			return txErr
+		}
+		var txErr error
+		updatedMsg, txErr = qtx.UpdateMessage(ctx, sqlcdb.UpdateMessageParams{
+			MessageID: messageID,
+			Content:   req.Text,
+		})
+		if errors.Is(txErr, sql.ErrNoRows) {
+			return errEditRecalled
+		}
+		return txErr
 	})
+	if errors.Is(err, errEditRecalled) {
+		c.JSON(http.StatusConflict, gin.H{"code": 409, "message": "消息已被撤回，无法编辑"})
+		return
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		c.JSON(http.StatusConflict, gin.H{"code": 409, "message": "消息正在被其他人编辑，请刷新后重试"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "消息编辑失败", "error": err.Error()})
 		return
 	}
 
+	editedAt := updatedMsg.EditedAt.Time
+
 	// 构建响应数据
 	responseData := gin.H{
 		"messageId": updatedMsg.MessageID,
 		"text":      updatedMsg.Content,
+		"time":      updatedMsg.SentAt.UTC().Format(time.RFC3339),
 		"isEdited":  true,
-		"editedAt":  updatedMsg.SentAt.UTC().Format(time.RFC3339),
+		"editedAt":  editedAt.UTC().Format(time.RFC3339),
+		"revision":  updatedMsg.EditCount,
+		"seq":       updatedMsg.ChangeSeq,
 	}
 
 	// 通过 WebSocket 广播消息编辑事件
-	wsMsg := websocketmsg.WSMessage{
-		Type:   "message",
-		Action: "edit",
-	}
-	wsData, _ := json.Marshal(gin.H{
-		"roomId":    roomID,
-		"messageId": messageID,
-		"newText":   req.Text,
-		"editedAt":  updatedMsg.SentAt.UTC().Format(time.RFC3339),
-	})
-	wsMsg.Data = wsData
-	websocketmsg.BroadcastToRoom(roomID, wsMsg)
+	websocketmsg.NotifyMessageEdited(roomID, messageID, updatedMsg.Content, editedAt, updatedMsg.EditCount, updatedMsg.ChangeSeq)
 
 	c.JSON(http.StatusOK, gin.H{
 		"code":    200,
@@ -2,6 +2,7 @@ package messages
 
 import (
 	sqlcdb "chatroombackend/db"
+	"chatroombackend/msgservice"
 	"context"
 	"database/sql"
 	"net/http"
@@ -87,18 +88,7 @@ func HandleGetMessageHistory(c *gin.Context) {
 
 		// 转换类型
 		for _, msg := range beforeMessages {
-			messages = append(messages, sqlcdb.GetMessagesByRoomRow{
-				MessageID:       msg.MessageID,
-				SentAt:          msg.SentAt,
-				Content:         msg.Content,
-				MessageType:     msg.MessageType,
-				QuotedMessageID: msg.QuotedMessageID,
-				SenderID:        msg.SenderID,
-				RoomID:          msg.RoomID,
-				Username:        msg.Username,
-				Nickname:        msg.Nickname,
-				AvatarUrl:       msg.AvatarUrl,
-			})
+			messages = append(messages, sqlcdb.GetMessagesByRoomRow(msg))
 		}
 	} else {
 		// 使用传统分页（page=1 返回最新消息）
@@ -121,6 +111,43 @@ func HandleGetMessageHistory(c *gin.Context) {
 	}
 
 	// 构建响应数据
+	messageList, err := buildMessageList(ctx, queries, userID.(string), messages)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取表情回应失败", "error": err.Error()})
+		return
+	}
+
+	hasMore := false
+	if beforeMsgID == "" {
+		hasMore = int64(page*pageSize) < total
+	} else {
+		hasMore = len(messages) >= pageSize
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 200,
+		"data": gin.H{
+			"messages": messageList,
+			"total":    total,
+			"page":     page,
+			"pageSize": pageSize,
+			"hasMore":  hasMore,
+		},
+	})
+}
+
+// buildMessageList 将消息行转换为响应格式，并附带表情回应汇总与话题信息
+func buildMessageList(ctx context.Context, queries *sqlcdb.Queries, userID string, messages []sqlcdb.GetMessagesByRoomRow) ([]gin.H, error) {
+	// 批量获取表情回应
+	messageIDs := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		messageIDs = append(messageIDs, msg.MessageID)
+	}
+	reactions, err := msgservice.ReactionSummaries(ctx, queries, userID, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	messageList := make([]gin.H, 0, len(messages))
 	for _, msg := range messages {
 		userName := msg.Nickname.String
@@ -129,45 +156,54 @@ func HandleGetMessageHistory(c *gin.Context) {
 		}
 
 		isOwn := false
-		if msg.SenderID.Valid && msg.SenderID.String == userID.(string) {
+		if msg.SenderID.Valid && msg.SenderID.String == userID {
 			isOwn = true
 		}
 
 		messageData := gin.H{
-			"messageId": msg.MessageID,
-			"roomId":    msg.RoomID,
-			"userId":    msg.SenderID.String,
-			"userName":  userName,
-			"type":      string(msg.MessageType),
-			"text":      msg.Content,
-			"time":      msg.SentAt.UTC().Format(time.RFC3339),
-			"isOwn":     isOwn,
-			"isEdited":  false,
-			"editedAt":  nil,
+			"messageId":  msg.MessageID,
+			"roomId":     msg.RoomID,
+			"userId":     msg.SenderID.String,
+			"userName":   userName,
+			"type":       string(msg.MessageType),
+			"text":       msg.Content,
+			"time":       msg.SentAt.UTC().Format(time.RFC3339),
+			"isOwn":      isOwn,
+			"isEdited":   msg.EditedAt.Valid,
+			"editedAt":   nil,
+			"isDeleted":  msg.DeletedAt.Valid,
+			"seq":        msg.Seq,
+			"changeSeq":  msg.ChangeSeq,
+			"reactions":  []msgservice.ReactionSummary{},
+			"replyCount": msg.ReplyCount,
+		}
+
+		if r, ok := reactions[msg.MessageID]; ok {
+			messageData["reactions"] = r
+		}
+
+		if msg.EditedAt.Valid {
+			messageData["editedAt"] = msg.EditedAt.Time.UTC().Format(time.RFC3339)
+		}
+
+		if msg.MediaUrl.Valid {
+			messageData["mediaUrl"] = msg.MediaUrl.String
 		}
 
 		if msg.QuotedMessageID.Valid && msg.QuotedMessageID.String != "" {
 			messageData["replyToMessageId"] = msg.QuotedMessageID.String
 		}
 
-		messageList = append(messageList, messageData)
-	}
+		if msg.LastReplyAt.Valid {
+			messageData["lastReplyAt"] = msg.LastReplyAt.Time.UTC().Format(time.RFC3339)
+		}
 
-	hasMore := false
-	if beforeMsgID == "" {
-		hasMore = int64(page*pageSize) < total
-	} else {
-		hasMore = len(messages) >= pageSize
-	}
+		if msg.ThreadRootID.Valid {
+			messageData["threadRootId"] = msg.ThreadRootID.String
+			messageData["alsoSendToRoom"] = msg.AlsoSendToRoom
+		}
 
-	c.JSON(http.StatusOK, gin.H{
-		"code": 200,
-		"data": gin.H{
-			"messages": messageList,
-			"total":    total,
-			"page":     page,
-			"pageSize": pageSize,
-			"hasMore":  hasMore,
-		},
-	})
+		messageList = append(messageList, messageData)
+	}
+	return messageList, nil
 }
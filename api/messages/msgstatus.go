@@ -0,0 +1,103 @@
+package messages
+
+import (
+	sqlcdb "chatroombackend/db"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetMessageStatus 处理获取消息投递状态请求 GET /chatroom/:roomid/messages/:messageid/status
+// 仅消息发送者、聊天室管理员和房主可查看
+func HandleGetMessageStatus(c *gin.Context) {
+	roomID := c.Param("roomid")
+	messageID := c.Param("messageid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	memberInfo, err := queries.GetUserChatroomMembership(ctx, sqlcdb.GetUserChatroomMembershipParams{
+		UserID: userID.(string),
+		RoomID: roomID,
+	})
+	if err != nil || !memberInfo.IsActive {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "您不在该聊天室中"})
+		return
+	}
+
+	msg, err := queries.GetMessageByID(ctx, messageID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "消息不存在"})
+		return
+	}
+	if msg.RoomID != roomID {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "消息不属于该聊天室"})
+		return
+	}
+
+	// 检查权限：是否是消息发送者、管理员或房主
+	isOwner := msg.SenderID.Valid && msg.SenderID.String == userID.(string)
+	isAdmin := memberInfo.MemberRole == sqlcdb.MemberRoleAdmin || memberInfo.MemberRole == sqlcdb.MemberRoleOwner
+	if !isOwner && !isAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "您没有权限查看此消息的投递状态"})
+		return
+	}
+
+	rows, err := queries.GetMessageReceipts(ctx, messageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取投递状态失败", "error": err.Error()})
+		return
+	}
+
+	summary := gin.H{
+		string(sqlcdb.MessageStatusSent):      0,
+		string(sqlcdb.MessageStatusDelivered): 0,
+		string(sqlcdb.MessageStatusRead):      0,
+	}
+	recipients := make([]gin.H, 0, len(rows))
+	for _, r := range rows {
+		if n, ok := summary[string(r.Status)].(int); ok {
+			summary[string(r.Status)] = n + 1
+		}
+		recipient := gin.H{
+			"userId":      r.UserID,
+			"username":    r.Username,
+			"nickname":    r.Nickname.String,
+			"avatar":      r.AvatarUrl.String,
+			"status":      string(r.Status),
+			"deliveredAt": nil,
+			"readAt":      nil,
+		}
+		if r.DeliveredAt.Valid {
+			recipient["deliveredAt"] = r.DeliveredAt.Time.UTC().Format(time.RFC3339)
+		}
+		if r.ReadAt.Valid {
+			recipient["readAt"] = r.ReadAt.Time.UTC().Format(time.RFC3339)
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": gin.H{
+			"messageId":  messageID,
+			"tracked":    len(rows) > 0,
+			"summary":    summary,
+			"recipients": recipients,
+		},
+	})
+}
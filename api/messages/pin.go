@@ -0,0 +1,243 @@
+package messages
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// 每个聊天室最多置顶的消息数
+const maxPinnedMessages = 50
+
+// HandleGetPinnedMessages 处理获取置顶消息请求 GET /chatroom/:roomid/pinned
+// 按置顶时间倒序返回，已撤回的消息不会出现在列表中
+func HandleGetPinnedMessages(c *gin.Context) {
+	roomID := c.Param("roomid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// 验证用户是否在聊天室中
+	inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{
+		UserID: userID.(string),
+		RoomID: roomID,
+	})
+	if err != nil || !inRoom {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "您不在该聊天室中"})
+		return
+	}
+
+	rows, err := queries.GetPinnedMessages(ctx, roomID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取置顶消息失败", "error": err.Error()})
+		return
+	}
+
+	msgs := make([]sqlcdb.GetMessagesByRoomRow, len(rows))
+	for i, r := range rows {
+		msgs[i] = sqlcdb.GetMessagesByRoomRow{
+			MessageID:       r.MessageID,
+			SentAt:          r.SentAt,
+			Content:         r.Content,
+			MessageType:     r.MessageType,
+			QuotedMessageID: r.QuotedMessageID,
+			SenderID:        r.SenderID,
+			RoomID:          r.RoomID,
+			EditedAt:        r.EditedAt,
+			EditCount:       r.EditCount,
+			MediaUrl:        r.MediaUrl,
+			Seq:             r.Seq,
+			ChangeSeq:       r.ChangeSeq,
+			DeletedAt:       r.DeletedAt,
+			ClientMsgID:     r.ClientMsgID,
+			ThreadRootID:    r.ThreadRootID,
+			AlsoSendToRoom:  r.AlsoSendToRoom,
+			ReplyCount:      r.ReplyCount,
+			LastReplyAt:     r.LastReplyAt,
+			Username:        r.Username,
+			Nickname:        r.Nickname,
+			AvatarUrl:       r.AvatarUrl,
+		}
+	}
+	messages, err := buildMessageList(ctx, queries, userID.(string), msgs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取置顶消息失败", "error": err.Error()})
+		return
+	}
+	for i, r := range rows {
+		messages[i]["pinnedBy"] = r.PinnedBy.String
+		messages[i]["pinnedAt"] = r.PinnedAt.UTC().Format(time.RFC3339)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": gin.H{
+			"messages": messages,
+			"total":    len(messages),
+		},
+	})
+}
+
+// HandlePinMessage 处理置顶消息请求 POST /chatroom/:roomid/messages/:messageid/pin
+func HandlePinMessage(c *gin.Context) {
+	handlePinChange(c, true)
+}
+
+// HandleUnpinMessage 处理取消置顶请求 POST /chatroom/:roomid/messages/:messageid/unpin
+func HandleUnpinMessage(c *gin.Context) {
+	handlePinChange(c, false)
+}
+
+// handlePinChange 置顶或取消置顶消息，仅管理员和房主可操作；变更与管理日志在同一事务中写入
+func handlePinChange(c *gin.Context, pin bool) {
+	roomID := c.Param("roomid")
+	messageID := c.Param("messageid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// 检查是否是管理员或房主
+	isAdmin, err := queries.IsUserAdminOrOwner(ctx, sqlcdb.IsUserAdminOrOwnerParams{
+		UserID: userID.(string),
+		RoomID: roomID,
+	})
+	if err != nil || !isAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "只有管理员和房主可以置顶消息"})
+		return
+	}
+
+	if !ensureWritable(c, ctx, queries, roomID) {
+		return
+	}
+
+	msg, err := queries.GetMessageByID(ctx, messageID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && msg.RoomID != roomID) {
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "消息不存在或不属于该聊天室"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取消息失败", "error": err.Error()})
+		return
+	}
+	if pin && msg.DeletedAt.Valid {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "消息已被撤回，无法置顶"})
+		return
+	}
+
+	if pin {
+		count, err := queries.CountPinnedMessages(ctx, roomID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "统计置顶消息失败", "error": err.Error()})
+			return
+		}
+		if count >= maxPinnedMessages {
+			c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": fmt.Sprintf("每个聊天室最多置顶%d条消息", maxPinnedMessages)})
+			return
+		}
+	}
+
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取数据库连接失败", "error": err.Error()})
+		return
+	}
+
+	operationType := "unpin_message"
+	if pin {
+		operationType = "pin_message"
+	}
+	details, _ := json.Marshal(map[string]string{"messageId": messageID})
+
+	changed := false
+	pinnedAt := time.Now()
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		if pin {
+			p, txErr := qtx.PinMessage(ctx, sqlcdb.PinMessageParams{
+				MessageID: messageID,
+				RoomID:    roomID,
+				PinnedBy:  sql.NullString{String: userID.(string), Valid: true},
+			})
+			if errors.Is(txErr, sql.ErrNoRows) {
+				return nil
+			}
+			if txErr != nil {
+				return txErr
+			}
+			pinnedAt = p.PinnedAt
+		} else {
+			n, txErr := qtx.UnpinMessage(ctx, sqlcdb.UnpinMessageParams{MessageID: messageID, RoomID: roomID})
+			if txErr != nil || n == 0 {
+				return txErr
+			}
+		}
+		changed = true
+
+		_, txErr := qtx.CreateAdminLog(ctx, sqlcdb.CreateAdminLogParams{
+			OperatorUserID: sql.NullString{String: userID.(string), Valid: true},
+			OperationType:  operationType,
+			Details:        pqtype.NullRawMessage{RawMessage: details, Valid: true},
+			IsGlobal:       false,
+			RelatedRoomID:  sql.NullString{String: roomID, Valid: true},
+			RelatedUserID:  msg.SenderID,
+		})
+		return txErr
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "操作失败", "error": err.Error()})
+		return
+	}
+
+	// 已置顶或未置顶时不重复记录与广播
+	if changed {
+		websocketmsg.NotifyMessagePinned(roomID, messageID, userID.(string), pin, pinnedAt)
+	}
+
+	data := gin.H{
+		"messageId": messageID,
+		"pinned":    pin,
+		"changed":   changed,
+	}
+	if pin && changed {
+		data["pinnedBy"] = userID.(string)
+		data["pinnedAt"] = pinnedAt.UTC().Format(time.RFC3339)
+	}
+	message := "取消置顶成功"
+	if pin {
+		message = "置顶成功"
+	}
+	c.JSON(http.StatusOK, gin.H{"code": 200, "message": message, "data": data})
+}
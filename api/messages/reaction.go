@@ -0,0 +1,166 @@
+package messages
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/msgservice"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleAddReaction 处理添加表情回应请求 POST /chatroom/:roomid/messages/:messageid/reactions
+func HandleAddReaction(c *gin.Context) {
+	handleReact(c, true)
+}
+
+// HandleRemoveReaction 处理取消表情回应请求 POST /chatroom/:roomid/messages/:messageid/reactions/remove
+func HandleRemoveReaction(c *gin.Context) {
+	handleReact(c, false)
+}
+
+func handleReact(c *gin.Context, add bool) {
+	roomID := c.Param("roomid")
+	messageID := c.Param("messageid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	var req struct {
+		Emoji string `json:"emoji" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "请求参数错误", "error": err.Error()})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	r, err := msgservice.React(ctx, queries, userID.(string), roomID, messageID, req.Emoji, add)
+	var reactErr *msgservice.Error
+	if errors.As(err, &reactErr) {
+		c.JSON(reactErr.Status, gin.H{"code": reactErr.Status, "message": reactErr.Message})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "表情回应失败", "error": err.Error()})
+		return
+	}
+
+	// 重复添加或取消未回应的表情不广播
+	if r.Changed {
+		websocketmsg.NotifyReaction(r)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "操作成功",
+		"data":    r,
+	})
+}
+
+// HandleGetReactions 处理获取消息表情回应列表请求 GET /chatroom/:roomid/messages/:messageid/reactions
+func HandleGetReactions(c *gin.Context) {
+	roomID := c.Param("roomid")
+	messageID := c.Param("messageid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// 验证用户是否在聊天室中
+	inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{
+		UserID: userID.(string),
+		RoomID: roomID,
+	})
+	if err != nil || !inRoom {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "您不在该聊天室中"})
+		return
+	}
+
+	msg, err := queries.GetMessageByID(ctx, messageID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "消息不存在"})
+		return
+	}
+	if msg.RoomID != roomID {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "消息不属于该聊天室"})
+		return
+	}
+
+	rows, err := queries.GetMessageReactions(ctx, messageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取表情回应失败", "error": err.Error()})
+		return
+	}
+
+	// 按表情分组，保持首次回应的顺序
+	groups := make([]gin.H, 0)
+	index := make(map[string]int)
+	for _, r := range rows {
+		i, ok := index[r.Emoji]
+		if !ok {
+			i = len(groups)
+			index[r.Emoji] = i
+			groups = append(groups, gin.H{"emoji": r.Emoji, "count": 0, "reacted": false, "users": []gin.H{}})
+		}
+		g := groups[i]
+		g["count"] = g["count"].(int) + 1
+		if r.UserID == userID.(string) {
+			g["reacted"] = true
+		}
+		g["users"] = append(g["users"].([]gin.H), gin.H{
+			"userId":    r.UserID,
+			"username":  r.Username,
+			"nickname":  r.Nickname.String,
+			"avatar":    r.AvatarUrl.String,
+			"reactedAt": r.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": gin.H{
+			"messageId": messageID,
+			"reactions": groups,
+		},
+	})
+}
+
+// ensureWritable 已归档的聊天室只读，不能编辑、撤回或置顶消息；失败时已写入响应
+func ensureWritable(c *gin.Context, ctx context.Context, queries *sqlcdb.Queries, roomID string) bool {
+	err := msgservice.CheckWritable(ctx, queries, roomID)
+	var svcErr *msgservice.Error
+	if errors.As(err, &svcErr) {
+		c.JSON(svcErr.Status, gin.H{"code": svcErr.Status, "message": svcErr.Message})
+		return false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "检查聊天室状态失败", "error": err.Error()})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+package messages
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleMarkMessagesRead 处理标记消息已读请求 POST /chatroom/:roomid/messages/read
+// 未指定 lastReadMessageId 时标记到聊天室最新一条消息
+func HandleMarkMessagesRead(c *gin.Context) {
+	roomID := c.Param("roomid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	var req struct {
+		LastReadMessageID string `json:"lastReadMessageId"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "请求参数错误", "error": err.Error()})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// 验证用户是否在聊天室中
+	inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{
+		UserID: userID.(string),
+		RoomID: roomID,
+	})
+	if err != nil || !inRoom {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "您不在该聊天室中"})
+		return
+	}
+
+	messageID := req.LastReadMessageID
+	if messageID == "" {
+		lastMsg, err := queries.GetLastMessageInRoom(ctx, roomID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// 聊天室暂无消息，无需更新
+			c.JSON(http.StatusOK, gin.H{"code": 200, "message": "标记成功", "data": gin.H{"roomId": roomID, "unread": 0}})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取最新消息失败", "error": err.Error()})
+			return
+		}
+		messageID = lastMsg.MessageID
+	}
+
+	// 已读位置只前进不后退；消息不属于该聊天室时不会更新
+	row, err := queries.UpdateMemberLastReadToMessage(ctx, sqlcdb.UpdateMemberLastReadToMessageParams{
+		MessageID: messageID,
+		UserID:    userID.(string),
+		RoomID:    roomID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "消息不存在或不属于该聊天室"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "标记已读失败", "error": err.Error()})
+		return
+	}
+
+	// 已读位置前进时才广播已读回执
+	if !row.LastReadAt.Valid || !row.LastReadAt.Time.After(row.SentAt) {
+		websocketmsg.NotifyReadReceipt(roomID, userID.(string), messageID, row.SentAt)
+		websocketmsg.MarkReceiptsRead(ctx, roomID, userID.(string), row.SentAt)
+	}
+
+	unread, err := queries.GetUnreadMessageCount(ctx, sqlcdb.GetUnreadMessageCountParams{
+		UserID: userID.(string),
+		RoomID: roomID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取未读消息数失败", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "标记成功",
+		"data": gin.H{
+			"roomId":            roomID,
+			"lastReadMessageId": messageID,
+			"lastReadAt":        row.LastReadAt.Time.UTC().Format(time.RFC3339),
+			"unread":            unread,
+		},
+	})
+}
+
+// HandleGetMessageReadBy 处理获取消息已读成员请求 GET /chatroom/:roomid/messages/:messageid/readby
+func HandleGetMessageReadBy(c *gin.Context) {
+	roomID := c.Param("roomid")
+	messageID := c.Param("messageid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// 验证用户是否在聊天室中
+	inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{
+		UserID: userID.(string),
+		RoomID: roomID,
+	})
+	if err != nil || !inRoom {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "您不在该聊天室中"})
+		return
+	}
+
+	msg, err := queries.GetMessageByID(ctx, messageID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "消息不存在"})
+		return
+	}
+	if msg.RoomID != roomID {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "消息不属于该聊天室"})
+		return
+	}
+
+	rows, err := queries.GetMessageReadBy(ctx, messageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取已读成员失败", "error": err.Error()})
+		return
+	}
+
+	readers := make([]gin.H, 0, len(rows))
+	for _, r := range rows {
+		readers = append(readers, gin.H{
+			"userId":   r.UserID,
+			"username": r.Username,
+			"nickname": r.Nickname.String,
+			"avatar":   r.AvatarUrl.String,
+			"readAt":   r.LastReadAt.Time.UTC().Format(time.RFC3339),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": gin.H{
+			"messageId": messageID,
+			"readBy":    readers,
+			"readCount": len(readers),
+		},
+	})
+}
@@ -0,0 +1,90 @@
+package messages
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/msgservice"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleSearchMessages 处理消息搜索请求 GET /chatroom/search
+// 在用户所在的所有聊天室中搜索，?q=<关键词>&roomId=&senderId=&type=&from=&to=&cursor=&limit=
+func HandleSearchMessages(c *gin.Context) {
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	opts := msgservice.SearchOptions{
+		Query:    c.Query("q"),
+		RoomID:   c.Query("roomId"),
+		SenderID: c.Query("senderId"),
+		Cursor:   c.Query("cursor"),
+	}
+	switch t := c.Query("type"); sqlcdb.MessageType(t) {
+	case "", sqlcdb.MessageTypeText, sqlcdb.MessageTypeImage, sqlcdb.MessageTypeFile:
+		opts.MessageType = t
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "type参数无效，可选值: text|image|file"})
+		return
+	}
+	var err error
+	if opts.From, err = parseSearchTime(c.Query("from"), false); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "from参数无效，格式为 2006-01-02 或 RFC3339"})
+		return
+	}
+	if opts.To, err = parseSearchTime(c.Query("to"), true); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "to参数无效，格式为 2006-01-02 或 RFC3339"})
+		return
+	}
+	opts.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || opts.Limit < 1 || opts.Limit > msgservice.MaxSearchLimit {
+		opts.Limit = 20
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	result, err := msgservice.Search(ctx, queries, userID.(string), opts)
+	var searchErr *msgservice.Error
+	if errors.As(err, &searchErr) {
+		c.JSON(searchErr.Status, gin.H{"code": searchErr.Status, "message": searchErr.Message})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "搜索消息失败", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "搜索成功",
+		"data":    result,
+	})
+}
+
+// parseSearchTime 解析日期（2006-01-02，按 UTC）或 RFC3339 时间；endOfDay 为 true 时日期取次日零点，使 to 包含当天
+func parseSearchTime(s string, endOfDay bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
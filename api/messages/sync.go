@@ -0,0 +1,56 @@
+package messages
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/msgservice"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleSyncMessages 处理消息同步请求 POST /chatroom/sync
+// 客户端断线重连后传入各聊天室已收到的最大序号，返回之后遗漏的新消息、编辑与撤回
+func HandleSyncMessages(c *gin.Context) {
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	var req struct {
+		Rooms       map[string]int64 `json:"rooms" binding:"required"` // roomId -> lastSeq
+		MessageSeqs map[string]int64 `json:"messageSeqs"`              // roomId -> 上一页返回的 messageSeq（分页时）
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "请求参数错误", "error": err.Error()})
+		return
+	}
+	if len(req.Rooms) > msgservice.MaxSyncRooms {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": fmt.Sprintf("单次最多同步%d个聊天室", msgservice.MaxSyncRooms)})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	rooms, err := msgservice.Sync(ctx, queries, userID.(string), req.Rooms, req.MessageSeqs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "同步消息失败", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "同步成功",
+		"data":    gin.H{"rooms": rooms},
+	})
+}
@@ -0,0 +1,165 @@
+package messages
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/msgservice"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleGetThread 处理获取话题请求 GET /chatroom/:roomid/messages/:messageid/thread
+// 返回根消息与按序号正序的回复，?afterSeq=<序号> 获取该序号之后的回复
+func HandleGetThread(c *gin.Context) {
+	roomID := c.Param("roomid")
+	messageID := c.Param("messageid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	afterSeq, err := strconv.ParseInt(c.DefaultQuery("afterSeq", "0"), 10, 64)
+	if err != nil || afterSeq < 0 {
+		afterSeq = 0
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pageSize", "50"))
+	if err != nil || pageSize < 1 || pageSize > 100 {
+		pageSize = 50
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	// 验证用户是否在聊天室中
+	inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{
+		UserID: userID.(string),
+		RoomID: roomID,
+	})
+	if err != nil || !inRoom {
+		c.JSON(http.StatusForbidden, gin.H{"code": 403, "message": "您不在该聊天室中"})
+		return
+	}
+
+	root, err := queries.GetMessageWithSender(ctx, messageID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && root.RoomID != roomID) {
+		c.JSON(http.StatusNotFound, gin.H{"code": 404, "message": "消息不存在或不属于该聊天室"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取消息失败", "error": err.Error()})
+		return
+	}
+	if root.ThreadRootID.Valid {
+		c.JSON(http.StatusBadRequest, gin.H{"code": 400, "message": "该消息是话题回复，请使用其根消息", "data": gin.H{"rootMessageId": root.ThreadRootID.String}})
+		return
+	}
+
+	replies, err := queries.GetThreadReplies(ctx, sqlcdb.GetThreadRepliesParams{
+		ThreadRootID: sql.NullString{String: messageID, Valid: true},
+		Seq:          afterSeq,
+		Limit:        int64(pageSize) + 1,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取话题回复失败", "error": err.Error()})
+		return
+	}
+	hasMore := len(replies) > pageSize
+	if hasMore {
+		replies = replies[:pageSize]
+	}
+
+	rows := []sqlcdb.GetMessagesByRoomRow{sqlcdb.GetMessagesByRoomRow(root)}
+	for _, r := range replies {
+		rows = append(rows, sqlcdb.GetMessagesByRoomRow(r))
+	}
+	list, err := buildMessageList(ctx, queries, userID.(string), rows)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "获取表情回应失败", "error": err.Error()})
+		return
+	}
+
+	following, err := queries.IsThreadFollower(ctx, sqlcdb.IsThreadFollowerParams{
+		RootMessageID: messageID,
+		UserID:        userID.(string),
+	})
+	if err != nil {
+		following = false
+	}
+
+	lastSeq := afterSeq
+	if len(replies) > 0 {
+		lastSeq = replies[len(replies)-1].Seq
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 200,
+		"data": gin.H{
+			"root":      list[0],
+			"replies":   list[1:],
+			"following": following,
+			"lastSeq":   lastSeq,
+			"hasMore":   hasMore,
+		},
+	})
+}
+
+// HandleFollowThread 处理关注话题请求 POST /chatroom/:roomid/messages/:messageid/thread/follow
+func HandleFollowThread(c *gin.Context) {
+	handleThreadFollow(c, true)
+}
+
+// HandleUnfollowThread 处理取消关注话题请求 POST /chatroom/:roomid/messages/:messageid/thread/unfollow
+func HandleUnfollowThread(c *gin.Context) {
+	handleThreadFollow(c, false)
+}
+
+func handleThreadFollow(c *gin.Context, follow bool) {
+	roomID := c.Param("roomid")
+	messageID := c.Param("messageid")
+	userID, exists := c.Get("userId")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "未授权"})
+		return
+	}
+
+	queries, ok := c.MustGet("queries").(*sqlcdb.Queries)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "数据库查询对象获取失败"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	rootID, err := msgservice.FollowThread(ctx, queries, userID.(string), roomID, messageID, follow)
+	var threadErr *msgservice.Error
+	if errors.As(err, &threadErr) {
+		c.JSON(threadErr.Status, gin.H{"code": threadErr.Status, "message": threadErr.Message})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "操作失败", "error": err.Error()})
+		return
+	}
+
+	websocketmsg.NotifyThreadFollow(userID.(string), roomID, rootID, follow)
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "操作成功",
+		"data":    gin.H{"rootMessageId": rootID, "following": follow},
+	})
+}
@@ -0,0 +1,135 @@
+package notification
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"chatroombackend/notify"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationListResponse struct {
+	Notifications []notify.Item `json:"notifications"`
+	Total         int64         `json:"total"`
+	UnreadCount   int64         `json:"unreadCount"`
+	Page          int           `json:"page"`
+	PageSize      int           `json:"pageSize"`
+}
+
+// HandleListNotifications 获取当前用户的通知列表
+func HandleListNotifications(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	// 解析过滤与分页参数
+	notificationType := c.DefaultQuery("type", "all")
+	switch notificationType {
+	case "all":
+		notificationType = ""
+	case notify.TypeFriend, notify.TypeChatroom, notify.TypeSystem:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "type参数无效，可选值: all|friend|chatroom|system",
+		})
+		return
+	}
+	status := c.DefaultQuery("status", "all")
+	switch status {
+	case "all":
+		status = ""
+	case "unread", "read":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "status参数无效，可选值: unread|read|all",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	total, err := queries.CountUserNotifications(ctx, sqlcdb.CountUserNotificationsParams{
+		ReceiverID:       currentUserID,
+		NotificationType: notificationType,
+		Status:           status,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取通知数量失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	unreadCount, err := queries.CountUnreadNotifications(ctx, currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取未读通知数量失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	rows, err := queries.GetUserNotifications(ctx, sqlcdb.GetUserNotificationsParams{
+		ReceiverID:       currentUserID,
+		NotificationType: notificationType,
+		Status:           status,
+		Limit:            int64(pageSize),
+		Offset:           int64(offset),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取通知列表失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	notifications := make([]notify.Item, 0, len(rows))
+	for _, n := range rows {
+		notifications = append(notifications, notify.ToItem(n))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": NotificationListResponse{
+			Notifications: notifications,
+			Total:         total,
+			UnreadCount:   unreadCount,
+			Page:          page,
+			PageSize:      pageSize,
+		},
+	})
+}
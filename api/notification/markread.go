@@ -0,0 +1,109 @@
+package notification
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleMarkNotificationRead 标记单条通知为已读
+func HandleMarkNotificationRead(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	notificationID := c.Param("notificationid")
+	if notificationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "通知ID不能为空",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 仅能标记自己的通知；已读的通知重复标记同样视为成功
+	affected, err := queries.MarkNotificationAsRead(c.Request.Context(), sqlcdb.MarkNotificationAsReadParams{
+		NotificationID: notificationID,
+		ReceiverID:     currentUserID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "标记通知已读失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if affected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "通知不存在",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "已标记为已读",
+		"data": gin.H{
+			"notificationId": notificationID,
+		},
+	})
+}
+
+// HandleMarkAllNotificationsRead 标记当前用户的所有通知为已读
+func HandleMarkAllNotificationsRead(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	affected, err := queries.MarkAllNotificationsAsRead(c.Request.Context(), currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "标记通知已读失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "已全部标记为已读",
+		"data": gin.H{
+			"markedCount": affected,
+		},
+	})
+}
@@ -0,0 +1,181 @@
+package user
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BlockedUserItem struct {
+	UserId    string    `json:"userId"`
+	Username  string    `json:"username"`
+	Nickname  string    `json:"nickname"`
+	Avatar    string    `json:"avatar"`
+	BlockedAt time.Time `json:"blockedAt"`
+}
+
+// HandleBlockUser 屏蔽用户，屏蔽后双方不能发起私聊，已有私聊会话中双方都不能发送消息
+func HandleBlockUser(c *gin.Context) {
+	handleBlockChange(c, true)
+}
+
+// HandleUnblockUser 取消屏蔽用户
+func HandleUnblockUser(c *gin.Context) {
+	handleBlockChange(c, false)
+}
+
+func handleBlockChange(c *gin.Context, block bool) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	targetUserID := c.Param("userid")
+	if targetUserID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "用户ID不能为空",
+		})
+		return
+	}
+	if targetUserID == currentUserID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "不能屏蔽自己",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	if block {
+		if _, err := queries.GetUserByID(ctx, targetUserID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusNotFound, gin.H{
+					"code":    404,
+					"message": "用户不存在",
+				})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "查询用户失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		// 重复屏蔽不做处理
+		if _, err := queries.BlockUser(ctx, sqlcdb.BlockUserParams{BlockerID: currentUserID, BlockedID: targetUserID}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "屏蔽用户失败",
+				"error":   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"code":    200,
+			"message": "已屏蔽该用户",
+			"data": gin.H{
+				"userId":  targetUserID,
+				"blocked": true,
+			},
+		})
+		return
+	}
+
+	n, err := queries.UnblockUser(ctx, sqlcdb.UnblockUserParams{BlockerID: currentUserID, BlockedID: targetUserID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "取消屏蔽失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if n == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "未屏蔽该用户",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "已取消屏蔽",
+		"data": gin.H{
+			"userId":  targetUserID,
+			"blocked": false,
+		},
+	})
+}
+
+// HandleListBlockedUsers 获取当前用户屏蔽的用户列表（最近屏蔽的在前）
+func HandleListBlockedUsers(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	rows, err := queries.GetBlockedUsers(c.Request.Context(), currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取屏蔽列表失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	users := make([]BlockedUserItem, 0, len(rows))
+	for _, r := range rows {
+		users = append(users, BlockedUserItem{
+			UserId:    r.UserID,
+			Username:  r.Username,
+			Nickname:  r.Nickname.String,
+			Avatar:    r.AvatarUrl.String,
+			BlockedAt: r.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": gin.H{
+			"users": users,
+			"total": len(users),
+		},
+	})
+}
@@ -0,0 +1,237 @@
+package user
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/middleware"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errDirectExists 并发创建私聊会话时另一请求已先完成，回滚本次创建
+var errDirectExists = errors.New("direct conversation already exists")
+
+type DirectPeer struct {
+	UserId   string `json:"userId"`
+	Username string `json:"username"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+}
+
+type DirectConversationResponse struct {
+	RoomId      string     `json:"roomId"`
+	Type        string     `json:"type"`
+	Peer        DirectPeer `json:"peer"`
+	Created     bool       `json:"created"`
+	CreatedTime time.Time  `json:"createdTime"`
+}
+
+func directPeerFromUser(u sqlcdb.User) DirectPeer {
+	return DirectPeer{
+		UserId:   u.UserID,
+		Username: u.Username,
+		Nickname: u.Nickname.String,
+		Avatar:   u.AvatarUrl.String,
+	}
+}
+
+// HandleGetOrCreateDirect 获取与指定用户的私聊会话，不存在时创建（幂等）
+func HandleGetOrCreateDirect(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	targetUserID := c.Param("userid")
+	if targetUserID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "用户ID不能为空",
+		})
+		return
+	}
+	if targetUserID == currentUserID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "不能与自己发起私聊",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	target, err := queries.GetUserByID(ctx, targetUserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": "用户不存在",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询用户失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 会话两端按用户ID排序保存，保证同一对用户只有一个会话
+	pair := sqlcdb.GetDirectConversationParams{UserLow: currentUserID, UserHigh: targetUserID}
+	if pair.UserLow > pair.UserHigh {
+		pair.UserLow, pair.UserHigh = pair.UserHigh, pair.UserLow
+	}
+
+	existing, err := queries.GetDirectConversation(ctx, pair)
+	if err == nil {
+		respondDirect(c, existing, target, false)
+		return
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询私聊会话失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 新建会话的检查：对方账号正常且双方没有屏蔽关系（已有会话仍可查看）
+	if target.AccountStatus.Valid && target.AccountStatus.UserAccountStatus != sqlcdb.UserAccountStatusActive {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "该用户账号状态异常，无法发起私聊",
+		})
+		return
+	}
+	blocked, err := queries.IsBlockedBetween(ctx, sqlcdb.IsBlockedBetweenParams{UserA: currentUserID, UserB: targetUserID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "检查屏蔽状态失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if blocked {
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": "你们之间存在屏蔽关系，无法发起私聊",
+		})
+		return
+	}
+
+	db, err := middleware.GetDBFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 创建聊天室、加入双方与记录会话需在同一事务中完成
+	var room sqlcdb.Chatroom
+	err = middleware.WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		var txErr error
+		room, txErr = qtx.CreateChatroom(ctx, sqlcdb.CreateChatroomParams{
+			RoomType: sqlcdb.ChatroomTypeDirect,
+		})
+		if txErr != nil {
+			return txErr
+		}
+		for _, userID := range []string{pair.UserLow, pair.UserHigh} {
+			if _, txErr = qtx.JoinChatroom(ctx, sqlcdb.JoinChatroomParams{
+				UserID:     userID,
+				RoomID:     room.RoomID,
+				MemberRole: sqlcdb.MemberRoleMember,
+			}); txErr != nil {
+				return txErr
+			}
+			if txErr = qtx.IncrementChatroomMemberCount(ctx, room.RoomID); txErr != nil {
+				return txErr
+			}
+		}
+		n, txErr := qtx.CreateDirectConversation(ctx, sqlcdb.CreateDirectConversationParams{
+			RoomID:   room.RoomID,
+			UserLow:  pair.UserLow,
+			UserHigh: pair.UserHigh,
+		})
+		if txErr != nil {
+			return txErr
+		}
+		if n == 0 {
+			return errDirectExists
+		}
+		return nil
+	})
+	if errors.Is(err, errDirectExists) {
+		// 并发请求已创建会话，返回已有的会话
+		existing, err = queries.GetDirectConversation(ctx, pair)
+		if err == nil {
+			respondDirect(c, existing, target, false)
+			return
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "创建私聊会话失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	logger.Info("Direct", fmt.Sprintf("User %s created direct conversation %s with user %s", currentUserID, room.RoomID, targetUserID))
+	if initiator, err := queries.GetUserByID(context.Background(), currentUserID); err == nil {
+		websocketmsg.NotifyDirectCreated(room.RoomID, currentUserID, targetUserID, directPeerFromUser(initiator))
+	}
+
+	respondDirect(c, sqlcdb.DirectConversation{
+		RoomID:    room.RoomID,
+		UserLow:   pair.UserLow,
+		UserHigh:  pair.UserHigh,
+		CreatedAt: room.CreatedAt,
+	}, target, true)
+}
+
+func respondDirect(c *gin.Context, conv sqlcdb.DirectConversation, peer sqlcdb.User, created bool) {
+	message := "获取成功"
+	if created {
+		message = "私聊会话创建成功"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": message,
+		"data": DirectConversationResponse{
+			RoomId:      conv.RoomID,
+			Type:        "direct",
+			Peer:        directPeerFromUser(peer),
+			Created:     created,
+			CreatedTime: conv.CreatedAt,
+		},
+	})
+}
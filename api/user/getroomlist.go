@@ -20,9 +20,12 @@ type ChatroomListItem struct {
 	OnlineCount       int32                 `json:"onlineCount"`
 	PeopleCount       int32                 `json:"peopleCount"`
 	Unread            int64                 `json:"unread"`
+	UnreadMentions    int64                 `json:"unreadMentions"`
 	CreatedTime       time.Time             `json:"createdTime"`
 	LastMessageTime   time.Time             `json:"lastMessageTime"`
+	Archived          bool                  `json:"archived"` // 已归档的聊天室只读
 	CurrentUserMember CurrentUserMemberInfo `json:"currentUserMember"`
+	Peer              *DirectPeer           `json:"peer,omitempty"` // 私聊会话的另一方
 }
 
 type CurrentUserMemberInfo struct {
@@ -98,6 +101,36 @@ func HandleGetUserChatrooms(c *gin.Context) {
 		return
 	}
 
+	// 一次性获取用户在各聊天室的未读消息数
+	unreadCounts, err := queries.GetUserUnreadCountsInAllRooms(c.Request.Context(), currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取未读消息数失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	unreadByRoom := make(map[string]int64, len(unreadCounts))
+	for _, u := range unreadCounts {
+		unreadByRoom[u.RoomID] = u.UnreadCount
+	}
+
+	// 一次性获取用户在各聊天室的未读提及数
+	mentionCounts, err := queries.GetUserUnreadMentionCountsInAllRooms(c.Request.Context(), currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取未读提及数失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	mentionsByRoom := make(map[string]int64, len(mentionCounts))
+	for _, m := range mentionCounts {
+		mentionsByRoom[m.RoomID] = m.UnreadCount
+	}
+
 	// 构建响应
 	chatroomList := make([]ChatroomListItem, 0, len(chatrooms))
 	for _, cr := range chatrooms {
@@ -117,31 +150,31 @@ func HandleGetUserChatrooms(c *gin.Context) {
 			roomType = "private"
 		case sqlcdb.ChatroomTypePrivatePassword:
 			roomType = "protected"
+		case sqlcdb.ChatroomTypeDirect:
+			roomType = "direct"
 		default:
 			roomType = string(cr.RoomType)
 		}
 
-		// 计算未读消息数（简单实现：如果有 last_read_at 则计算，否则为 0）
-		// TODO: 实际项目中需要根据 messages 表统计未读数
-		unread := int64(0)
-
 		item := ChatroomListItem{
-			RoomId:      cr.RoomID,
-			Name:        cr.RoomName,
-			Description: cr.Description.String,
-			Icon:        cr.IconUrl.String,
-			Type:        roomType,
-			CreatorId:   creatorId,
-			OnlineCount: cr.OnlineCount,
-			PeopleCount: cr.MemberCount,
-			Unread:      unread,
-			CreatedTime: cr.CreatedAt,
+			RoomId:         cr.RoomID,
+			Name:           cr.RoomName,
+			Description:    cr.Description.String,
+			Icon:           cr.IconUrl.String,
+			Type:           roomType,
+			CreatorId:      creatorId,
+			OnlineCount:    cr.OnlineCount,
+			PeopleCount:    cr.MemberCount,
+			Unread:         unreadByRoom[cr.RoomID],
+			UnreadMentions: mentionsByRoom[cr.RoomID],
+			CreatedTime:    cr.CreatedAt,
 			LastMessageTime: func() time.Time {
 				if cr.LastActiveAt.Valid {
 					return cr.LastActiveAt.Time
 				}
 				return cr.CreatedAt
 			}(),
+			Archived: cr.RoomStatus == sqlcdb.ChatroomStatusArchived,
 			CurrentUserMember: CurrentUserMemberInfo{
 				MemberId: cr.MemberRelID,
 				RoomRole: string(cr.MemberRole),
@@ -149,6 +182,26 @@ func HandleGetUserChatrooms(c *gin.Context) {
 				JoinedAt: cr.JoinedAt,
 			},
 		}
+
+		// 私聊会话以对方的昵称和头像作为名称与图标
+		if cr.RoomType == sqlcdb.ChatroomTypeDirect {
+			if peer, err := queries.GetDirectConversationPeer(c.Request.Context(), sqlcdb.GetDirectConversationPeerParams{
+				UserID: currentUserID,
+				RoomID: cr.RoomID,
+			}); err == nil {
+				item.Peer = &DirectPeer{
+					UserId:   peer.UserID,
+					Username: peer.Username,
+					Nickname: peer.Nickname.String,
+					Avatar:   peer.AvatarUrl.String,
+				}
+				item.Name = peer.Nickname.String
+				if item.Name == "" {
+					item.Name = peer.Username
+				}
+				item.Icon = peer.AvatarUrl.String
+			}
+		}
 		chatroomList = append(chatroomList, item)
 	}
 
@@ -2,6 +2,7 @@ package user
 
 import (
 	"chatroombackend/middleware"
+	"chatroombackend/utils"
 	"database/sql"
 	"errors"
 	"net/http"
@@ -148,13 +149,24 @@ func HandleGetUserInfoByID(c *gin.Context) {
 		return
 	}
 
+	// 用户关闭"显示在线状态"时对外显示为离线
+	showOnlineStatus, err := queries.ShouldShowOnlineStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "查询用户设置失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
 	// 构建响应（只返回公开信息，不包含邮箱、电话等敏感信息）
 	response := PublicUserInfoResponse{
 		UserId:        user.UserID,
 		Nickname:      user.Nickname.String,
 		Avatar:        user.AvatarUrl.String,
 		Signature:     user.Bio.String,
-		OnlineStatus:  string(user.OnlineStatus.UserOnlineStatus),
+		OnlineStatus:  utils.VisibleOnlineStatus(string(user.OnlineStatus.UserOnlineStatus), showOnlineStatus),
 		AccountStatus: string(user.AccountStatus.UserAccountStatus),
 		SystemRole:    string(user.SystemRole.UserSystemRole),
 	}
@@ -0,0 +1,147 @@
+package user
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MentionItem struct {
+	MessageId    string `json:"messageId"`
+	RoomId       string `json:"roomId"`
+	RoomName     string `json:"roomName"`
+	MentionType  string `json:"mentionType"`
+	SenderId     string `json:"senderId"`
+	SenderName   string `json:"senderName"`
+	SenderAvatar string `json:"senderAvatar"`
+	Type         string `json:"type"`
+	Text         string `json:"text"`
+	Seq          int64  `json:"seq"`
+	ThreadRootId string `json:"threadRootId,omitempty"`
+	Time         string `json:"time"`
+	IsUnread     bool   `json:"isUnread"`
+}
+
+type MentionListResponse struct {
+	Mentions []MentionItem `json:"mentions"`
+	Total    int64         `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"pageSize"`
+}
+
+// HandleGetMentions 获取提及当前用户的消息（最新的在前）
+// 消息发送时间晚于用户在该聊天室的已读位置的提及为未读
+func HandleGetMentions(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	// 解析过滤与分页参数
+	roomID := c.Query("roomId")
+	status := c.DefaultQuery("status", "all")
+	switch status {
+	case "all":
+		status = ""
+	case "unread":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "status参数无效，可选值: unread|all",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	ctx := c.Request.Context()
+
+	total, err := queries.CountUserMentions(ctx, sqlcdb.CountUserMentionsParams{
+		UserID: currentUserID,
+		RoomID: roomID,
+		Status: status,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取提及数量失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	rows, err := queries.GetUserMentions(ctx, sqlcdb.GetUserMentionsParams{
+		UserID: currentUserID,
+		RoomID: roomID,
+		Status: status,
+		Limit:  int64(pageSize),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取提及列表失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	mentions := make([]MentionItem, 0, len(rows))
+	for _, r := range rows {
+		senderName := r.Nickname.String
+		if senderName == "" {
+			senderName = r.Username.String
+		}
+		mentions = append(mentions, MentionItem{
+			MessageId:    r.MessageID,
+			RoomId:       r.RoomID,
+			RoomName:     r.RoomName,
+			MentionType:  string(r.MentionType),
+			SenderId:     r.SenderID.String,
+			SenderName:   senderName,
+			SenderAvatar: r.AvatarUrl.String,
+			Type:         string(r.MessageType),
+			Text:         r.Content,
+			Seq:          r.Seq,
+			ThreadRootId: r.ThreadRootID.String,
+			Time:         r.CreatedAt.UTC().Format(time.RFC3339),
+			IsUnread:     r.IsUnread,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": MentionListResponse{
+			Mentions: mentions,
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+		},
+	})
+}
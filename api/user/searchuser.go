@@ -0,0 +1,117 @@
+package user
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"chatroombackend/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchUserItem struct {
+	UserId       string `json:"userId"`
+	Username     string `json:"username"`
+	Nickname     string `json:"nickname"`
+	Avatar       string `json:"avatar"`
+	Signature    string `json:"signature"`
+	OnlineStatus string `json:"onlineStatus"`
+}
+
+type SearchUserResponse struct {
+	Users    []SearchUserItem `json:"users"`
+	Total    int64            `json:"total"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"pageSize"`
+}
+
+// HandleSearchUsers 搜索用户
+// 用户名、昵称模糊匹配；邮箱、手机号需完整匹配，且仅返回在隐私设置中允许被搜索的用户
+func HandleSearchUsers(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	keyword := strings.TrimSpace(c.Query("keyword"))
+	if keyword == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "搜索关键词不能为空",
+		})
+		return
+	}
+
+	// 解析分页参数
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	total, err := queries.CountSearchUsers(c.Request.Context(), keyword)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取搜索结果数量失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	users, err := queries.SearchUsers(c.Request.Context(), sqlcdb.SearchUsersParams{
+		Keyword: keyword,
+		Limit:   int64(pageSize),
+		Offset:  int64(offset),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "搜索用户失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	userList := make([]SearchUserItem, 0, len(users))
+	for _, u := range users {
+		userList = append(userList, SearchUserItem{
+			UserId:       u.UserID,
+			Username:     u.Username,
+			Nickname:     u.Nickname.String,
+			Avatar:       u.AvatarUrl.String,
+			Signature:    u.Bio.String,
+			OnlineStatus: utils.VisibleOnlineStatus(string(u.OnlineStatus.UserOnlineStatus), u.ShowOnlineStatus),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 200,
+		"data": SearchUserResponse{
+			Users:    userList,
+			Total:    total,
+			Page:     page,
+			PageSize: pageSize,
+		},
+	})
+}
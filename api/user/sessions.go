@@ -0,0 +1,183 @@
+package user
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionItem struct {
+	SessionId    string    `json:"sessionId"`
+	Device       string    `json:"device"`
+	UserAgent    string    `json:"userAgent"`
+	IpAddress    string    `json:"ipAddress"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastActiveAt time.Time `json:"lastActiveAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	Current      bool      `json:"current"` // 是否为当前请求所用的会话
+}
+
+// HandleListSessions 获取当前用户的有效登录会话
+func HandleListSessions(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	sessions, err := queries.ListActiveUserSessions(c.Request.Context(), currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取登录会话失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	currentSessionID := c.GetString("sessionId")
+	items := make([]SessionItem, 0, len(sessions))
+	for _, s := range sessions {
+		items = append(items, SessionItem{
+			SessionId:    s.SessionID,
+			Device:       s.Device.String,
+			UserAgent:    s.UserAgent.String,
+			IpAddress:    s.IpAddress.String,
+			CreatedAt:    s.CreatedAt,
+			LastActiveAt: s.LastActiveAt,
+			ExpiresAt:    s.ExpiresAt,
+			Current:      s.SessionID == currentSessionID,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data": gin.H{
+			"sessions": items,
+			"total":    len(items),
+		},
+	})
+}
+
+// HandleRevokeSession 吊销当前用户的指定会话，并断开该会话的 WebSocket 连接
+func HandleRevokeSession(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	sessionID := c.Param("sessionid")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "会话ID不能为空",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 仅能吊销自己的会话
+	affected, err := queries.RevokeUserSession(c.Request.Context(), sqlcdb.RevokeUserSessionParams{
+		SessionID: sessionID,
+		UserID:    currentUserID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "吊销登录会话失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if affected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "会话不存在或已失效",
+		})
+		return
+	}
+
+	websocketmsg.CloseSessionConnections(currentUserID, sessionID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "会话已吊销",
+		"data": gin.H{
+			"sessionId": sessionID,
+			"current":   sessionID == c.GetString("sessionId"),
+		},
+	})
+}
+
+// HandleRevokeAllSessions 吊销当前用户的所有会话（包括当前会话），并断开所有 WebSocket 连接
+func HandleRevokeAllSessions(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	affected, err := queries.RevokeAllUserSessions(c.Request.Context(), currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "吊销登录会话失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	websocketmsg.CloseUserConnections(currentUserID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "已退出所有设备",
+		"data": gin.H{
+			"revokedCount": affected,
+		},
+	})
+}
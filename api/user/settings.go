@@ -0,0 +1,222 @@
+package user
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/middleware"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationSettings struct {
+	EnableFriendRequest       bool `json:"enableFriendRequest"`
+	EnableChatRoomMessage     bool `json:"enableChatRoomMessage"`
+	EnableSystemNotice        bool `json:"enableSystemNotice"`
+	EnableSound               bool `json:"enableSound"`
+	EnableDesktopNotification bool `json:"enableDesktopNotification"`
+}
+
+type PrivacySettings struct {
+	AllowSearchByPhone bool `json:"allowSearchByPhone"`
+	AllowSearchByEmail bool `json:"allowSearchByEmail"`
+	ShowOnlineStatus   bool `json:"showOnlineStatus"`
+}
+
+type UserSettingsResponse struct {
+	Notifications NotificationSettings `json:"notifications"`
+	Privacy       PrivacySettings      `json:"privacy"`
+	Theme         string               `json:"theme"`
+	Language      string               `json:"language"`
+	UpdatedAt     time.Time            `json:"updatedAt"`
+}
+
+// 更新请求中未提供的字段保持不变
+type UpdateSettingsRequest struct {
+	Notifications *struct {
+		EnableFriendRequest       *bool `json:"enableFriendRequest"`
+		EnableChatRoomMessage     *bool `json:"enableChatRoomMessage"`
+		EnableSystemNotice        *bool `json:"enableSystemNotice"`
+		EnableSound               *bool `json:"enableSound"`
+		EnableDesktopNotification *bool `json:"enableDesktopNotification"`
+	} `json:"notifications"`
+	Privacy *struct {
+		AllowSearchByPhone *bool `json:"allowSearchByPhone"`
+		AllowSearchByEmail *bool `json:"allowSearchByEmail"`
+		ShowOnlineStatus   *bool `json:"showOnlineStatus"`
+	} `json:"privacy"`
+	Theme    *string `json:"theme" binding:"omitempty,oneof=light dark system"`
+	Language *string `json:"language" binding:"omitempty,max=10"`
+}
+
+func toSettingsResponse(s sqlcdb.UserSetting) UserSettingsResponse {
+	return UserSettingsResponse{
+		Notifications: NotificationSettings{
+			EnableFriendRequest:       s.EnableFriendRequest,
+			EnableChatRoomMessage:     s.EnableChatroomMessage,
+			EnableSystemNotice:        s.EnableSystemNotice,
+			EnableSound:               s.EnableSound,
+			EnableDesktopNotification: s.EnableDesktopNotification,
+		},
+		Privacy: PrivacySettings{
+			AllowSearchByPhone: s.AllowSearchByPhone,
+			AllowSearchByEmail: s.AllowSearchByEmail,
+			ShowOnlineStatus:   s.ShowOnlineStatus,
+		},
+		Theme:     s.Theme,
+		Language:  s.Language,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func nullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
+func nullStringPtr(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+// getOrCreateSettings 获取用户设置，不存在时按默认值创建
+func getOrCreateSettings(c *gin.Context, queries *sqlcdb.Queries, userID string) (sqlcdb.UserSetting, error) {
+	settings, err := queries.GetUserSettings(c.Request.Context(), userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return queries.CreateUserSettings(c.Request.Context(), userID)
+	}
+	return settings, err
+}
+
+// HandleGetUserSettings 获取当前用户的设置
+func HandleGetUserSettings(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	settings, err := getOrCreateSettings(c, queries, currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取用户设置失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "获取成功",
+		"data":    toSettingsResponse(settings),
+	})
+}
+
+// HandleUpdateUserSettings 更新当前用户的设置（部分更新）
+func HandleUpdateUserSettings(c *gin.Context) {
+	currentUserID := c.GetString("userId")
+	if currentUserID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未登录，请先登录获取Token",
+		})
+		return
+	}
+
+	var req UpdateSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请求参数错误",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	queries, err := middleware.GetQueriesFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取数据库连接失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 确保设置记录存在，并记录修改前的在线状态可见性
+	before, err := getOrCreateSettings(c, queries, currentUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "获取用户设置失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	params := sqlcdb.UpdateUserSettingsParams{
+		Theme:    nullStringPtr(req.Theme),
+		Language: nullStringPtr(req.Language),
+		UserID:   currentUserID,
+	}
+	if n := req.Notifications; n != nil {
+		params.EnableFriendRequest = nullBool(n.EnableFriendRequest)
+		params.EnableChatroomMessage = nullBool(n.EnableChatRoomMessage)
+		params.EnableSystemNotice = nullBool(n.EnableSystemNotice)
+		params.EnableSound = nullBool(n.EnableSound)
+		params.EnableDesktopNotification = nullBool(n.EnableDesktopNotification)
+	}
+	if p := req.Privacy; p != nil {
+		params.AllowSearchByPhone = nullBool(p.AllowSearchByPhone)
+		params.AllowSearchByEmail = nullBool(p.AllowSearchByEmail)
+		params.ShowOnlineStatus = nullBool(p.ShowOnlineStatus)
+	}
+
+	settings, err := queries.UpdateUserSettings(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": "更新用户设置失败",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// 在线状态可见性变化时，同步其他成员看到的状态
+	if before.ShowOnlineStatus != settings.ShowOnlineStatus {
+		status := "offline"
+		if settings.ShowOnlineStatus {
+			if u, err := queries.GetUserByID(c.Request.Context(), currentUserID); err == nil && u.OnlineStatus.Valid {
+				status = string(u.OnlineStatus.UserOnlineStatus)
+			}
+		}
+		websocketmsg.BroadcastUserStatus(currentUserID, status)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    200,
+		"message": "设置已更新",
+		"data":    toSettingsResponse(settings),
+	})
+}
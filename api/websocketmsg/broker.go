@@ -0,0 +1,148 @@
+package websocketmsg
+
+import (
+	"chatroombackend/logger"
+	"chatroombackend/utils"
+	"fmt"
+	"sync"
+)
+
+// 事件类型
+const (
+	EventRoom       = "room"       // 广播到房间
+	EventUser       = "user"       // 推送给指定用户
+	EventJoinRoom   = "join_room"  // 为已连接的用户订阅房间（如新建私聊会话）
+	EventLeaveRoom  = "leave_room" // 取消用户的房间订阅（如被踢出）
+	EventDisconnect = "disconnect" // 关闭用户的连接（如会话被吊销）
+)
+
+// BrokerEvent 通过 Broker 分发的事件
+type BrokerEvent struct {
+	Kind      string    `json:"kind"`                // 事件类型: room | user | join_room | leave_room | disconnect
+	Target    string    `json:"target"`              // roomId 或 userId
+	UserID    string    `json:"userId,omitempty"`    // join_room / leave_room 时的用户ID
+	SessionID string    `json:"sessionId,omitempty"` // disconnect 时的会话编号，为空表示全部会话
+	Message   WSMessage `json:"message"`             // 推送给客户端的消息
+	Origin    string    `json:"origin"`              // 发布事件的实例编号
+}
+
+// ephemeral 是否为可丢弃的瞬时事件（如输入状态），发送队列已满时直接丢弃
+func (e BrokerEvent) ephemeral() bool {
+	return e.Kind == EventRoom && e.Message.Type == "typing"
+}
+
+// Broker 事件分发背板
+// Publish 须将事件投递给所有实例（包括本实例）上的订阅者
+type Broker interface {
+	Publish(event BrokerEvent) error
+	Subscribe(handler func(BrokerEvent)) error
+	Close() error
+}
+
+// instanceID 当前服务实例编号
+var instanceID = utils.GenerateInstanceID()
+
+var (
+	broker    Broker
+	brokerMux sync.RWMutex
+)
+
+func init() {
+	b := NewMemoryBroker()
+	_ = b.Subscribe(hub.handleEvent)
+	broker = b
+}
+
+// SetBroker 替换事件分发背板（需在服务启动时调用）
+func SetBroker(b Broker) error {
+	if err := b.Subscribe(hub.handleEvent); err != nil {
+		return fmt.Errorf("订阅事件失败: %w", err)
+	}
+
+	brokerMux.Lock()
+	old := broker
+	broker = b
+	brokerMux.Unlock()
+
+	if old != nil && old != b {
+		_ = old.Close()
+	}
+	logger.Info("WebSocket", fmt.Sprintf("Broker set to %T (instance %s)", b, instanceID))
+	return nil
+}
+
+// CloseBroker 关闭当前事件分发背板
+func CloseBroker() error {
+	brokerMux.RLock()
+	defer brokerMux.RUnlock()
+	if broker == nil {
+		return nil
+	}
+	return broker.Close()
+}
+
+// publish 发布事件
+func publish(event BrokerEvent) {
+	event.Origin = instanceID
+
+	brokerMux.RLock()
+	b := broker
+	brokerMux.RUnlock()
+
+	if err := b.Publish(event); err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to publish %s event to %s", event.Kind, event.Target), err)
+	}
+}
+
+// handleEvent 在本实例上处理事件
+func (h *Hub) handleEvent(event BrokerEvent) {
+	switch event.Kind {
+	case EventRoom:
+		h.deliverRoom(event.Target, event.Message)
+	case EventUser:
+		h.deliverToUser(event.Target, event.Message)
+	case EventJoinRoom:
+		h.addToRoom(event.UserID, event.Target)
+	case EventLeaveRoom:
+		h.removeFromRoom(event.UserID, event.Target)
+	case EventDisconnect:
+		h.disconnect(event.Target, event.SessionID)
+	default:
+		logger.Warn("WebSocket", fmt.Sprintf("Unknown broker event kind: %s", event.Kind))
+	}
+}
+
+// MemoryBroker 进程内事件分发（单实例部署）
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	handlers []func(BrokerEvent)
+}
+
+// NewMemoryBroker 创建进程内事件分发
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+// Publish 同步分发给所有订阅者
+func (b *MemoryBroker) Publish(event BrokerEvent) error {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(event)
+	}
+	return nil
+}
+
+// Subscribe 注册事件处理函数
+func (b *MemoryBroker) Subscribe(handler func(BrokerEvent)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+	return nil
+}
+
+// Close 关闭（进程内实现无需释放资源）
+func (b *MemoryBroker) Close() error {
+	return nil
+}
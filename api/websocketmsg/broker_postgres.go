@@ -0,0 +1,261 @@
+package websocketmsg
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/middleware"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// DefaultBrokerChannel 默认的 LISTEN/NOTIFY 频道名
+	DefaultBrokerChannel = "chatroom_ws_events"
+
+	// maxNotifyPayload NOTIFY 负载上限（PostgreSQL 默认 8000 字节，预留余量）
+	maxNotifyPayload = 7500
+	// eventRefPrefix 超出上限的事件写入 ws_events 表，通知中携带 "@<event_id>"
+	eventRefPrefix = "@"
+	// eventRetention ws_events 表中事件的保留时长
+	eventRetention = 5 * time.Minute
+	// outboxSize 待发送通知的队列长度
+	outboxSize = 1024
+	// outboxTimeout 队列已满时非瞬时事件等待入队的最长时间
+	outboxTimeout = 3 * time.Second
+)
+
+// PostgresBroker 基于 PostgreSQL LISTEN/NOTIFY 的跨实例事件分发
+// 复用 DBManager 的连接池发送 NOTIFY，并使用同一 DSN 建立监听连接。
+// 通知经缓冲队列由单个协程依次发送，发布方不等待数据库往返
+type PostgresBroker struct {
+	queries  *sqlcdb.Queries
+	listener *pq.Listener
+	channel  string
+	outbox   chan BrokerEvent
+
+	mu       sync.RWMutex
+	handlers []func(BrokerEvent)
+
+	closeOnce sync.Once
+	done      chan struct{}
+	drained   chan struct{} // 发送协程退出后关闭
+}
+
+// NewPostgresBroker 创建 PostgreSQL 事件分发
+func NewPostgresBroker(manager *middleware.DBManager, channel string) (*PostgresBroker, error) {
+	if manager == nil {
+		return nil, errors.New("数据库管理器不能为空")
+	}
+	if channel == "" {
+		channel = DefaultBrokerChannel
+	}
+
+	listener := pq.NewListener(manager.GetDSN(), 10*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		switch ev {
+		case pq.ListenerEventConnected:
+			logger.Info("Broker", "Listener connected")
+		case pq.ListenerEventDisconnected:
+			logger.Error("Broker", "Listener disconnected", err)
+		case pq.ListenerEventReconnected:
+			logger.Info("Broker", "Listener reconnected")
+		case pq.ListenerEventConnectionAttemptFailed:
+			logger.Error("Broker", "Listener connection attempt failed", err)
+		}
+	})
+	if err := listener.Listen(channel); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("监听频道 %s 失败: %w", channel, err)
+	}
+
+	b := &PostgresBroker{
+		queries:  manager.GetQueries(),
+		listener: listener,
+		channel:  channel,
+		outbox:   make(chan BrokerEvent, outboxSize),
+		done:     make(chan struct{}),
+		drained:  make(chan struct{}),
+	}
+	go b.run()
+	go b.runSender()
+
+	logger.Info("Broker", fmt.Sprintf("Listening on channel %s (instance %s)", channel, instanceID))
+	return b, nil
+}
+
+// Publish 先分发给本实例订阅者，再放入发送队列通过 NOTIFY 通知其他实例。
+// 队列已满时丢弃瞬时事件（如输入状态），其他事件最多等待 outboxTimeout
+func (b *PostgresBroker) Publish(event BrokerEvent) error {
+	b.dispatch(event)
+
+	select {
+	case b.outbox <- event:
+		return nil
+	default:
+	}
+	if event.ephemeral() {
+		logger.Debug("Broker", fmt.Sprintf("Outbox full, dropped %s/%s event to %s", event.Message.Type, event.Message.Action, event.Target))
+		return nil
+	}
+
+	timer := time.NewTimer(outboxTimeout)
+	defer timer.Stop()
+	select {
+	case b.outbox <- event:
+		return nil
+	case <-b.done:
+		return errors.New("事件分发已关闭")
+	case <-timer.C:
+		return errors.New("发送队列已满")
+	}
+}
+
+// runSender 依次发送队列中的事件，关闭时尽量发送完已入队的事件
+func (b *PostgresBroker) runSender() {
+	defer close(b.drained)
+	for {
+		select {
+		case event := <-b.outbox:
+			b.send(event)
+		case <-b.done:
+			for {
+				select {
+				case event := <-b.outbox:
+					b.send(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b *PostgresBroker) send(event BrokerEvent) {
+	if err := b.notify(event); err != nil {
+		logger.Error("Broker", fmt.Sprintf("Failed to notify %s event to %s", event.Kind, event.Target), err)
+	}
+}
+
+// notify 通过 NOTIFY 通知其他实例，超出负载上限的事件先写入 ws_events 表
+func (b *PostgresBroker) notify(event BrokerEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("序列化事件失败: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	notifyPayload := string(payload)
+	if len(payload) > maxNotifyPayload {
+		eventID, err := b.queries.CreateWSEvent(ctx, string(payload))
+		if err != nil {
+			return fmt.Errorf("保存事件失败: %w", err)
+		}
+		notifyPayload = eventRefPrefix + strconv.FormatInt(eventID, 10)
+	}
+
+	if err := b.queries.NotifyChannel(ctx, sqlcdb.NotifyChannelParams{
+		Channel: b.channel,
+		Payload: notifyPayload,
+	}); err != nil {
+		return fmt.Errorf("发送通知失败: %w", err)
+	}
+	return nil
+}
+
+// Subscribe 注册事件处理函数
+func (b *PostgresBroker) Subscribe(handler func(BrokerEvent)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+	return nil
+}
+
+// Close 停止监听并关闭监听连接，等待已入队的事件发送完毕
+func (b *PostgresBroker) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		close(b.done)
+		<-b.drained
+		err = b.listener.Close()
+	})
+	return err
+}
+
+func (b *PostgresBroker) dispatch(event BrokerEvent) {
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// run 接收其他实例的通知并分发，定期检查监听连接与清理过期事件
+func (b *PostgresBroker) run() {
+	ticker := time.NewTicker(90 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case n, ok := <-b.listener.Notify:
+			if !ok {
+				return
+			}
+			if n == nil {
+				// 监听连接重连后会收到 nil，期间的通知可能已丢失
+				logger.Warn("Broker", "Listener reconnected, notifications may have been missed")
+				continue
+			}
+			b.handleNotification(n.Extra)
+		case <-ticker.C:
+			if err := b.listener.Ping(); err != nil {
+				logger.Error("Broker", "Listener ping failed", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			if err := b.queries.DeleteExpiredWSEvents(ctx, time.Now().Add(-eventRetention)); err != nil {
+				logger.Error("Broker", "Failed to delete expired ws events", err)
+			}
+			cancel()
+		}
+	}
+}
+
+func (b *PostgresBroker) handleNotification(payload string) {
+	if strings.HasPrefix(payload, eventRefPrefix) {
+		eventID, err := strconv.ParseInt(strings.TrimPrefix(payload, eventRefPrefix), 10, 64)
+		if err != nil {
+			logger.Warn("Broker", fmt.Sprintf("Invalid event reference: %s", payload))
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		payload, err = b.queries.GetWSEvent(ctx, eventID)
+		if err != nil {
+			logger.Error("Broker", fmt.Sprintf("Failed to load event %d", eventID), err)
+			return
+		}
+	}
+
+	var event BrokerEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		logger.Warn("Broker", fmt.Sprintf("Failed to parse event: %v", err))
+		return
+	}
+
+	// 本实例发布的事件已在 Publish 时分发
+	if event.Origin == instanceID {
+		return
+	}
+	b.dispatch(event)
+}
@@ -0,0 +1,21 @@
+package websocketmsg
+
+import (
+	"chatroombackend/logger"
+	"encoding/json"
+	"fmt"
+)
+
+// NotifyDirectCreated 新建私聊会话后为双方订阅该房间（跨实例），并通知被发起方。
+// initiator 为发起方的公开信息，作为事件中的 peer
+func NotifyDirectCreated(roomID, initiatorID, peerID string, initiator any) {
+	publish(BrokerEvent{Kind: EventJoinRoom, Target: roomID, UserID: initiatorID})
+	publish(BrokerEvent{Kind: EventJoinRoom, Target: roomID, UserID: peerID})
+
+	data, _ := json.Marshal(map[string]any{
+		"roomId": roomID,
+		"peer":   initiator,
+	})
+	logger.Info("WebSocket", fmt.Sprintf("Notifying user %s of direct conversation %s with user %s", peerID, roomID, initiatorID))
+	SendToUser(peerID, WSMessage{Type: "direct", Action: "created", Data: data})
+}
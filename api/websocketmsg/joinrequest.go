@@ -0,0 +1,41 @@
+package websocketmsg
+
+import (
+	"chatroombackend/logger"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JoinRequestEvent 加入申请事件数据
+type JoinRequestEvent struct {
+	RequestID string `json:"requestId"`
+	RoomID    string `json:"roomId"`
+	RoomName  string `json:"roomName"`
+	UserID    string `json:"userId"` // 申请人ID
+	Username  string `json:"username"`
+	Nickname  string `json:"nickname"`
+	Avatar    string `json:"avatar"`
+	Message   string `json:"message,omitempty"`
+	Status    string `json:"status"`
+	HandledBy string `json:"handledBy,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// NotifyJoinRequest 向多个用户推送加入申请事件
+// action: new（管理员收到新申请）| approved | rejected（申请被处理，推送给申请人与聊天室管理员）
+func NotifyJoinRequest(userIDs []string, action string, event JoinRequestEvent) {
+	if event.Timestamp == "" {
+		event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+	data, _ := json.Marshal(event)
+	msg := WSMessage{
+		Type:   "join_request",
+		Action: action,
+		Data:   data,
+	}
+	logger.Info("WebSocket", fmt.Sprintf("Notifying %d users of join request %s in room %s: %s", len(userIDs), event.RequestID, event.RoomID, action))
+	for _, userID := range userIDs {
+		SendToUser(userID, msg)
+	}
+}
@@ -0,0 +1,15 @@
+package websocketmsg
+
+import (
+	"chatroombackend/logger"
+	"chatroombackend/msgservice"
+	"encoding/json"
+	"fmt"
+)
+
+// NotifyMention 向被提及的成员的所有设备推送 mention 事件（跨实例），作为 msgservice.MentionHook 的回调
+func NotifyMention(m msgservice.Mention) {
+	data, _ := json.Marshal(m)
+	logger.Debug("WebSocket", fmt.Sprintf("Notifying user %s of mention (%s) in message %s", m.UserID, m.MentionType, m.Message.MessageID))
+	SendToUser(m.UserID, WSMessage{Type: "mention", Action: "new", Data: data})
+}
@@ -0,0 +1,156 @@
+package websocketmsg
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/middleware"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	// 实例刷新连接登记的间隔
+	presenceHeartbeat = 30 * time.Second
+	// 超过该时长未刷新的连接视为失效（实例已退出），需大于刷新间隔
+	presenceTimeout = 2 * time.Minute
+)
+
+// presenceDB 用于在事务中维护全局在线状态
+var presenceDB *sql.DB
+
+// SetDB 注入数据库连接，并启动连接登记的定期刷新与失效清理。
+// 在线状态按用户在所有实例上的连接数维护，仅在 0 与 1 之间变化时设置在线/离线
+func SetDB(db *sql.DB) {
+	presenceDB = db
+	go runPresenceHeartbeat()
+}
+
+// presenceConnect 登记连接；用户在所有实例上的第一个连接建立时设置在线
+func presenceConnect(client *Client) {
+	if queries == nil || presenceDB == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	first := false
+	err := middleware.WithTransaction(ctx, presenceDB, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		if err := qtx.LockUserPresence(ctx, client.UserID); err != nil {
+			return err
+		}
+		if err := qtx.CreateWSConnection(ctx, sqlcdb.CreateWSConnectionParams{
+			ConnID:     client.ConnID,
+			UserID:     client.UserID,
+			InstanceID: instanceID,
+		}); err != nil {
+			return err
+		}
+		n, err := qtx.CountLiveUserConnections(ctx, sqlcdb.CountLiveUserConnectionsParams{
+			UserID:         client.UserID,
+			TimeoutSeconds: int32(presenceTimeout.Seconds()),
+		})
+		if err != nil {
+			return err
+		}
+		first = n == 1
+		if first {
+			return qtx.SetUserOnline(ctx, client.UserID)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to register connection %s of user %s", client.ConnID, client.UserID), err)
+		return
+	}
+	if first {
+		logger.Info("WebSocket", fmt.Sprintf("User %s set to online", client.UserID))
+		syncOnlineCounts(ctx, client.UserID)
+	}
+}
+
+// presenceDisconnect 注销连接；用户在所有实例上的最后一个连接断开时设置离线
+func presenceDisconnect(client *Client) {
+	if queries == nil || presenceDB == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	err := queries.DeleteWSConnection(ctx, sqlcdb.DeleteWSConnectionParams{
+		InstanceID: instanceID,
+		ConnID:     client.ConnID,
+	})
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to unregister connection %s of user %s", client.ConnID, client.UserID), err)
+	}
+	settlePresence(ctx, client.UserID)
+}
+
+// settlePresence 用户在所有实例上都没有有效连接时设置离线
+func settlePresence(ctx context.Context, userID string) {
+	last := false
+	err := middleware.WithTransaction(ctx, presenceDB, func(tx *sql.Tx) error {
+		qtx := queries.WithTx(tx)
+		if err := qtx.LockUserPresence(ctx, userID); err != nil {
+			return err
+		}
+		n, err := qtx.CountLiveUserConnections(ctx, sqlcdb.CountLiveUserConnectionsParams{
+			UserID:         userID,
+			TimeoutSeconds: int32(presenceTimeout.Seconds()),
+		})
+		if err != nil {
+			return err
+		}
+		last = n == 0
+		if last {
+			return qtx.SetUserOffline(ctx, userID)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to update presence of user %s", userID), err)
+		return
+	}
+	if last {
+		logger.Info("WebSocket", fmt.Sprintf("User %s set to offline", userID))
+		syncOnlineCounts(ctx, userID)
+	}
+}
+
+// syncOnlineCounts 重新统计用户所在聊天室的在线人数
+func syncOnlineCounts(ctx context.Context, userID string) {
+	if err := queries.SyncUserChatroomsOnlineCount(ctx, userID); err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to sync online counts for rooms of user %s", userID), err)
+	}
+}
+
+// runPresenceHeartbeat 定期刷新本实例的连接登记，并清理已退出实例遗留的连接
+func runPresenceHeartbeat() {
+	ticker := time.NewTicker(presenceHeartbeat)
+	defer ticker.Stop()
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := queries.TouchInstanceConnections(ctx, instanceID); err != nil {
+			logger.Error("WebSocket", "Failed to refresh ws connections", err)
+		}
+		stale, err := queries.DeleteStaleWSConnections(ctx, int32(presenceTimeout.Seconds()))
+		if err != nil {
+			logger.Error("WebSocket", "Failed to delete stale ws connections", err)
+		}
+		settled := make(map[string]bool, len(stale))
+		for _, userID := range stale {
+			if settled[userID] {
+				continue
+			}
+			settled[userID] = true
+			settlePresence(ctx, userID)
+		}
+		if len(stale) > 0 {
+			logger.Info("WebSocket", fmt.Sprintf("Removed %d stale ws connections of %d users", len(stale), len(settled)))
+		}
+		cancel()
+	}
+}
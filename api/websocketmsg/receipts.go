@@ -0,0 +1,135 @@
+package websocketmsg
+
+import (
+	"bytes"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 新消息与话题回复事件序列化后的固定前缀，writePump 据此识别需要记录送达的帧
+var (
+	newMessagePrefix  = []byte(`{"type":"message","action":"new","data":`)
+	threadReplyPrefix = []byte(`{"type":"thread","action":"reply","data":`)
+)
+
+// deliveryAck 一次待记录的送达
+type deliveryAck struct {
+	messageID string
+	userID    string
+}
+
+var (
+	deliveryQueue = make(chan deliveryAck, 1024)
+	deliveryOnce  sync.Once
+)
+
+// startDeliveryWorker 启动送达记录的后台协程，避免 writePump 等待数据库
+func startDeliveryWorker() {
+	deliveryOnce.Do(func() {
+		go func() {
+			for ack := range deliveryQueue {
+				recordDelivered(ack)
+			}
+		}()
+	})
+}
+
+// ackDelivered 在帧成功写入连接后调用；帧为需要记录投递状态的他人新消息时排队记录送达
+func (c *Client) ackDelivered(frame []byte) {
+	if !bytes.HasPrefix(frame, newMessagePrefix) && !bytes.HasPrefix(frame, threadReplyPrefix) {
+		return
+	}
+	var msg struct {
+		Data struct {
+			MessageID   string `json:"messageId"`
+			UserID      string `json:"userId"`
+			TrackStatus bool   `json:"trackStatus"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(frame, &msg); err != nil || !msg.Data.TrackStatus || msg.Data.UserID == c.UserID {
+		return
+	}
+	select {
+	case deliveryQueue <- deliveryAck{messageID: msg.Data.MessageID, userID: c.UserID}:
+	default:
+		// 队列已满时放弃记录，接收者已读时状态仍会被更新为 read
+		logger.Warn("WebSocket", fmt.Sprintf("Dropped delivery ack of message %s for user %s: queue full", msg.Data.MessageID, c.UserID))
+	}
+}
+
+// recordDelivered 将消息标记为已送达并通知发送者；已送达、已读或未记录投递状态的消息不会重复通知
+func recordDelivered(ack deliveryAck) {
+	if queries == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	row, err := queries.MarkMessageDelivered(ctx, sqlcdb.MarkMessageDeliveredParams{
+		MessageID: ack.messageID,
+		UserID:    ack.userID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return
+	}
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to mark message %s delivered to user %s", ack.messageID, ack.userID), err)
+		return
+	}
+	if row.SenderID.Valid {
+		NotifyMessageStatus(row.SenderID.String, row.RoomID, ack.userID, "delivered", []string{ack.messageID}, row.DeliveredAt.Time)
+	}
+}
+
+// MarkReceiptsRead 在成员已读位置前进后，将其在该聊天室 upTo 及之前的消息投递状态更新为 read，并按发送者分别通知
+func MarkReceiptsRead(ctx context.Context, roomID, userID string, upTo time.Time) {
+	if queries == nil {
+		return
+	}
+	rows, err := queries.MarkMessagesReadUpTo(ctx, sqlcdb.MarkMessagesReadUpToParams{
+		UserID: userID,
+		RoomID: roomID,
+		SentAt: upTo,
+	})
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to mark receipts read for user %s in room %s", userID, roomID), err)
+		return
+	}
+
+	bySender := make(map[string][]string)
+	readAt := time.Now()
+	for _, r := range rows {
+		if r.SenderID.Valid {
+			bySender[r.SenderID.String] = append(bySender[r.SenderID.String], r.MessageID)
+		}
+		readAt = r.ReadAt.Time
+	}
+	for senderID, messageIDs := range bySender {
+		NotifyMessageStatus(senderID, roomID, userID, "read", messageIDs, readAt)
+	}
+}
+
+// NotifyMessageStatus 通知发送者其消息在某个接收者处的投递状态变化（跨实例）
+func NotifyMessageStatus(senderID, roomID, recipientID, status string, messageIDs []string, at time.Time) {
+	data, _ := json.Marshal(map[string]any{
+		"roomId":     roomID,
+		"userId":     recipientID,
+		"messageIds": messageIDs,
+		"status":     status,
+		"at":         at.UTC().Format(time.RFC3339),
+	})
+	msg := WSMessage{
+		Type:   "message_status",
+		Action: status,
+		Data:   data,
+	}
+	logger.Debug("WebSocket", fmt.Sprintf("Notifying user %s: %d messages %s by user %s in room %s", senderID, len(messageIDs), status, recipientID, roomID))
+	SendToUser(senderID, msg)
+}
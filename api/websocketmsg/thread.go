@@ -0,0 +1,92 @@
+package websocketmsg
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/msgservice"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// notifyThreadReply 将话题回复推送给话题关注者，并向聊天室广播话题摘要（回复数、最后回复时间）。
+// 摘要带有回复的序号，未关注话题的成员据此保持序号连续
+func notifyThreadReply(ctx context.Context, q *sqlcdb.Queries, m msgservice.Payload, data json.RawMessage) {
+	followers, err := q.GetThreadFollowerIDs(ctx, m.ThreadRootID)
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to load followers of thread %s", m.ThreadRootID), err)
+	}
+	reply := WSMessage{Type: "thread", Action: "reply", Data: data}
+	for _, uid := range followers {
+		SendToUser(uid, reply)
+	}
+
+	summary := map[string]any{
+		"roomId":        m.RoomID,
+		"rootMessageId": m.ThreadRootID,
+		"replyId":       m.MessageID,
+		"replyUserId":   m.UserID,
+		"lastReplyAt":   m.Time,
+		"seq":           m.Seq,
+	}
+	if root, err := q.GetMessageByID(ctx, m.ThreadRootID); err == nil {
+		summary["replyCount"] = root.ReplyCount
+	} else {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to load thread root %s", m.ThreadRootID), err)
+	}
+	b, _ := json.Marshal(summary)
+	logger.Info("WebSocket", fmt.Sprintf("Notifying %d followers of thread %s in room %s", len(followers), m.ThreadRootID, m.RoomID))
+	hub.broadcastRoom(m.RoomID, WSMessage{Type: "thread", Action: "updated", Data: b})
+}
+
+// handleThreadFollow 处理关注/取消关注话题，结果推送给用户的所有设备
+func (c *Client) handleThreadFollow(msg WSMessage) {
+	var d struct {
+		RoomID    string `json:"roomId"`
+		MessageID string `json:"messageId"`
+	}
+	if err := json.Unmarshal(msg.Data, &d); err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to parse thread follow data from user %s", c.UserID), err)
+		c.sendError("invalid_data", "Invalid thread data")
+		return
+	}
+
+	if queries == nil {
+		logger.Error("WebSocket", "Database queries not initialized", nil)
+		c.sendError("internal_error", "Database not available")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	follow := msg.Action == "follow"
+	rootID, err := msgservice.FollowThread(ctx, queries, c.UserID, d.RoomID, d.MessageID, follow)
+	var threadErr *msgservice.Error
+	if errors.As(err, &threadErr) {
+		c.sendError(threadErr.Code, threadErr.Message)
+		return
+	}
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to %s thread %s for user %s", msg.Action, d.MessageID, c.UserID), err)
+		c.sendError("internal_error", "Failed to update thread subscription")
+		return
+	}
+
+	NotifyThreadFollow(c.UserID, d.RoomID, rootID, follow)
+}
+
+// NotifyThreadFollow 通知用户的所有设备其话题关注状态已变化（跨实例）
+func NotifyThreadFollow(userID, roomID, rootMessageID string, follow bool) {
+	action := "unfollowed"
+	if follow {
+		action = "followed"
+	}
+	data, _ := json.Marshal(map[string]string{
+		"roomId":        roomID,
+		"rootMessageId": rootMessageID,
+	})
+	SendToUser(userID, WSMessage{Type: "thread", Action: action, Data: data})
+}
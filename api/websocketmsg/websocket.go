@@ -4,9 +4,12 @@ import (
 	sqlcdb "chatroombackend/db"
 	"chatroombackend/logger"
 	"chatroombackend/middleware"
+	"chatroombackend/msgservice"
+	"chatroombackend/utils"
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -23,28 +26,62 @@ var upgrader = websocket.Upgrader{
 }
 
 type Client struct {
-	Conn   *websocket.Conn
-	UserID string
-	Send   chan []byte
+	Conn        *websocket.Conn
+	UserID      string
+	SessionID   string // 登录会话编号（来自 token 的 sid 声明）
+	ConnID      string // 连接编号，同一用户的多个设备各自独立
+	Device      string // 设备类型（web / ios / android / desktop 等，由客户端上报）
+	UserAgent   string
+	RemoteAddr  string
+	ConnectedAt time.Time
+	Send        chan []byte
+
+	closeCh     chan struct{} // 关闭信号，由 writePump 发送完剩余消息后关闭连接
+	closeOnce   sync.Once
+	closeReason string
+}
+
+// ConnectionInfo 单个连接的设备信息
+type ConnectionInfo struct {
+	ConnID      string    `json:"connId"`
+	SessionID   string    `json:"sessionId"`
+	Device      string    `json:"device"`
+	UserAgent   string    `json:"userAgent"`
+	RemoteAddr  string    `json:"remoteAddr"`
+	ConnectedAt time.Time `json:"connectedAt"`
 }
 
 type Hub struct {
-	Clients    map[string]*Client // userId -> client
+	Clients    map[string]map[string]*Client // userId -> connId -> client
 	ClientsMux sync.RWMutex
-	Rooms      map[string]map[string]bool // roomId -> set of userIds
+	Rooms      map[string]map[string]map[string]*Client // roomId -> userId -> connId -> client，按连接订阅
 	RoomsMux   sync.RWMutex
+
+	// 按用户串行化连接建立与断开，保证"第一个连接"与"最后一个连接"的副作用（上下线、订阅房间）不会交错
+	userLocks    map[string]*userLock
+	userLocksMux sync.Mutex
+}
+
+type userLock struct {
+	mu   sync.Mutex
+	refs int
 }
 
 var hub = &Hub{
-	Clients: make(map[string]*Client),
-	Rooms:   make(map[string]map[string]bool),
+	Clients:   make(map[string]map[string]*Client),
+	Rooms:     make(map[string]map[string]map[string]*Client),
+	userLocks: make(map[string]*userLock),
 }
 
+// 客户端上报的设备类型长度上限（字符数），超出部分截断
+const maxDeviceLength = 32
+
 var queries *sqlcdb.Queries
 
 // SetQueries 注入 sqlc 生成的 Queries 对象
 func SetQueries(q *sqlcdb.Queries) {
 	queries = q
+	startDeliveryWorker()
 }
 
 // WebSocket 消息结构
@@ -71,6 +108,18 @@ func HandleWebSocket(c *gin.Context) {
 	}
 	userId := claims.UserID
 
+	// 校验登录会话是否已被吊销
+	if queries != nil {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+		err := middleware.ValidateSession(ctx, queries, claims)
+		cancel()
+		if err != nil {
+			logger.Warn("WebSocket", fmt.Sprintf("Connection attempt with revoked session %s for user %s: %v", claims.SessionID, userId, err))
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "session revoked"})
+			return
+		}
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		logger.Error("WebSocket", fmt.Sprintf("Failed to upgrade connection for user %s", userId), err)
@@ -79,122 +128,259 @@ func HandleWebSocket(c *gin.Context) {
 
 	logger.Info("WebSocket", fmt.Sprintf("User %s connected from %s", userId, c.ClientIP()))
 
-	client := &Client{
-		Conn:   conn,
-		UserID: userId,
-		Send:   make(chan []byte, 256),
+	device := c.Query("device")
+	if device == "" {
+		device = "unknown"
+	} else if r := []rune(device); len(r) > maxDeviceLength {
+		device = string(r[:maxDeviceLength])
 	}
-
-	hub.ClientsMux.Lock()
-	hub.Clients[userId] = client
-	hub.ClientsMux.Unlock()
+	client := &Client{
+		Conn:        conn,
+		UserID:      userId,
+		SessionID:   claims.SessionID,
+		ConnID:      utils.GenerateConnectionID(),
+		Device:      device,
+		UserAgent:   c.Request.UserAgent(),
+		RemoteAddr:  c.ClientIP(),
+		ConnectedAt: time.Now(),
+		Send:        make(chan []byte, 256),
+		closeCh:     make(chan struct{}),
+	}
+
+	unlock := hub.lockUser(userId)
+	firstConn := hub.register(client)
+	logger.Info("WebSocket", fmt.Sprintf("User %s registered connection %s (device: %s, first: %v)", userId, client.ConnID, device, firstConn))
 
 	go client.writePump()
 
-	// 标记为在线并订阅用户加入的房间（断线重连支持）
+	// 告知客户端本次连接的编号
+	data, _ := json.Marshal(map[string]string{
+		"connId": client.ConnID,
+		"device": device,
+	})
+	b, _ := json.Marshal(WSMessage{
+		Type:   "connection",
+		Action: "established",
+		Data:   data,
+	})
+	client.Send <- b
+
+	// 每个连接独立订阅用户加入的房间（断线重连支持）；在线状态按所有实例上的连接数维护
+	if queries != nil {
+		onConnect(client)
+	}
+	presenceConnect(client)
+	unlock()
+
+	// 推送未读通知数量，离线期间产生的通知在重连后即可感知
 	if queries != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		defer cancel()
-		// 将用户设置为在线
-		if err := queries.SetUserOnline(ctx, userId); err != nil {
-			logger.Error("WebSocket", fmt.Sprintf("Failed to set user %s online", userId), err)
-		} else {
-			logger.Info("WebSocket", fmt.Sprintf("User %s set to online", userId))
-		}
-		// 列出用户的聊天室并加入 hub.rooms
-		rooms, err := queries.ListUserChatrooms(ctx, sqlcdb.ListUserChatroomsParams{UserID: userId, Limit: 1000, Offset: 0})
-		if err == nil {
-			logger.Info("WebSocket", fmt.Sprintf("User %s auto-joining %d rooms", userId, len(rooms)))
-			for _, r := range rooms {
-				hub.joinRoom(userId, r.RoomID)
-				// 尝试增加聊天室在线计数（容错）
-				if err := queries.IncrementChatroomOnlineCount(ctx, r.RoomID); err != nil {
-					logger.Error("WebSocket", fmt.Sprintf("Failed to increment online count for room %s", r.RoomID), err)
-				}
-			}
+		unread, err := queries.CountUnreadNotifications(ctx, userId)
+		cancel()
+		if err != nil {
+			logger.Error("WebSocket", fmt.Sprintf("Failed to count unread notifications for user %s", userId), err)
 		} else {
-			logger.Error("WebSocket", fmt.Sprintf("Failed to list rooms for user %s", userId), err)
+			data, _ := json.Marshal(map[string]int64{"unreadCount": unread})
+			b, _ := json.Marshal(WSMessage{
+				Type:   "notification",
+				Action: "unread",
+				Data:   data,
+			})
+			client.Send <- b
 		}
 	}
 
 	client.readPump()
 
-	logger.Info("WebSocket", fmt.Sprintf("User %s disconnecting", userId))
+	logger.Info("WebSocket", fmt.Sprintf("User %s disconnecting connection %s", userId, client.ConnID))
 
-	hub.ClientsMux.Lock()
-	delete(hub.Clients, userId)
-	hub.ClientsMux.Unlock()
+	unlock = hub.lockUser(userId)
+	hub.unregister(client)
+	// 取消该连接的所有房间订阅；所有实例上都没有连接时设置离线
+	onDisconnect(client)
+	presenceDisconnect(client)
+	unlock()
 
-	// 断开连接，取消所有房间订阅并设置离线
-	if queries != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		defer cancel()
-		// 将用户设置为离线
-		if err := queries.SetUserOffline(ctx, userId); err != nil {
-			logger.Error("WebSocket", fmt.Sprintf("Failed to set user %s offline", userId), err)
-		} else {
-			logger.Info("WebSocket", fmt.Sprintf("User %s set to offline", userId))
+	_ = conn.Close()
+	logger.Info("WebSocket", fmt.Sprintf("User %s connection %s closed", userId, client.ConnID))
+}
+
+// onConnect 连接建立后，为该连接订阅用户加入的所有房间。调用方持有该用户的连接锁
+func onConnect(client *Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	// 列出用户的聊天室并加入 hub.rooms
+	rooms, err := queries.ListUserChatrooms(ctx, sqlcdb.ListUserChatroomsParams{UserID: client.UserID, Limit: 1000, Offset: 0})
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to list rooms for user %s", client.UserID), err)
+		return
+	}
+	logger.Info("WebSocket", fmt.Sprintf("User %s connection %s auto-joining %d rooms", client.UserID, client.ConnID, len(rooms)))
+	for _, r := range rooms {
+		hub.joinRoom(client, r.RoomID)
+	}
+}
+
+// onDisconnect 连接断开后，取消该连接的所有房间订阅，同一用户其他连接的订阅不受影响。调用方持有该用户的连接锁
+func onDisconnect(client *Client) {
+	hub.RoomsMux.Lock()
+	roomCount := 0
+	for roomID := range hub.Rooms {
+		if hub.unsubscribeLocked(client.UserID, client.ConnID, roomID) {
+			roomCount++
 		}
-		// 从所有房间移除并减少在线计数
-		hub.RoomsMux.Lock()
-		roomCount := 0
-		for roomID := range hub.Rooms {
-			if _, ok := hub.Rooms[roomID][userId]; ok {
-				delete(hub.Rooms[roomID], userId)
-				roomCount++
-				if err := queries.DecrementChatroomOnlineCount(ctx, roomID); err != nil {
-					logger.Error("WebSocket", fmt.Sprintf("Failed to decrement online count for room %s", roomID), err)
-				}
-			}
+	}
+	hub.RoomsMux.Unlock()
+	logger.Info("WebSocket", fmt.Sprintf("User %s connection %s removed from %d rooms", client.UserID, client.ConnID, roomCount))
+}
+
+// lockUser 获取用户的连接锁，返回解锁函数。锁在没有持有者时释放，避免按用户无限增长
+func (h *Hub) lockUser(userID string) func() {
+	h.userLocksMux.Lock()
+	l, ok := h.userLocks[userID]
+	if !ok {
+		l = &userLock{}
+		h.userLocks[userID] = l
+	}
+	l.refs++
+	h.userLocksMux.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		h.userLocksMux.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(h.userLocks, userID)
 		}
-		hub.RoomsMux.Unlock()
-		logger.Info("WebSocket", fmt.Sprintf("User %s removed from %d rooms", userId, roomCount))
+		h.userLocksMux.Unlock()
 	}
-	_ = conn.Close()
-	logger.Info("WebSocket", fmt.Sprintf("User %s connection closed", userId))
+}
+
+// register 注册连接，返回是否为该用户的第一个连接
+func (h *Hub) register(client *Client) bool {
+	h.ClientsMux.Lock()
+	defer h.ClientsMux.Unlock()
+	conns, ok := h.Clients[client.UserID]
+	if !ok {
+		conns = make(map[string]*Client)
+		h.Clients[client.UserID] = conns
+	}
+	conns[client.ConnID] = client
+	return len(conns) == 1
+}
+
+// unregister 注销连接，返回是否为该用户的最后一个连接
+func (h *Hub) unregister(client *Client) bool {
+	h.ClientsMux.Lock()
+	defer h.ClientsMux.Unlock()
+	conns, ok := h.Clients[client.UserID]
+	if !ok {
+		return false
+	}
+	if existing, ok := conns[client.ConnID]; !ok || existing != client {
+		return false
+	}
+	delete(conns, client.ConnID)
+	if len(conns) == 0 {
+		delete(h.Clients, client.UserID)
+		return true
+	}
+	return false
+}
+
+// userClients 获取用户当前的所有连接（快照）
+func (h *Hub) userClients(userID string) []*Client {
+	h.ClientsMux.RLock()
+	defer h.ClientsMux.RUnlock()
+	conns := h.Clients[userID]
+	clients := make([]*Client, 0, len(conns))
+	for _, client := range conns {
+		clients = append(clients, client)
+	}
+	return clients
 }
 
 // hub: 加入与广播辅助
-func (h *Hub) joinRoom(userID, roomID string) {
+// joinRoom 为连接订阅房间，返回是否为本实例上该用户第一个订阅该房间的连接
+func (h *Hub) joinRoom(client *Client, roomID string) bool {
 	h.RoomsMux.Lock()
 	defer h.RoomsMux.Unlock()
-	set, ok := h.Rooms[roomID]
+	users, ok := h.Rooms[roomID]
+	if !ok {
+		users = make(map[string]map[string]*Client)
+		h.Rooms[roomID] = users
+	}
+	conns, ok := users[client.UserID]
 	if !ok {
-		set = make(map[string]bool)
-		h.Rooms[roomID] = set
+		conns = make(map[string]*Client)
+		users[client.UserID] = conns
 	}
-	set[userID] = true
+	conns[client.ConnID] = client
+	return !ok
 }
 
-func (h *Hub) leaveRoom(userID, roomID string) {
+// leaveRoom 取消连接的房间订阅，返回是否为本实例上该用户最后一个订阅该房间的连接
+func (h *Hub) leaveRoom(client *Client, roomID string) bool {
 	h.RoomsMux.Lock()
 	defer h.RoomsMux.Unlock()
-	if set, ok := h.Rooms[roomID]; ok {
-		delete(set, userID)
-		if len(set) == 0 {
-			delete(h.Rooms, roomID)
-		}
+	if !h.unsubscribeLocked(client.UserID, client.ConnID, roomID) {
+		return false
+	}
+	_, still := h.Rooms[roomID][client.UserID]
+	return !still
+}
+
+// unsubscribeLocked 移除连接的房间订阅，返回此前是否已订阅。调用方持有 RoomsMux
+func (h *Hub) unsubscribeLocked(userID, connID, roomID string) bool {
+	users, ok := h.Rooms[roomID]
+	if !ok {
+		return false
 	}
+	conns, ok := users[userID]
+	if !ok {
+		return false
+	}
+	if _, ok := conns[connID]; !ok {
+		return false
+	}
+	delete(conns, connID)
+	if len(conns) == 0 {
+		delete(users, userID)
+	}
+	if len(users) == 0 {
+		delete(h.Rooms, roomID)
+	}
+	return true
 }
 
+// broadcastRoom 通过 Broker 将消息广播到所有实例上的房间订阅者
 func (h *Hub) broadcastRoom(roomID string, msg WSMessage) {
+	publish(BrokerEvent{Kind: EventRoom, Target: roomID, Message: msg})
+}
+
+// deliverRoom 将消息投递给本实例上订阅该房间的连接
+func (h *Hub) deliverRoom(roomID string, msg WSMessage) {
 	h.RoomsMux.RLock()
-	members, ok := h.Rooms[roomID]
+	users, ok := h.Rooms[roomID]
+	clients := []*Client{}
+	for _, conns := range users {
+		for _, client := range conns {
+			clients = append(clients, client)
+		}
+	}
 	h.RoomsMux.RUnlock()
 	if !ok {
 		return
 	}
 	b, _ := json.Marshal(msg)
-	for uid := range members {
-		h.ClientsMux.RLock()
-		if client, ok := h.Clients[uid]; ok {
-			select {
-			case client.Send <- b:
-			default:
-				// 如果发送通道阻塞，跳过
-			}
+	for _, client := range clients {
+		select {
+		case client.Send <- b:
+		default:
+			// 发送通道阻塞时丢弃，客户端可根据消息序号发现缺口并通过 sync 补齐
+			logger.Warn("WebSocket", fmt.Sprintf("Dropped %s/%s event for user %s in room %s: send buffer full", msg.Type, msg.Action, client.UserID, roomID))
 		}
-		h.ClientsMux.RUnlock()
 	}
 }
 
@@ -246,19 +432,38 @@ func (c *Client) writePump() {
 				return
 			}
 			_, _ = w.Write(message)
+			frames := [][]byte{message}
 
 			// Add queued messages to the current message
 			n := len(c.Send)
 			for i := 0; i < n; i++ {
+				next := <-c.Send
 				_, _ = w.Write([]byte{'\n'})
-				_, _ = w.Write(<-c.Send)
+				_, _ = w.Write(next)
+				frames = append(frames, next)
 			}
 
 			if err := w.Close(); err != nil {
 				logger.Error("WebSocket", fmt.Sprintf("Failed to close writer for user %s", c.UserID), err)
 				return
 			}
+			// 写入成功后记录送达
+			for _, f := range frames {
+				c.ackDelivered(f)
+			}
 			logger.Debug("WebSocket", fmt.Sprintf("Sent message to user %s (%d bytes)", c.UserID, len(message)))
+		case <-c.closeCh:
+			// 先发送已排队的消息，再以关闭帧告知客户端原因
+			_ = c.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			for n := len(c.Send); n > 0; n-- {
+				next := <-c.Send
+				if err := c.Conn.WriteMessage(websocket.TextMessage, next); err == nil {
+					c.ackDelivered(next)
+				}
+			}
+			_ = c.Conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, c.closeReason))
+			logger.Info("WebSocket", fmt.Sprintf("Closed connection %s for user %s: %s", c.ConnID, c.UserID, c.closeReason))
+			return
 		case <-ticker.C:
 			_ = c.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
 			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
@@ -318,6 +523,24 @@ func (c *Client) handleMessage(message []byte) {
 		return
 	}
 
+	// 处理表情回应
+	if msg.Type == "reaction" && (msg.Action == "add" || msg.Action == "remove") {
+		c.handleReaction(msg)
+		return
+	}
+
+	// 处理关注/取消关注话题
+	if msg.Type == "thread" && (msg.Action == "follow" || msg.Action == "unfollow") {
+		c.handleThreadFollow(msg)
+		return
+	}
+
+	// 处理断线重连后的消息同步
+	if msg.Type == "sync" {
+		c.handleSync(msg)
+		return
+	}
+
 	// 处理正在输入状态
 	if msg.Type == "typing" {
 		c.handleTypingStatus(msg)
@@ -327,15 +550,18 @@ func (c *Client) handleMessage(message []byte) {
 	logger.Warn("WebSocket", fmt.Sprintf("Unknown message type/action from user %s: %s/%s", c.UserID, msg.Type, msg.Action))
 }
 
-// handleSendMessage 处理发送消息
+// handleSendMessage 处理发送消息，校验、持久化与广播由 msgservice 完成
 func (c *Client) handleSendMessage(msg WSMessage) {
 	// 解析 data
 	var d struct {
-		RoomID          string  `json:"roomId"`
-		MessageType     string  `json:"messageType"`
-		Text            string  `json:"text"`
-		QuotedMessageID *string `json:"quotedMessageId,omitempty"`
-		MediaURL        *string `json:"mediaUrl,omitempty"`
+		RoomID          string `json:"roomId"`
+		MessageType     string `json:"messageType"`
+		Text            string `json:"text"`
+		QuotedMessageID string `json:"quotedMessageId,omitempty"`
+		MediaURL        string `json:"mediaUrl,omitempty"`
+		ClientMsgID     string `json:"clientMsgId,omitempty"`
+		ThreadRootID    string `json:"threadRootId,omitempty"`
+		AlsoSendToRoom  bool   `json:"alsoSendToRoom,omitempty"`
 	}
 	if err := json.Unmarshal(msg.Data, &d); err != nil {
 		logger.Error("WebSocket", fmt.Sprintf("Failed to parse send message data from user %s", c.UserID), err)
@@ -355,134 +581,39 @@ func (c *Client) handleSendMessage(msg WSMessage) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// 检查用户是否在房间中
-	inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{UserID: c.UserID, RoomID: d.RoomID})
-	if err != nil {
-		logger.Error("WebSocket", fmt.Sprintf("Error checking user %s in room %s", c.UserID, d.RoomID), err)
-		c.sendError("internal_error", "Failed to verify room membership")
-		return
-	}
-	if !inRoom {
-		logger.Warn("WebSocket", fmt.Sprintf("User %s not in room %s", c.UserID, d.RoomID))
-		c.sendError("not_in_room", "You are not a member of this room")
-		return
-	}
-
-	// 检查是否被禁言（全局或房间）
-	canSend, err := queries.CanUserSendMessageInRoom(ctx, sqlcdb.CanUserSendMessageInRoomParams{MutedUserID: c.UserID, RoomID: d.RoomID})
-	if err != nil {
-		logger.Error("WebSocket", fmt.Sprintf("Error checking mute status for user %s in room %s", c.UserID, d.RoomID), err)
-		c.sendError("internal_error", "Failed to check permissions")
-		return
-	}
-	if !canSend.Valid || !canSend.Bool {
-		logger.Warn("WebSocket", fmt.Sprintf("User %s is muted in room %s", c.UserID, d.RoomID))
-		c.sendError("muted", "You are muted and cannot send messages")
-		return
-	}
-
-	// 构建 CreateMessageParams
-	var quoted sql.NullString
-	if d.QuotedMessageID != nil && *d.QuotedMessageID != "" {
-		quoted = sql.NullString{String: *d.QuotedMessageID, Valid: true}
-	}
-	sender := sql.NullString{String: c.UserID, Valid: true}
-
-	// 验证消息类型
-	mt := sqlcdb.MessageType(d.MessageType)
-	validTypes := []sqlcdb.MessageType{
-		sqlcdb.MessageTypeText,
-		sqlcdb.MessageTypeImage,
-		sqlcdb.MessageTypeFile,
-		sqlcdb.MessageTypeSystemNotification,
-	}
-	isValidType := false
-	for _, vt := range validTypes {
-		if mt == vt {
-			isValidType = true
-			break
-		}
-	}
-	if !isValidType {
-		logger.Warn("WebSocket", fmt.Sprintf("Invalid message type from user %s: %s", c.UserID, d.MessageType))
-		c.sendError("invalid_type", "Invalid message type")
-		return
-	}
-
-	// 对于非文本消息，需要 mediaUrl
-	if mt != sqlcdb.MessageTypeText && mt != sqlcdb.MessageTypeSystemNotification {
-		if d.MediaURL == nil || *d.MediaURL == "" {
-			logger.Warn("WebSocket", fmt.Sprintf("Missing media URL for type %s from user %s", d.MessageType, c.UserID))
-			c.sendError("missing_media", "Media URL required for this message type")
-			return
-		}
-	}
-
-	createParams := sqlcdb.CreateMessageParams{
-		Content:         d.Text,
-		MessageType:     mt,
-		QuotedMessageID: quoted,
-		SenderID:        sender,
+	sent, err := msgservice.Send(ctx, queries, msgservice.Input{
 		RoomID:          d.RoomID,
+		SenderID:        c.UserID,
+		MessageType:     d.MessageType,
+		Text:            d.Text,
+		QuotedMessageID: d.QuotedMessageID,
+		MediaURL:        d.MediaURL,
+		ClientMsgID:     d.ClientMsgID,
+		ThreadRootID:    d.ThreadRootID,
+		AlsoSendToRoom:  d.AlsoSendToRoom,
+	})
+	var sendErr *msgservice.Error
+	if errors.As(err, &sendErr) {
+		logger.Warn("WebSocket", fmt.Sprintf("Message from user %s rejected in room %s: %s", c.UserID, d.RoomID, sendErr.Code))
+		c.sendError(sendErr.Code, sendErr.Message)
+		return
 	}
-
-	// 创建消息
-	m, err := queries.CreateMessage(ctx, createParams)
 	if err != nil {
 		logger.Error("WebSocket", fmt.Sprintf("Failed to create message from user %s in room %s", c.UserID, d.RoomID), err)
 		c.sendError("internal_error", "Failed to create message")
 		return
 	}
 
-	logger.Info("WebSocket", fmt.Sprintf("Message created: %s from user %s in room %s", m.MessageID, c.UserID, d.RoomID))
-
-	// 获取带发送者信息的消息（包含昵称等）
-	mm, err := queries.GetMessageWithSender(ctx, m.MessageID)
-	if err != nil {
-		logger.Error("WebSocket", fmt.Sprintf("Failed to get message with sender for message %s", m.MessageID), err)
-		// 仍然继续，使用基础信息
-	}
-
-	// 组装广播消息
-	out := map[string]interface{}{
-		"messageId": m.MessageID,
-		"roomId":    m.RoomID,
-		"userId":    c.UserID,
-		"type":      string(m.MessageType),
-		"text":      m.Content,
-		"time":      m.SentAt.UTC().Format(time.RFC3339),
-	}
-
-	if err == nil {
-		out["userName"] = chooseDisplayName(mm.Username, mm.Nickname)
-		if mm.AvatarUrl.Valid {
-			out["avatarUrl"] = mm.AvatarUrl.String
+	// 重试发送不会再次广播，单独回复当前连接以便客户端确认
+	if sent.Duplicate {
+		event := WSMessage{Type: "message", Action: "new"}
+		if sent.ThreadRootID != "" {
+			event = WSMessage{Type: "thread", Action: "reply"}
 		}
+		event.Data, _ = json.Marshal(sent)
+		b, _ := json.Marshal(event)
+		c.Send <- b
 	}
-
-	if d.QuotedMessageID != nil && *d.QuotedMessageID != "" {
-		out["quotedMessageId"] = *d.QuotedMessageID
-	}
-
-	if d.MediaURL != nil && *d.MediaURL != "" {
-		out["mediaUrl"] = *d.MediaURL
-	}
-
-	outMsg := WSMessage{Type: "message", Action: "new"}
-	b, _ := json.Marshal(out)
-	outMsg.Data = b
-
-	// 更新房间最后活跃时间（异步）
-	go func(roomID string) {
-		ctx := context.Background()
-		if err := queries.UpdateChatroomLastActiveTime(ctx, roomID); err != nil {
-			logger.Error("WebSocket", fmt.Sprintf("Failed to update last active time for room %s", roomID), err)
-		}
-	}(d.RoomID)
-
-	// 广播到房间
-	logger.Info("WebSocket", fmt.Sprintf("Broadcasting message %s to room %s", m.MessageID, d.RoomID))
-	hub.broadcastRoom(d.RoomID, outMsg)
 }
 
 // handleJoinRoom 处理用户加入房间
@@ -519,13 +650,8 @@ func (c *Client) handleJoinRoom(msg WSMessage) {
 		return
 	}
 
-	// 加入房间的 WebSocket 订阅
-	hub.joinRoom(c.UserID, d.RoomID)
-
-	// 增加房间在线计数
-	if err := queries.IncrementChatroomOnlineCount(ctx, d.RoomID); err != nil {
-		logger.Error("WebSocket", fmt.Sprintf("Failed to increment online count for room %s", d.RoomID), err)
-	}
+	// 为当前连接订阅房间；房间在线人数按成员的在线状态统计，与订阅无关
+	first := hub.joinRoom(c, d.RoomID)
 
 	logger.Info("WebSocket", fmt.Sprintf("User %s successfully joined room %s", c.UserID, d.RoomID))
 
@@ -538,6 +664,11 @@ func (c *Client) handleJoinRoom(msg WSMessage) {
 	b, _ := json.Marshal(resp)
 	c.Send <- b
 
+	// 用户的其他连接已订阅该房间时不重复广播
+	if !first {
+		return
+	}
+
 	// 广播用户加入消息到房间（可选）
 	joinNotice := WSMessage{
 		Type:   "room_member",
@@ -559,17 +690,8 @@ func (c *Client) handleLeaveRoom(msg WSMessage) {
 
 	logger.Info("WebSocket", fmt.Sprintf("User %s leaving room %s via WebSocket", c.UserID, d.RoomID))
 
-	hub.leaveRoom(c.UserID, d.RoomID)
-
-	if queries != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		defer cancel()
-
-		// 减少房间在线计数
-		if err := queries.DecrementChatroomOnlineCount(ctx, d.RoomID); err != nil {
-			logger.Error("WebSocket", fmt.Sprintf("Failed to decrement online count for room %s", d.RoomID), err)
-		}
-	}
+	// 只取消当前连接的订阅，同一用户的其他设备仍可收到房间消息
+	last := hub.leaveRoom(c, d.RoomID)
 
 	logger.Info("WebSocket", fmt.Sprintf("User %s successfully left room %s", c.UserID, d.RoomID))
 
@@ -582,6 +704,11 @@ func (c *Client) handleLeaveRoom(msg WSMessage) {
 	b, _ := json.Marshal(resp)
 	c.Send <- b
 
+	// 用户还有其他连接订阅该房间时不广播离开
+	if !last {
+		return
+	}
+
 	// 广播用户离开消息到房间（可选）
 	leaveNotice := WSMessage{
 		Type:   "room_member",
@@ -629,12 +756,24 @@ func (c *Client) handleUserStatusUpdate(msg WSMessage) {
 	}
 
 	logger.Info("WebSocket", fmt.Sprintf("User %s status updated to %s", c.UserID, d.Status))
+	syncOnlineCounts(ctx, c.UserID)
+
+	// 用户关闭"显示在线状态"时不对外广播
+	show, err := queries.ShouldShowOnlineStatus(ctx, c.UserID)
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to load privacy settings for user %s", c.UserID), err)
+		return
+	}
+	if !show {
+		logger.Debug("WebSocket", fmt.Sprintf("User %s hides online status, skipping broadcast", c.UserID))
+		return
+	}
 
 	// 广播状态更新到所有相关房间
 	hub.RoomsMux.RLock()
 	userRooms := []string{}
-	for roomID, members := range hub.Rooms {
-		if members[c.UserID] {
+	for roomID, users := range hub.Rooms {
+		if _, ok := users[c.UserID]; ok {
 			userRooms = append(userRooms, roomID)
 		}
 	}
@@ -662,10 +801,121 @@ func (c *Client) handleMessageRead(msg WSMessage) {
 		return
 	}
 
+	if d.RoomID == "" || d.MessageID == "" {
+		c.sendError("invalid_data", "roomId and messageId are required")
+		return
+	}
+
+	if queries == nil {
+		logger.Error("WebSocket", "Database queries not initialized", nil)
+		c.sendError("internal_error", "Database not available")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 消息不属于该房间或用户不在房间中时不会更新任何记录
+	row, err := queries.UpdateMemberLastReadToMessage(ctx, sqlcdb.UpdateMemberLastReadToMessageParams{
+		MessageID: d.MessageID,
+		UserID:    c.UserID,
+		RoomID:    d.RoomID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		c.sendError("invalid_message", "Message not found in this room or you are not a member")
+		return
+	}
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to update read position of user %s in room %s", c.UserID, d.RoomID), err)
+		c.sendError("internal_error", "Failed to update read status")
+		return
+	}
+
 	logger.Info("WebSocket", fmt.Sprintf("User %s marked message %s as read in room %s", c.UserID, d.MessageID, d.RoomID))
 
-	// 这里可以更新数据库中的已读状态
-	// 目前只记录日志
+	// 已读位置未前进（确认的是更早的消息）时不重复广播
+	if row.LastReadAt.Valid && row.LastReadAt.Time.After(row.SentAt) {
+		return
+	}
+	NotifyReadReceipt(d.RoomID, c.UserID, d.MessageID, row.SentAt)
+	MarkReceiptsRead(ctx, d.RoomID, c.UserID, row.SentAt)
+}
+
+// handleReaction 处理添加/取消表情回应，校验与持久化由 msgservice 完成
+func (c *Client) handleReaction(msg WSMessage) {
+	var d struct {
+		RoomID    string `json:"roomId"`
+		MessageID string `json:"messageId"`
+		Emoji     string `json:"emoji"`
+	}
+	if err := json.Unmarshal(msg.Data, &d); err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to parse reaction data from user %s", c.UserID), err)
+		c.sendError("invalid_data", "Invalid reaction data")
+		return
+	}
+
+	if queries == nil {
+		logger.Error("WebSocket", "Database queries not initialized", nil)
+		c.sendError("internal_error", "Database not available")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	r, err := msgservice.React(ctx, queries, c.UserID, d.RoomID, d.MessageID, d.Emoji, msg.Action == "add")
+	var reactErr *msgservice.Error
+	if errors.As(err, &reactErr) {
+		c.sendError(reactErr.Code, reactErr.Message)
+		return
+	}
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to %s reaction of user %s on message %s", msg.Action, c.UserID, d.MessageID), err)
+		c.sendError("internal_error", "Failed to update reaction")
+		return
+	}
+
+	if r.Changed {
+		NotifyReaction(r)
+	}
+}
+
+// handleSync 处理消息同步：返回各聊天室中客户端已知序号之后的新消息、编辑与撤回，仅回复当前连接
+func (c *Client) handleSync(msg WSMessage) {
+	var d struct {
+		Rooms       map[string]int64 `json:"rooms"`       // roomId -> lastSeq
+		MessageSeqs map[string]int64 `json:"messageSeqs"` // roomId -> 上一页返回的 messageSeq（分页时）
+	}
+	if err := json.Unmarshal(msg.Data, &d); err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to parse sync data from user %s", c.UserID), err)
+		c.sendError("invalid_data", "Invalid sync data")
+		return
+	}
+	if len(d.Rooms) > msgservice.MaxSyncRooms {
+		c.sendError("invalid_data", fmt.Sprintf("At most %d rooms per sync", msgservice.MaxSyncRooms))
+		return
+	}
+
+	if queries == nil {
+		logger.Error("WebSocket", "Database queries not initialized", nil)
+		c.sendError("internal_error", "Database not available")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rooms, err := msgservice.Sync(ctx, queries, c.UserID, d.Rooms, d.MessageSeqs)
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to sync messages for user %s", c.UserID), err)
+		c.sendError("internal_error", "Failed to sync messages")
+		return
+	}
+
+	data, _ := json.Marshal(map[string]any{"rooms": rooms})
+	b, _ := json.Marshal(WSMessage{Type: "sync", Action: "result", Data: data})
+	logger.Info("WebSocket", fmt.Sprintf("Synced %d rooms for user %s", len(rooms), c.UserID))
+	c.Send <- b
 }
 
 // handleTypingStatus 处理正在输入状态
@@ -690,6 +940,14 @@ func (c *Client) handleTypingStatus(msg WSMessage) {
 	hub.broadcastRoom(d.RoomID, typingMsg)
 }
 
+// close 通知 writePump 发送完剩余消息后关闭连接，readPump 随之退出并完成清理
+func (c *Client) close(reason string) {
+	c.closeOnce.Do(func() {
+		c.closeReason = reason
+		close(c.closeCh)
+	})
+}
+
 // sendError 发送错误消息给客户端
 func (c *Client) sendError(action string, message string) {
 	errMsg := WSMessage{
@@ -701,24 +959,24 @@ func (c *Client) sendError(action string, message string) {
 	c.Send <- b
 }
 
-func chooseDisplayName(username sql.NullString, nickname sql.NullString) string {
-	if nickname.Valid && nickname.String != "" {
-		return nickname.String
-	}
-	if username.Valid {
-		return username.String
-	}
-	return ""
+// SendToUser 广播消息给指定用户的所有设备（跨实例）
+func SendToUser(userId string, msg WSMessage) {
+	publish(BrokerEvent{Kind: EventUser, Target: userId, Message: msg})
 }
 
-// SendToUser 广播消息给指定用户
-func SendToUser(userId string, msg WSMessage) {
-	hub.ClientsMux.RLock()
-	client, ok := hub.Clients[userId]
-	hub.ClientsMux.RUnlock()
-	if ok {
-		b, _ := json.Marshal(msg)
-		client.Send <- b
+// deliverToUser 将消息投递给本实例上该用户的所有连接
+func (h *Hub) deliverToUser(userId string, msg WSMessage) {
+	clients := h.userClients(userId)
+	if len(clients) == 0 {
+		return
+	}
+	b, _ := json.Marshal(msg)
+	for _, client := range clients {
+		select {
+		case client.Send <- b:
+		default:
+			// 如果发送通道阻塞，跳过
+		}
 	}
 }
 
@@ -761,6 +1019,7 @@ func SendSystemMessage(roomID string, content string, memberID ...string) error
 		"type":      "system_notification",
 		"text":      m.Content,
 		"time":      m.SentAt.UTC().Format(time.RFC3339),
+		"seq":       m.Seq,
 	}
 
 	outMsg := WSMessage{Type: "message", Action: "new"}
@@ -790,6 +1049,50 @@ func NotifyRoomMemberChange(roomID, userID, action string) {
 	hub.broadcastRoom(roomID, msg)
 }
 
+// NotifyOwnerChanged 通知房间房主变更，原房主降为管理员
+// mode: manual（房主主动转让）| leave（房主退出）| account_deleted（房主注销账号）
+func NotifyOwnerChanged(roomID, fromUserID, toUserID, mode string) {
+	data, _ := json.Marshal(map[string]string{
+		"roomId":     roomID,
+		"fromUserId": fromUserID,
+		"toUserId":   toUserID,
+		"mode":       mode,
+		"timestamp":  time.Now().UTC().Format(time.RFC3339),
+	})
+	msg := WSMessage{
+		Type:   "room_member",
+		Action: "owner_changed",
+		Data:   data,
+	}
+	logger.Info("WebSocket", fmt.Sprintf("Notifying room %s of owner change from %s to %s (%s)", roomID, fromUserID, toUserID, mode))
+	hub.broadcastRoom(roomID, msg)
+}
+
+// NotifyRoomArchived 通知房间已归档（客户端切换为只读）或已取消归档
+func NotifyRoomArchived(roomID, operatorID string, archived bool) {
+	action := "archived"
+	if !archived {
+		action = "unarchived"
+	}
+	data, _ := json.Marshal(map[string]string{
+		"roomId":     roomID,
+		"operatorId": operatorID,
+		"timestamp":  time.Now().UTC().Format(time.RFC3339),
+	})
+	msg := WSMessage{
+		Type:   "room",
+		Action: action,
+		Data:   data,
+	}
+	logger.Info("WebSocket", fmt.Sprintf("Notifying room %s %s by user %s", roomID, action, operatorID))
+	hub.broadcastRoom(roomID, msg)
+}
+
+// SubscribeRoom 为用户已连接的客户端订阅房间（跨实例），加入聊天室后无需重连即可收到消息
+func SubscribeRoom(roomID, userID string) {
+	publish(BrokerEvent{Kind: EventJoinRoom, Target: roomID, UserID: userID})
+}
+
 // NotifyUserKicked 通知用户被踢出房间
 func NotifyUserKicked(userID, roomID, reason string) {
 	msg := WSMessage{
@@ -800,17 +1103,79 @@ func NotifyUserKicked(userID, roomID, reason string) {
 	logger.Info("WebSocket", fmt.Sprintf("Notifying user %s kicked from room %s", userID, roomID))
 	SendToUser(userID, msg)
 
-	// Also remove them from the room
-	hub.leaveRoom(userID, roomID)
+	// Also remove them from the room on every instance
+	publish(BrokerEvent{Kind: EventLeaveRoom, Target: roomID, UserID: userID})
+}
 
-	// Decrease online count if they're connected
-	if queries != nil {
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		defer cancel()
-		if err := queries.DecrementChatroomOnlineCount(ctx, roomID); err != nil {
-			logger.Error("WebSocket", fmt.Sprintf("Failed to decrement online count for room %s", roomID), err)
+// CloseSessionConnections 关闭用户指定登录会话的所有 WebSocket 连接（跨实例）
+func CloseSessionConnections(userID, sessionID string) {
+	logger.Info("WebSocket", fmt.Sprintf("Closing connections of session %s for user %s", sessionID, userID))
+	publish(BrokerEvent{Kind: EventDisconnect, Target: userID, SessionID: sessionID})
+}
+
+// CloseUserConnections 关闭用户所有 WebSocket 连接（跨实例）
+func CloseUserConnections(userID string) {
+	logger.Info("WebSocket", fmt.Sprintf("Closing all connections for user %s", userID))
+	publish(BrokerEvent{Kind: EventDisconnect, Target: userID})
+}
+
+// disconnect 通知并关闭本实例上用户的连接，sessionID 为空时关闭该用户全部连接
+func (h *Hub) disconnect(userID, sessionID string) {
+	msg := WSMessage{
+		Type:   "connection",
+		Action: "revoked",
+		Data:   json.RawMessage(fmt.Sprintf(`{"sessionId":"%s","timestamp":"%s"}`, sessionID, time.Now().UTC().Format(time.RFC3339))),
+	}
+	b, _ := json.Marshal(msg)
+	for _, client := range h.userClients(userID) {
+		if sessionID != "" && client.SessionID != sessionID {
+			continue
+		}
+		select {
+		case client.Send <- b:
+		default:
+		}
+		client.close("session revoked")
+	}
+}
+
+// addToRoom 为本实例上用户的所有连接订阅房间。用户成为成员后重新统计房间在线人数（重复执行结果相同）
+func (h *Hub) addToRoom(userID, roomID string) {
+	joined := false
+	for _, client := range h.userClients(userID) {
+		if h.joinRoom(client, roomID) {
+			joined = true
 		}
 	}
+	if !joined {
+		return
+	}
+	syncRoomOnlineCount(roomID)
+}
+
+// removeFromRoom 取消本实例上用户所有连接的房间订阅。用户不再是成员后重新统计房间在线人数
+func (h *Hub) removeFromRoom(userID, roomID string) {
+	h.RoomsMux.Lock()
+	conns, ok := h.Rooms[roomID][userID]
+	for connID := range conns {
+		h.unsubscribeLocked(userID, connID, roomID)
+	}
+	h.RoomsMux.Unlock()
+	if !ok {
+		return
+	}
+	syncRoomOnlineCount(roomID)
+}
+
+func syncRoomOnlineCount(roomID string) {
+	if queries == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := queries.SyncChatroomOnlineCount(ctx, sql.NullString{String: roomID, Valid: true}); err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to sync online count for room %s", roomID), err)
+	}
 }
 
 // NotifyUserMuted 通知用户被禁言
@@ -846,29 +1211,155 @@ func NotifyUserUnmuted(userID, roomID string) {
 	SendToUser(userID, msg)
 }
 
-// NotifyMessageDeleted 通知消息被删除
-func NotifyMessageDeleted(roomID, messageID string) {
+// BroadcastNewMessage 将新消息广播到聊天室（跨实例），作为 msgservice 的发送后钩子注册。
+// 话题回复推送给话题关注者，勾选同时发送到聊天室时再作为新消息广播
+func BroadcastNewMessage(ctx context.Context, q *sqlcdb.Queries, m msgservice.Payload) {
+	data, _ := json.Marshal(m)
+	if m.ThreadRootID != "" {
+		notifyThreadReply(ctx, q, m, data)
+		if !m.AlsoSendToRoom {
+			return
+		}
+	}
+	logger.Info("WebSocket", fmt.Sprintf("Broadcasting message %s to room %s", m.MessageID, m.RoomID))
+	hub.broadcastRoom(m.RoomID, WSMessage{Type: "message", Action: "new", Data: data})
+}
+
+// NotifyMessageDeleted 通知消息被撤回，seq 为此次变更的聊天室序号
+func NotifyMessageDeleted(roomID, messageID string, deletedAt time.Time, seq int64) {
+	data, _ := json.Marshal(map[string]any{
+		"roomId":    roomID,
+		"messageId": messageID,
+		"deletedAt": deletedAt.UTC().Format(time.RFC3339),
+		"seq":       seq,
+	})
 	msg := WSMessage{
 		Type:   "message",
-		Action: "deleted",
-		Data:   json.RawMessage(fmt.Sprintf(`{"roomId":"%s","messageId":"%s","timestamp":"%s"}`, roomID, messageID, time.Now().UTC().Format(time.RFC3339))),
+		Action: "delete",
+		Data:   data,
 	}
 	logger.Info("WebSocket", fmt.Sprintf("Notifying room %s of deleted message %s", roomID, messageID))
 	hub.broadcastRoom(roomID, msg)
 }
 
-// NotifyMessageEdited 通知消息被编辑
-func NotifyMessageEdited(roomID, messageID, newContent string) {
+// NotifyMessageEdited 通知消息被编辑，revision 为编辑后的修订号（即编辑次数），seq 为此次变更的聊天室序号
+func NotifyMessageEdited(roomID, messageID, newContent string, editedAt time.Time, revision int32, seq int64) {
+	data, _ := json.Marshal(map[string]any{
+		"roomId":    roomID,
+		"messageId": messageID,
+		"newText":   newContent,
+		"editedAt":  editedAt.UTC().Format(time.RFC3339),
+		"revision":  revision,
+		"seq":       seq,
+	})
 	msg := WSMessage{
 		Type:   "message",
-		Action: "edited",
-		Data:   json.RawMessage(fmt.Sprintf(`{"roomId":"%s","messageId":"%s","text":"%s","timestamp":"%s"}`, roomID, messageID, newContent, time.Now().UTC().Format(time.RFC3339))),
+		Action: "edit",
+		Data:   data,
+	}
+	logger.Info("WebSocket", fmt.Sprintf("Notifying room %s of edited message %s (revision %d)", roomID, messageID, revision))
+	hub.broadcastRoom(roomID, msg)
+}
+
+// NotifyMessagePinned 向聊天室广播消息被置顶或取消置顶（跨实例），operatorID 为操作的管理员或房主
+func NotifyMessagePinned(roomID, messageID, operatorID string, pinned bool, at time.Time) {
+	action := "unpinned"
+	if pinned {
+		action = "pinned"
+	}
+	data, _ := json.Marshal(map[string]string{
+		"roomId":     roomID,
+		"messageId":  messageID,
+		"operatorId": operatorID,
+		"at":         at.UTC().Format(time.RFC3339),
+	})
+	logger.Info("WebSocket", fmt.Sprintf("Notifying room %s of %s message %s", roomID, action, messageID))
+	BroadcastToRoom(roomID, WSMessage{Type: "message", Action: action, Data: data})
+}
+
+// NotifyReaction 向聊天室广播表情回应的添加或取消（跨实例）
+func NotifyReaction(r msgservice.Reaction) {
+	action := "removed"
+	if r.Added {
+		action = "added"
 	}
-	logger.Info("WebSocket", fmt.Sprintf("Notifying room %s of edited message %s", roomID, messageID))
+	data, _ := json.Marshal(r)
+	BroadcastToRoom(r.RoomID, WSMessage{Type: "reaction", Action: action, Data: data})
+}
+
+// NotifyReadReceipt 向聊天室广播成员的已读位置（跨实例）
+func NotifyReadReceipt(roomID, userID, messageID string, readAt time.Time) {
+	data, _ := json.Marshal(map[string]string{
+		"roomId":    roomID,
+		"userId":    userID,
+		"messageId": messageID,
+		"readAt":    readAt.UTC().Format(time.RFC3339),
+	})
+	msg := WSMessage{
+		Type:   "read_receipt",
+		Action: "updated",
+		Data:   data,
+	}
+	logger.Debug("WebSocket", fmt.Sprintf("Broadcasting read receipt of user %s in room %s: %s", userID, roomID, messageID))
 	hub.broadcastRoom(roomID, msg)
 }
 
-// GetOnlineUsersInRoom 获取房间内在线用户列表
+// BroadcastUserStatus 向用户加入的所有聊天室广播其在线状态（跨实例），并重新统计这些聊天室的在线人数，
+// 用于用户修改"显示在线状态"设置后同步其他成员看到的状态
+func BroadcastUserStatus(userID, status string) {
+	if queries == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	syncOnlineCounts(ctx, userID)
+	rooms, err := queries.ListUserChatrooms(ctx, sqlcdb.ListUserChatroomsParams{UserID: userID, Limit: 1000, Offset: 0})
+	if err != nil {
+		logger.Error("WebSocket", fmt.Sprintf("Failed to list rooms for user %s", userID), err)
+		return
+	}
+	statusMsg := WSMessage{
+		Type:   "user_status",
+		Action: "updated",
+		Data:   json.RawMessage(fmt.Sprintf(`{"userId":"%s","status":"%s"}`, userID, status)),
+	}
+	logger.Info("WebSocket", fmt.Sprintf("Broadcasting status %s of user %s to %d rooms", status, userID, len(rooms)))
+	for _, r := range rooms {
+		hub.broadcastRoom(r.RoomID, statusMsg)
+	}
+}
+
+// FriendRequestEvent 好友请求事件数据
+type FriendRequestEvent struct {
+	RequestID  string `json:"requestId"`
+	FromUserID string `json:"fromUserId"`
+	ToUserID   string `json:"toUserId"`
+	UserID     string `json:"userId"` // 对方用户ID
+	Username   string `json:"username"`
+	Nickname   string `json:"nickname"`
+	Avatar     string `json:"avatar"`
+	Message    string `json:"message,omitempty"`
+	Status     string `json:"status"`
+	Timestamp  string `json:"timestamp"`
+}
+
+// NotifyFriendRequest 推送好友请求事件
+// action: received（收到新请求）| accepted（发出的请求被接受）
+func NotifyFriendRequest(userID, action string, event FriendRequestEvent) {
+	if event.Timestamp == "" {
+		event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+	data, _ := json.Marshal(event)
+	msg := WSMessage{
+		Type:   "friend_request",
+		Action: action,
+		Data:   data,
+	}
+	logger.Info("WebSocket", fmt.Sprintf("Notifying user %s of friend request %s: %s", userID, event.RequestID, action))
+	SendToUser(userID, msg)
+}
+
+// GetOnlineUsersInRoom 获取房间内在线用户列表（仅本实例）
 func GetOnlineUsersInRoom(roomID string) []string {
 	hub.RoomsMux.RLock()
 	defer hub.RoomsMux.RUnlock()
@@ -882,14 +1373,14 @@ func GetOnlineUsersInRoom(roomID string) []string {
 	return users
 }
 
-// GetOnlineUserCount 获取在线用户数
+// GetOnlineUserCount 获取在线用户数（仅本实例）
 func GetOnlineUserCount() int {
 	hub.ClientsMux.RLock()
 	defer hub.ClientsMux.RUnlock()
 	return len(hub.Clients)
 }
 
-// IsUserOnline 检查用户是否在线
+// IsUserOnline 检查用户是否在本实例在线（任一设备在线即视为在线）
 func IsUserOnline(userID string) bool {
 	hub.ClientsMux.RLock()
 	defer hub.ClientsMux.RUnlock()
@@ -897,6 +1388,23 @@ func IsUserOnline(userID string) bool {
 	return ok
 }
 
+// GetUserConnections 获取用户在本实例上所有在线设备的连接信息
+func GetUserConnections(userID string) []ConnectionInfo {
+	clients := hub.userClients(userID)
+	infos := make([]ConnectionInfo, 0, len(clients))
+	for _, client := range clients {
+		infos = append(infos, ConnectionInfo{
+			ConnID:      client.ConnID,
+			SessionID:   client.SessionID,
+			Device:      client.Device,
+			UserAgent:   client.UserAgent,
+			RemoteAddr:  client.RemoteAddr,
+			ConnectedAt: client.ConnectedAt,
+		})
+	}
+	return infos
+}
+
 // NullString 创建 sql.NullString 辅助函数
 func NullString(s string) sql.NullString {
 	if s == "" {
@@ -11,16 +11,19 @@ import (
 	"time"
 )
 
-const archiveChatroom = `-- name: ArchiveChatroom :exec
+const archiveChatroom = `-- name: ArchiveChatroom :execrows
 UPDATE chatrooms 
 SET room_status = 'archived'
-WHERE room_id = $1
+WHERE room_id = $1 AND room_status = 'active'
 `
 
-// 归档聊天室
-func (q *Queries) ArchiveChatroom(ctx context.Context, roomID string) error {
-	_, err := q.exec(ctx, q.archiveChatroomStmt, archiveChatroom, roomID)
-	return err
+// 归档聊天室（只读），仅对正常状态的聊天室生效
+func (q *Queries) ArchiveChatroom(ctx context.Context, roomID string) (int64, error) {
+	result, err := q.exec(ctx, q.archiveChatroomStmt, archiveChatroom, roomID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const clearExpiredMutes = `-- name: ClearExpiredMutes :exec
@@ -95,7 +98,7 @@ const countUserChatrooms = `-- name: CountUserChatrooms :one
 SELECT COUNT(*) 
 FROM chatrooms cr
 JOIN chatroom_members cm ON cr.room_id = cm.room_id
-WHERE cm.user_id = $1 AND cm.is_active = true AND cr.room_status = 'active'
+WHERE cm.user_id = $1 AND cm.is_active = true AND cr.room_status IN ('active', 'archived')
 `
 
 // 统计用户加入的聊天室数量
@@ -108,15 +111,15 @@ func (q *Queries) CountUserChatrooms(ctx context.Context, userID string) (int64,
 
 const createChatroom = `-- name: CreateChatroom :one
 
-
 INSERT INTO chatrooms (
     room_name,
     description,
     icon_url,
     room_type,
-    access_password
+    access_password,
+    require_approval
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6
 ) RETURNING 
     room_id,
     room_name,
@@ -128,15 +131,17 @@ INSERT INTO chatrooms (
     online_count,
     room_status,
     created_at,
-    last_active_at
+    last_active_at,
+    require_approval
 `
 
 type CreateChatroomParams struct {
-	RoomName       string         `json:"room_name"`
-	Description    sql.NullString `json:"description"`
-	IconUrl        sql.NullString `json:"icon_url"`
-	RoomType       ChatroomType   `json:"room_type"`
-	AccessPassword sql.NullString `json:"access_password"`
+	RoomName        string         `json:"room_name"`
+	Description     sql.NullString `json:"description"`
+	IconUrl         sql.NullString `json:"icon_url"`
+	RoomType        ChatroomType   `json:"room_type"`
+	AccessPassword  sql.NullString `json:"access_password"`
+	RequireApproval bool           `json:"require_approval"`
 }
 
 // =============================================
@@ -154,6 +159,7 @@ func (q *Queries) CreateChatroom(ctx context.Context, arg CreateChatroomParams)
 		arg.IconUrl,
 		arg.RoomType,
 		arg.AccessPassword,
+		arg.RequireApproval,
 	)
 	var i Chatroom
 	err := row.Scan(
@@ -168,6 +174,7 @@ func (q *Queries) CreateChatroom(ctx context.Context, arg CreateChatroomParams)
 		&i.RoomStatus,
 		&i.CreatedAt,
 		&i.LastActiveAt,
+		&i.RequireApproval,
 	)
 	return i, err
 }
@@ -219,7 +226,8 @@ SELECT
     member_role,
     mute_status,
     mute_expires_at,
-    is_active
+    is_active,
+    invited_by
 FROM chatroom_members 
 WHERE user_id = $1 AND room_id = $2 AND is_active = true
 `
@@ -244,6 +252,7 @@ func (q *Queries) GetActiveMembership(ctx context.Context, arg GetActiveMembersh
 		&i.MuteStatus,
 		&i.MuteExpiresAt,
 		&i.IsActive,
+		&i.InvitedBy,
 	)
 	return i, err
 }
@@ -324,7 +333,8 @@ SELECT
     online_count,
     room_status,
     created_at,
-    last_active_at
+    last_active_at,
+    require_approval
 FROM chatrooms 
 WHERE room_id = $1 AND room_status = 'active'
 `
@@ -345,6 +355,46 @@ func (q *Queries) GetChatroomByID(ctx context.Context, roomID string) (Chatroom,
 		&i.RoomStatus,
 		&i.CreatedAt,
 		&i.LastActiveAt,
+		&i.RequireApproval,
+	)
+	return i, err
+}
+
+const getChatroomIncludingArchived = `-- name: GetChatroomIncludingArchived :one
+SELECT 
+    room_id,
+    room_name,
+    description,
+    icon_url,
+    room_type,
+    access_password,
+    member_count,
+    online_count,
+    room_status,
+    created_at,
+    last_active_at,
+    require_approval
+FROM chatrooms 
+WHERE room_id = $1 AND room_status IN ('active', 'archived')
+`
+
+// 获取聊天室详情，包含已归档的聊天室（用于查看成员、退出等只读或离开操作）
+func (q *Queries) GetChatroomIncludingArchived(ctx context.Context, roomID string) (Chatroom, error) {
+	row := q.queryRow(ctx, q.getChatroomIncludingArchivedStmt, getChatroomIncludingArchived, roomID)
+	var i Chatroom
+	err := row.Scan(
+		&i.RoomID,
+		&i.RoomName,
+		&i.Description,
+		&i.IconUrl,
+		&i.RoomType,
+		&i.AccessPassword,
+		&i.MemberCount,
+		&i.OnlineCount,
+		&i.RoomStatus,
+		&i.CreatedAt,
+		&i.LastActiveAt,
+		&i.RequireApproval,
 	)
 	return i, err
 }
@@ -363,9 +413,11 @@ SELECT
     cm.mute_status,
     cm.mute_expires_at,
     cm.last_read_at,
-    cm.is_active
+    cm.is_active,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
 FROM chatroom_members cm
 JOIN users u ON cm.user_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE cm.room_id = $1 AND cm.is_active = true
 ORDER BY 
     CASE cm.member_role
@@ -384,18 +436,19 @@ type GetChatroomMembersParams struct {
 }
 
 type GetChatroomMembersRow struct {
-	UserID        string               `json:"user_id"`
-	Username      string               `json:"username"`
-	Nickname      sql.NullString       `json:"nickname"`
-	AvatarUrl     sql.NullString       `json:"avatar_url"`
-	OnlineStatus  NullUserOnlineStatus `json:"online_status"`
-	MemberRelID   string               `json:"member_rel_id"`
-	JoinedAt      time.Time            `json:"joined_at"`
-	MemberRole    MemberRole           `json:"member_role"`
-	MuteStatus    MemberMuteStatus     `json:"mute_status"`
-	MuteExpiresAt sql.NullTime         `json:"mute_expires_at"`
-	LastReadAt    sql.NullTime         `json:"last_read_at"`
-	IsActive      bool                 `json:"is_active"`
+	UserID           string               `json:"user_id"`
+	Username         string               `json:"username"`
+	Nickname         sql.NullString       `json:"nickname"`
+	AvatarUrl        sql.NullString       `json:"avatar_url"`
+	OnlineStatus     NullUserOnlineStatus `json:"online_status"`
+	MemberRelID      string               `json:"member_rel_id"`
+	JoinedAt         time.Time            `json:"joined_at"`
+	MemberRole       MemberRole           `json:"member_role"`
+	MuteStatus       MemberMuteStatus     `json:"mute_status"`
+	MuteExpiresAt    sql.NullTime         `json:"mute_expires_at"`
+	LastReadAt       sql.NullTime         `json:"last_read_at"`
+	IsActive         bool                 `json:"is_active"`
+	ShowOnlineStatus bool                 `json:"show_online_status"`
 }
 
 // =============================================
@@ -424,6 +477,7 @@ func (q *Queries) GetChatroomMembers(ctx context.Context, arg GetChatroomMembers
 			&i.MuteExpiresAt,
 			&i.LastReadAt,
 			&i.IsActive,
+			&i.ShowOnlineStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -492,25 +546,27 @@ SELECT
     online_count,
     room_status,
     created_at,
-    last_active_at
+    last_active_at,
+    require_approval
 FROM chatrooms 
-WHERE room_id = $1 AND room_status = 'active'
+WHERE room_id = $1 AND room_status IN ('active', 'archived')
 `
 
 type GetChatroomWithoutPasswordRow struct {
-	RoomID       string         `json:"room_id"`
-	RoomName     string         `json:"room_name"`
-	Description  sql.NullString `json:"description"`
-	IconUrl      sql.NullString `json:"icon_url"`
-	RoomType     ChatroomType   `json:"room_type"`
-	MemberCount  int32          `json:"member_count"`
-	OnlineCount  int32          `json:"online_count"`
-	RoomStatus   ChatroomStatus `json:"room_status"`
-	CreatedAt    time.Time      `json:"created_at"`
-	LastActiveAt sql.NullTime   `json:"last_active_at"`
-}
-
-// 获取聊天室详情（不含密码，用于公开展示）
+	RoomID          string         `json:"room_id"`
+	RoomName        string         `json:"room_name"`
+	Description     sql.NullString `json:"description"`
+	IconUrl         sql.NullString `json:"icon_url"`
+	RoomType        ChatroomType   `json:"room_type"`
+	MemberCount     int32          `json:"member_count"`
+	OnlineCount     int32          `json:"online_count"`
+	RoomStatus      ChatroomStatus `json:"room_status"`
+	CreatedAt       time.Time      `json:"created_at"`
+	LastActiveAt    sql.NullTime   `json:"last_active_at"`
+	RequireApproval bool           `json:"require_approval"`
+}
+
+// 获取聊天室详情（不含密码，用于公开展示），包含已归档的聊天室
 func (q *Queries) GetChatroomWithoutPassword(ctx context.Context, roomID string) (GetChatroomWithoutPasswordRow, error) {
 	row := q.queryRow(ctx, q.getChatroomWithoutPasswordStmt, getChatroomWithoutPassword, roomID)
 	var i GetChatroomWithoutPasswordRow
@@ -525,10 +581,31 @@ func (q *Queries) GetChatroomWithoutPassword(ctx context.Context, roomID string)
 		&i.RoomStatus,
 		&i.CreatedAt,
 		&i.LastActiveAt,
+		&i.RequireApproval,
 	)
 	return i, err
 }
 
+const getLongestTenuredAdmin = `-- name: GetLongestTenuredAdmin :one
+SELECT cm.user_id
+FROM chatroom_members cm
+JOIN users u ON cm.user_id = u.user_id
+WHERE cm.room_id = $1
+    AND cm.member_role = 'admin'
+    AND cm.is_active = true
+    AND u.account_status = 'active'
+ORDER BY cm.joined_at ASC, cm.member_rel_id ASC
+LIMIT 1
+`
+
+// 获取加入最早的管理员（账号状态正常），用于房主退出或注销时自动接任
+func (q *Queries) GetLongestTenuredAdmin(ctx context.Context, roomID string) (string, error) {
+	row := q.queryRow(ctx, q.getLongestTenuredAdminStmt, getLongestTenuredAdmin, roomID)
+	var user_id string
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
 const getMemberByRelID = `-- name: GetMemberByRelID :one
 SELECT 
     member_rel_id,
@@ -540,7 +617,8 @@ SELECT
     member_role,
     mute_status,
     mute_expires_at,
-    is_active
+    is_active,
+    invited_by
 FROM chatroom_members 
 WHERE member_rel_id = $1
 `
@@ -560,6 +638,7 @@ func (q *Queries) GetMemberByRelID(ctx context.Context, memberRelID string) (Cha
 		&i.MuteStatus,
 		&i.MuteExpiresAt,
 		&i.IsActive,
+		&i.InvitedBy,
 	)
 	return i, err
 }
@@ -752,7 +831,8 @@ SELECT
     member_role,
     mute_status,
     mute_expires_at,
-    is_active
+    is_active,
+    invited_by
 FROM chatroom_members 
 WHERE user_id = $1 AND room_id = $2
 `
@@ -777,6 +857,7 @@ func (q *Queries) GetUserChatroomMembership(ctx context.Context, arg GetUserChat
 		&i.MuteStatus,
 		&i.MuteExpiresAt,
 		&i.IsActive,
+		&i.InvitedBy,
 	)
 	return i, err
 }
@@ -809,6 +890,21 @@ func (q *Queries) IncrementChatroomOnlineCount(ctx context.Context, roomID strin
 	return err
 }
 
+const isChatroomArchived = `-- name: IsChatroomArchived :one
+SELECT EXISTS(
+    SELECT 1 FROM chatrooms 
+    WHERE room_id = $1 AND room_status = 'archived'
+) AS is_archived
+`
+
+// 检查聊天室是否已归档
+func (q *Queries) IsChatroomArchived(ctx context.Context, roomID string) (bool, error) {
+	row := q.queryRow(ctx, q.isChatroomArchivedStmt, isChatroomArchived, roomID)
+	var is_archived bool
+	err := row.Scan(&is_archived)
+	return is_archived, err
+}
+
 const isChatroomPublic = `-- name: IsChatroomPublic :one
 SELECT room_type = 'public' AS is_public
 FROM chatrooms 
@@ -912,16 +1008,18 @@ const joinChatroom = `-- name: JoinChatroom :one
 INSERT INTO chatroom_members (
     user_id,
     room_id,
-    member_role
+    member_role,
+    invited_by
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 ) 
 ON CONFLICT (user_id, room_id) 
 DO UPDATE SET 
     is_active = true,
     joined_at = NOW(),
     left_at = NULL,
-    member_role = EXCLUDED.member_role
+    member_role = EXCLUDED.member_role,
+    invited_by = EXCLUDED.invited_by
 RETURNING 
     member_rel_id,
     user_id,
@@ -932,21 +1030,28 @@ RETURNING
     member_role,
     mute_status,
     mute_expires_at,
-    is_active
+    is_active,
+    invited_by
 `
 
 type JoinChatroomParams struct {
-	UserID     string     `json:"user_id"`
-	RoomID     string     `json:"room_id"`
-	MemberRole MemberRole `json:"member_role"`
+	UserID     string         `json:"user_id"`
+	RoomID     string         `json:"room_id"`
+	MemberRole MemberRole     `json:"member_role"`
+	InvitedBy  sql.NullString `json:"invited_by"`
 }
 
 // =============================================
 // 3. 聊天室成员操作 (Member Operations)
 // =============================================
-// 加入聊天室 POST /chatrooms/:roomId/join
+// 加入聊天室 POST /chatrooms/:roomId/join（invited_by 为邀请人，直接加入时为 NULL）
 func (q *Queries) JoinChatroom(ctx context.Context, arg JoinChatroomParams) (ChatroomMember, error) {
-	row := q.queryRow(ctx, q.joinChatroomStmt, joinChatroom, arg.UserID, arg.RoomID, arg.MemberRole)
+	row := q.queryRow(ctx, q.joinChatroomStmt, joinChatroom,
+		arg.UserID,
+		arg.RoomID,
+		arg.MemberRole,
+		arg.InvitedBy,
+	)
 	var i ChatroomMember
 	err := row.Scan(
 		&i.MemberRelID,
@@ -959,6 +1064,7 @@ func (q *Queries) JoinChatroom(ctx context.Context, arg JoinChatroomParams) (Cha
 		&i.MuteStatus,
 		&i.MuteExpiresAt,
 		&i.IsActive,
+		&i.InvitedBy,
 	)
 	return i, err
 }
@@ -1001,6 +1107,51 @@ func (q *Queries) LeaveChatroom(ctx context.Context, arg LeaveChatroomParams) er
 	return err
 }
 
+const listOwnedChatrooms = `-- name: ListOwnedChatrooms :many
+SELECT 
+    c.room_id,
+    c.room_name
+FROM chatroom_members cm
+JOIN chatrooms c ON cm.room_id = c.room_id
+WHERE cm.user_id = $1
+    AND cm.member_role = 'owner'
+    AND cm.is_active = true
+    AND c.room_status <> 'deleted'
+ORDER BY c.room_id
+`
+
+type ListOwnedChatroomsRow struct {
+	RoomID   string `json:"room_id"`
+	RoomName string `json:"room_name"`
+}
+
+// 获取用户担任房主的聊天室（不含已删除的聊天室）
+func (q *Queries) ListOwnedChatrooms(ctx context.Context, userID string) ([]ListOwnedChatroomsRow, error) {
+	rows, err := q.query(ctx, q.listOwnedChatroomsStmt, listOwnedChatrooms, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOwnedChatroomsRow{}
+	for rows.Next() {
+		var i ListOwnedChatroomsRow
+		if err := rows.Scan(
+			&i.RoomID,
+			&i.RoomName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPublicChatrooms = `-- name: ListPublicChatrooms :many
 SELECT 
     room_id,
@@ -1091,7 +1242,7 @@ SELECT
     cm.is_active
 FROM chatrooms cr
 JOIN chatroom_members cm ON cr.room_id = cm.room_id
-WHERE cm.user_id = $1 AND cm.is_active = true AND cr.room_status = 'active'
+WHERE cm.user_id = $1 AND cm.is_active = true AND cr.room_status IN ('active', 'archived')
 ORDER BY cr.last_active_at DESC NULLS LAST
 LIMIT $2 OFFSET $3
 `
@@ -1125,7 +1276,7 @@ type ListUserChatroomsRow struct {
 // =============================================
 // 2. 聊天室列表查询 (Chatroom List Queries)
 // =============================================
-// 获取用户的聊天室列表 GET /users/me/chatrooms
+// 获取用户的聊天室列表 GET /users/me/chatrooms（包含已归档的聊天室）
 func (q *Queries) ListUserChatrooms(ctx context.Context, arg ListUserChatroomsParams) ([]ListUserChatroomsRow, error) {
 	rows, err := q.query(ctx, q.listUserChatroomsStmt, listUserChatrooms, arg.UserID, arg.Limit, arg.Offset)
 	if err != nil {
@@ -1214,9 +1365,11 @@ SELECT
     u.username,
     u.nickname,
     u.avatar_url,
-    u.online_status
+    u.online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
 FROM chatroom_members cm
 JOIN users u ON cm.user_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE cm.room_id = $1 
     AND cm.is_active = true
     AND (
@@ -1240,11 +1393,12 @@ type SearchChatroomMembersParams struct {
 }
 
 type SearchChatroomMembersRow struct {
-	UserID       string               `json:"user_id"`
-	Username     string               `json:"username"`
-	Nickname     sql.NullString       `json:"nickname"`
-	AvatarUrl    sql.NullString       `json:"avatar_url"`
-	OnlineStatus NullUserOnlineStatus `json:"online_status"`
+	UserID           string               `json:"user_id"`
+	Username         string               `json:"username"`
+	Nickname         sql.NullString       `json:"nickname"`
+	AvatarUrl        sql.NullString       `json:"avatar_url"`
+	OnlineStatus     NullUserOnlineStatus `json:"online_status"`
+	ShowOnlineStatus bool                 `json:"show_online_status"`
 }
 
 // 在聊天室内搜索成员（模糊查询用户名或昵称）
@@ -1268,6 +1422,7 @@ func (q *Queries) SearchChatroomMembers(ctx context.Context, arg SearchChatroomM
 			&i.Nickname,
 			&i.AvatarUrl,
 			&i.OnlineStatus,
+			&i.ShowOnlineStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -1357,6 +1512,23 @@ func (q *Queries) SearchChatrooms(ctx context.Context, arg SearchChatroomsParams
 	return items, nil
 }
 
+const setChatroomRequireApproval = `-- name: SetChatroomRequireApproval :exec
+UPDATE chatrooms 
+SET require_approval = $2
+WHERE room_id = $1 AND room_status = 'active'
+`
+
+type SetChatroomRequireApprovalParams struct {
+	RoomID          string `json:"room_id"`
+	RequireApproval bool   `json:"require_approval"`
+}
+
+// 设置加入是否需要审核 POST /chatroom/:roomid/update
+func (q *Queries) SetChatroomRequireApproval(ctx context.Context, arg SetChatroomRequireApprovalParams) error {
+	_, err := q.exec(ctx, q.setChatroomRequireApprovalStmt, setChatroomRequireApproval, arg.RoomID, arg.RequireApproval)
+	return err
+}
+
 const setMemberAsAdmin = `-- name: SetMemberAsAdmin :exec
 UPDATE chatroom_members 
 SET member_role = 'admin'
@@ -1416,26 +1588,55 @@ UPDATE chatrooms
 SET online_count = (
     SELECT COUNT(*) FROM chatroom_members cm
     JOIN users u ON cm.user_id = u.user_id
+    LEFT JOIN user_settings us ON us.user_id = u.user_id
     WHERE cm.room_id = chatrooms.room_id AND cm.is_active = true 
     AND u.online_status IN ('online', 'away', 'do_not_disturb')
+    AND COALESCE(us.show_online_status, true)
 )
 WHERE room_id = $1
 `
 
-// 同步在线人数（用于数据修复）
+// 同步在线人数（用于数据修复），关闭了"显示在线状态"的成员不计入
 func (q *Queries) SyncChatroomOnlineCount(ctx context.Context, dollar_1 sql.NullString) error {
 	_, err := q.exec(ctx, q.syncChatroomOnlineCountStmt, syncChatroomOnlineCount, dollar_1)
 	return err
 }
 
-const transferOwnership = `-- name: TransferOwnership :exec
+const syncUserChatroomsOnlineCount = `-- name: SyncUserChatroomsOnlineCount :exec
+UPDATE chatrooms 
+SET online_count = (
+    SELECT COUNT(*) FROM chatroom_members cm
+    JOIN users u ON cm.user_id = u.user_id
+    LEFT JOIN user_settings us ON us.user_id = u.user_id
+    WHERE cm.room_id = chatrooms.room_id AND cm.is_active = true 
+    AND u.online_status IN ('online', 'away', 'do_not_disturb')
+    AND COALESCE(us.show_online_status, true)
+)
+WHERE room_id IN (
+    SELECT room_id FROM chatroom_members 
+    WHERE user_id = $1 AND is_active = true
+)
+`
+
+// 重新统计用户所在聊天室的在线人数（用户上线、离线或修改在线状态可见性后调用，各实例重复执行结果相同）。
+// 关闭了"显示在线状态"的成员不计入
+func (q *Queries) SyncUserChatroomsOnlineCount(ctx context.Context, userID string) error {
+	_, err := q.exec(ctx, q.syncUserChatroomsOnlineCountStmt, syncUserChatroomsOnlineCount, userID)
+	return err
+}
+
+const transferOwnership = `-- name: TransferOwnership :execrows
 UPDATE chatroom_members 
 SET member_role = CASE 
-    WHEN user_id = $1 THEN 'member'
+    WHEN user_id = $1 THEN 'admin'
     WHEN user_id = $2 THEN 'owner'
     ELSE member_role
 END
 WHERE room_id = $3 AND user_id IN ($1, $2) AND is_active = true
+    AND EXISTS (
+        SELECT 1 FROM chatroom_members o
+        WHERE o.room_id = $3 AND o.user_id = $1 AND o.member_role = 'owner' AND o.is_active = true
+    )
 `
 
 type TransferOwnershipParams struct {
@@ -1444,10 +1645,31 @@ type TransferOwnershipParams struct {
 	RoomID   string `json:"room_id"`
 }
 
-// 转让房主
-func (q *Queries) TransferOwnership(ctx context.Context, arg TransferOwnershipParams) error {
-	_, err := q.exec(ctx, q.transferOwnershipStmt, transferOwnership, arg.UserID, arg.UserID_2, arg.RoomID)
-	return err
+// 转让房主：原房主 $1 降为管理员，$2 成为房主。
+// $1 须仍是房主且两人都须为有效成员，影响行数不为 2 时调用方应回滚事务
+func (q *Queries) TransferOwnership(ctx context.Context, arg TransferOwnershipParams) (int64, error) {
+	result, err := q.exec(ctx, q.transferOwnershipStmt, transferOwnership, arg.UserID, arg.UserID_2, arg.RoomID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const unarchiveChatroom = `-- name: UnarchiveChatroom :execrows
+UPDATE chatrooms 
+SET 
+    room_status = 'active',
+    last_active_at = NOW()
+WHERE room_id = $1 AND room_status = 'archived'
+`
+
+// 取消归档，恢复为正常状态
+func (q *Queries) UnarchiveChatroom(ctx context.Context, roomID string) (int64, error) {
+	result, err := q.exec(ctx, q.unarchiveChatroomStmt, unarchiveChatroom, roomID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const unmuteMember = `-- name: UnmuteMember :exec
@@ -1490,7 +1712,8 @@ RETURNING
     online_count,
     room_status,
     created_at,
-    last_active_at
+    last_active_at,
+    require_approval
 `
 
 type UpdateChatroomParams struct {
@@ -1525,6 +1748,7 @@ func (q *Queries) UpdateChatroom(ctx context.Context, arg UpdateChatroomParams)
 		&i.RoomStatus,
 		&i.CreatedAt,
 		&i.LastActiveAt,
+		&i.RequireApproval,
 	)
 	return i, err
 }
@@ -1562,40 +1786,33 @@ func (q *Queries) UpdateMemberLastReadTime(ctx context.Context, arg UpdateMember
 	return err
 }
 
-const updateMemberLastReadToMessage = `-- name: UpdateMemberLastReadToMessage :exec
-UPDATE chatroom_members 
-SET last_read_at = (SELECT sent_at FROM messages WHERE message_id = $3)
-WHERE user_id = $1 AND room_id = $2 AND is_active = true
+const updateMemberLastReadToMessage = `-- name: UpdateMemberLastReadToMessage :one
+UPDATE chatroom_members cm
+SET last_read_at = GREATEST(cm.last_read_at, m.sent_at)
+FROM messages m
+WHERE m.message_id = $1 AND m.room_id = cm.room_id
+    AND cm.user_id = $2 AND cm.room_id = $3 AND cm.is_active = true
+RETURNING cm.last_read_at, m.sent_at
 `
 
 type UpdateMemberLastReadToMessageParams struct {
-	Column1 sql.NullString `json:"column_1"`
-	Column2 sql.NullString `json:"column_2"`
-	Column3 sql.NullString `json:"column_3"`
-}
-
-// 更新最后阅读到指定消息
-func (q *Queries) UpdateMemberLastReadToMessage(ctx context.Context, arg UpdateMemberLastReadToMessageParams) error {
-	_, err := q.exec(ctx, q.updateMemberLastReadToMessageStmt, updateMemberLastReadToMessage, arg.Column1, arg.Column2, arg.Column3)
-	return err
+	MessageID string `json:"message_id"`
+	UserID    string `json:"user_id"`
+	RoomID    string `json:"room_id"`
 }
 
-const verifyChatroomPassword = `-- name: VerifyChatroomPassword :one
-SELECT EXISTS(
-    SELECT 1 FROM chatrooms 
-    WHERE room_id = $1 AND access_password = $2 AND room_status = 'active'
-) AS is_valid
-`
-
-type VerifyChatroomPasswordParams struct {
-	RoomID         string         `json:"room_id"`
-	AccessPassword sql.NullString `json:"access_password"`
+type UpdateMemberLastReadToMessageRow struct {
+	LastReadAt sql.NullTime `json:"last_read_at"`
+	SentAt     time.Time    `json:"sent_at"`
 }
 
-// 验证聊天室密码
-func (q *Queries) VerifyChatroomPassword(ctx context.Context, arg VerifyChatroomPasswordParams) (bool, error) {
-	row := q.queryRow(ctx, q.verifyChatroomPasswordStmt, verifyChatroomPassword, arg.RoomID, arg.AccessPassword)
-	var is_valid bool
-	err := row.Scan(&is_valid)
-	return is_valid, err
+// 更新最后阅读到指定消息（消息须属于该聊天室，已读位置只前进不后退）
+func (q *Queries) UpdateMemberLastReadToMessage(ctx context.Context, arg UpdateMemberLastReadToMessageParams) (UpdateMemberLastReadToMessageRow, error) {
+	row := q.queryRow(ctx, q.updateMemberLastReadToMessageStmt, updateMemberLastReadToMessage, arg.MessageID, arg.UserID, arg.RoomID)
+	var i UpdateMemberLastReadToMessageRow
+	err := row.Scan(
+		&i.LastReadAt,
+		&i.SentAt,
+	)
+	return i, err
 }
@@ -24,15 +24,27 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.acceptFriendRequestStmt, err = db.PrepareContext(ctx, acceptFriendRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query AcceptFriendRequest: %w", err)
+	}
 	if q.activateUserStmt, err = db.PrepareContext(ctx, activateUser); err != nil {
 		return nil, fmt.Errorf("error preparing query ActivateUser: %w", err)
 	}
+	if q.addMessageReactionStmt, err = db.PrepareContext(ctx, addMessageReaction); err != nil {
+		return nil, fmt.Errorf("error preparing query AddMessageReaction: %w", err)
+	}
 	if q.archiveChatroomStmt, err = db.PrepareContext(ctx, archiveChatroom); err != nil {
 		return nil, fmt.Errorf("error preparing query ArchiveChatroom: %w", err)
 	}
+	if q.blockUserStmt, err = db.PrepareContext(ctx, blockUser); err != nil {
+		return nil, fmt.Errorf("error preparing query BlockUser: %w", err)
+	}
 	if q.canUserSendMessageInRoomStmt, err = db.PrepareContext(ctx, canUserSendMessageInRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query CanUserSendMessageInRoom: %w", err)
 	}
+	if q.cancelFriendRequestStmt, err = db.PrepareContext(ctx, cancelFriendRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelFriendRequest: %w", err)
+	}
 	if q.checkEmailExistsStmt, err = db.PrepareContext(ctx, checkEmailExists); err != nil {
 		return nil, fmt.Errorf("error preparing query CheckEmailExists: %w", err)
 	}
@@ -45,6 +57,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.clearExpiredMutesStmt, err = db.PrepareContext(ctx, clearExpiredMutes); err != nil {
 		return nil, fmt.Errorf("error preparing query ClearExpiredMutes: %w", err)
 	}
+	if q.clearRoomPasswordAttemptsStmt, err = db.PrepareContext(ctx, clearRoomPasswordAttempts); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearRoomPasswordAttempts: %w", err)
+	}
 	if q.countAdminLogsStmt, err = db.PrepareContext(ctx, countAdminLogs); err != nil {
 		return nil, fmt.Errorf("error preparing query CountAdminLogs: %w", err)
 	}
@@ -60,24 +75,69 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.countChatroomMembersStmt, err = db.PrepareContext(ctx, countChatroomMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query CountChatroomMembers: %w", err)
 	}
+	if q.countFriendsStmt, err = db.PrepareContext(ctx, countFriends); err != nil {
+		return nil, fmt.Errorf("error preparing query CountFriends: %w", err)
+	}
+	if q.countFriendsByStatusStmt, err = db.PrepareContext(ctx, countFriendsByStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query CountFriendsByStatus: %w", err)
+	}
+	if q.countLiveUserConnectionsStmt, err = db.PrepareContext(ctx, countLiveUserConnections); err != nil {
+		return nil, fmt.Errorf("error preparing query CountLiveUserConnections: %w", err)
+	}
+	if q.countMessageReactionsByEmojiStmt, err = db.PrepareContext(ctx, countMessageReactionsByEmoji); err != nil {
+		return nil, fmt.Errorf("error preparing query CountMessageReactionsByEmoji: %w", err)
+	}
 	if q.countMessagesInRoomStmt, err = db.PrepareContext(ctx, countMessagesInRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query CountMessagesInRoom: %w", err)
 	}
 	if q.countOnlineChatroomMembersStmt, err = db.PrepareContext(ctx, countOnlineChatroomMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query CountOnlineChatroomMembers: %w", err)
 	}
+	if q.countOnlineFriendsStmt, err = db.PrepareContext(ctx, countOnlineFriends); err != nil {
+		return nil, fmt.Errorf("error preparing query CountOnlineFriends: %w", err)
+	}
 	if q.countOnlineUsersStmt, err = db.PrepareContext(ctx, countOnlineUsers); err != nil {
 		return nil, fmt.Errorf("error preparing query CountOnlineUsers: %w", err)
 	}
+	if q.countPendingJoinRequestsStmt, err = db.PrepareContext(ctx, countPendingJoinRequests); err != nil {
+		return nil, fmt.Errorf("error preparing query CountPendingJoinRequests: %w", err)
+	}
+	if q.countPendingReceivedRequestsStmt, err = db.PrepareContext(ctx, countPendingReceivedRequests); err != nil {
+		return nil, fmt.Errorf("error preparing query CountPendingReceivedRequests: %w", err)
+	}
+	if q.countPinnedMessagesStmt, err = db.PrepareContext(ctx, countPinnedMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query CountPinnedMessages: %w", err)
+	}
+	if q.countReceivedFriendRequestsStmt, err = db.PrepareContext(ctx, countReceivedFriendRequests); err != nil {
+		return nil, fmt.Errorf("error preparing query CountReceivedFriendRequests: %w", err)
+	}
 	if q.countSearchChatroomMembersStmt, err = db.PrepareContext(ctx, countSearchChatroomMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query CountSearchChatroomMembers: %w", err)
 	}
 	if q.countSearchUsersStmt, err = db.PrepareContext(ctx, countSearchUsers); err != nil {
 		return nil, fmt.Errorf("error preparing query CountSearchUsers: %w", err)
 	}
+	if q.countSentFriendRequestsStmt, err = db.PrepareContext(ctx, countSentFriendRequests); err != nil {
+		return nil, fmt.Errorf("error preparing query CountSentFriendRequests: %w", err)
+	}
+	if q.countUnreadNotificationsStmt, err = db.PrepareContext(ctx, countUnreadNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUnreadNotifications: %w", err)
+	}
+	if q.countUnreadNotificationsByTypeStmt, err = db.PrepareContext(ctx, countUnreadNotificationsByType); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUnreadNotificationsByType: %w", err)
+	}
 	if q.countUserChatroomsStmt, err = db.PrepareContext(ctx, countUserChatrooms); err != nil {
 		return nil, fmt.Errorf("error preparing query CountUserChatrooms: %w", err)
 	}
+	if q.countUserMentionsStmt, err = db.PrepareContext(ctx, countUserMentions); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUserMentions: %w", err)
+	}
+	if q.countUserNotificationsStmt, err = db.PrepareContext(ctx, countUserNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUserNotifications: %w", err)
+	}
+	if q.countUserReactionsOnMessageStmt, err = db.PrepareContext(ctx, countUserReactionsOnMessage); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUserReactionsOnMessage: %w", err)
+	}
 	if q.createAdminLogStmt, err = db.PrepareContext(ctx, createAdminLog); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateAdminLog: %w", err)
 	}
@@ -87,33 +147,84 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.createChatroomStmt, err = db.PrepareContext(ctx, createChatroom); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateChatroom: %w", err)
 	}
+	if q.createChatroomNotificationStmt, err = db.PrepareContext(ctx, createChatroomNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateChatroomNotification: %w", err)
+	}
 	if q.createDeleteMessageLogStmt, err = db.PrepareContext(ctx, createDeleteMessageLog); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateDeleteMessageLog: %w", err)
 	}
+	if q.createDirectConversationStmt, err = db.PrepareContext(ctx, createDirectConversation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateDirectConversation: %w", err)
+	}
+	if q.createFriendNotificationStmt, err = db.PrepareContext(ctx, createFriendNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFriendNotification: %w", err)
+	}
+	if q.createFriendRequestStmt, err = db.PrepareContext(ctx, createFriendRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFriendRequest: %w", err)
+	}
+	if q.createFriendshipStmt, err = db.PrepareContext(ctx, createFriendship); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFriendship: %w", err)
+	}
 	if q.createGlobalMuteRecordStmt, err = db.PrepareContext(ctx, createGlobalMuteRecord); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateGlobalMuteRecord: %w", err)
 	}
+	if q.createJoinRequestStmt, err = db.PrepareContext(ctx, createJoinRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateJoinRequest: %w", err)
+	}
 	if q.createKickLogStmt, err = db.PrepareContext(ctx, createKickLog); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateKickLog: %w", err)
 	}
 	if q.createMessageStmt, err = db.PrepareContext(ctx, createMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateMessage: %w", err)
 	}
+	if q.createMessageEditStmt, err = db.PrepareContext(ctx, createMessageEdit); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMessageEdit: %w", err)
+	}
+	if q.createMessageMentionsStmt, err = db.PrepareContext(ctx, createMessageMentions); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMessageMentions: %w", err)
+	}
+	if q.createMessageReceiptsStmt, err = db.PrepareContext(ctx, createMessageReceipts); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateMessageReceipts: %w", err)
+	}
 	if q.createMuteLogStmt, err = db.PrepareContext(ctx, createMuteLog); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateMuteLog: %w", err)
 	}
 	if q.createMuteRecordStmt, err = db.PrepareContext(ctx, createMuteRecord); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateMuteRecord: %w", err)
 	}
+	if q.createNotificationStmt, err = db.PrepareContext(ctx, createNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNotification: %w", err)
+	}
 	if q.createRoleChangeLogStmt, err = db.PrepareContext(ctx, createRoleChangeLog); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateRoleChangeLog: %w", err)
 	}
+	if q.createRoomInviteStmt, err = db.PrepareContext(ctx, createRoomInvite); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRoomInvite: %w", err)
+	}
+	if q.createSystemNotificationStmt, err = db.PrepareContext(ctx, createSystemNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSystemNotification: %w", err)
+	}
+	if q.createThreadReplyReceiptsStmt, err = db.PrepareContext(ctx, createThreadReplyReceipts); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateThreadReplyReceipts: %w", err)
+	}
 	if q.createUnmuteLogStmt, err = db.PrepareContext(ctx, createUnmuteLog); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUnmuteLog: %w", err)
 	}
 	if q.createUserStmt, err = db.PrepareContext(ctx, createUser); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateUser: %w", err)
 	}
+	if q.createUserSessionStmt, err = db.PrepareContext(ctx, createUserSession); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateUserSession: %w", err)
+	}
+	if q.createUserSettingsStmt, err = db.PrepareContext(ctx, createUserSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateUserSettings: %w", err)
+	}
+	if q.createWSConnectionStmt, err = db.PrepareContext(ctx, createWSConnection); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWSConnection: %w", err)
+	}
+	if q.createWSEventStmt, err = db.PrepareContext(ctx, createWSEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateWSEvent: %w", err)
+	}
 	if q.deactivateGlobalMuteRecordStmt, err = db.PrepareContext(ctx, deactivateGlobalMuteRecord); err != nil {
 		return nil, fmt.Errorf("error preparing query DeactivateGlobalMuteRecord: %w", err)
 	}
@@ -135,6 +246,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteChatroomStmt, err = db.PrepareContext(ctx, deleteChatroom); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteChatroom: %w", err)
 	}
+	if q.deleteExpiredWSEventsStmt, err = db.PrepareContext(ctx, deleteExpiredWSEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredWSEvents: %w", err)
+	}
+	if q.deleteFriendshipStmt, err = db.PrepareContext(ctx, deleteFriendship); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteFriendship: %w", err)
+	}
 	if q.deleteMessageStmt, err = db.PrepareContext(ctx, deleteMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteMessage: %w", err)
 	}
@@ -150,15 +267,36 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.deleteMessagesByUserInRoomStmt, err = db.PrepareContext(ctx, deleteMessagesByUserInRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteMessagesByUserInRoom: %w", err)
 	}
+	if q.deleteNotificationStmt, err = db.PrepareContext(ctx, deleteNotification); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteNotification: %w", err)
+	}
+	if q.deleteOldNotificationsStmt, err = db.PrepareContext(ctx, deleteOldNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOldNotifications: %w", err)
+	}
+	if q.deleteReadNotificationsStmt, err = db.PrepareContext(ctx, deleteReadNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReadNotifications: %w", err)
+	}
+	if q.deleteStaleWSConnectionsStmt, err = db.PrepareContext(ctx, deleteStaleWSConnections); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStaleWSConnections: %w", err)
+	}
 	if q.deleteUserAccountStmt, err = db.PrepareContext(ctx, deleteUserAccount); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteUserAccount: %w", err)
 	}
+	if q.deleteUserSettingsStmt, err = db.PrepareContext(ctx, deleteUserSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUserSettings: %w", err)
+	}
+	if q.deleteWSConnectionStmt, err = db.PrepareContext(ctx, deleteWSConnection); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWSConnection: %w", err)
+	}
 	if q.expireGlobalMuteRecordsStmt, err = db.PrepareContext(ctx, expireGlobalMuteRecords); err != nil {
 		return nil, fmt.Errorf("error preparing query ExpireGlobalMuteRecords: %w", err)
 	}
 	if q.expireMuteRecordsStmt, err = db.PrepareContext(ctx, expireMuteRecords); err != nil {
 		return nil, fmt.Errorf("error preparing query ExpireMuteRecords: %w", err)
 	}
+	if q.followThreadStmt, err = db.PrepareContext(ctx, followThread); err != nil {
+		return nil, fmt.Errorf("error preparing query FollowThread: %w", err)
+	}
 	if q.getActiveGlobalMuteRecordStmt, err = db.PrepareContext(ctx, getActiveGlobalMuteRecord); err != nil {
 		return nil, fmt.Errorf("error preparing query GetActiveGlobalMuteRecord: %w", err)
 	}
@@ -171,6 +309,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getActiveMuteRecordsByRoomStmt, err = db.PrepareContext(ctx, getActiveMuteRecordsByRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query GetActiveMuteRecordsByRoom: %w", err)
 	}
+	if q.getActiveUserSessionStmt, err = db.PrepareContext(ctx, getActiveUserSession); err != nil {
+		return nil, fmt.Errorf("error preparing query GetActiveUserSession: %w", err)
+	}
 	if q.getAdminLogByIDStmt, err = db.PrepareContext(ctx, getAdminLogByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAdminLogByID: %w", err)
 	}
@@ -198,12 +339,18 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getAllActiveGlobalMuteRecordsStmt, err = db.PrepareContext(ctx, getAllActiveGlobalMuteRecords); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllActiveGlobalMuteRecords: %w", err)
 	}
+	if q.getBlockedUsersStmt, err = db.PrepareContext(ctx, getBlockedUsers); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBlockedUsers: %w", err)
+	}
 	if q.getChatroomAdminsStmt, err = db.PrepareContext(ctx, getChatroomAdmins); err != nil {
 		return nil, fmt.Errorf("error preparing query GetChatroomAdmins: %w", err)
 	}
 	if q.getChatroomByIDStmt, err = db.PrepareContext(ctx, getChatroomByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetChatroomByID: %w", err)
 	}
+	if q.getChatroomIncludingArchivedStmt, err = db.PrepareContext(ctx, getChatroomIncludingArchived); err != nil {
+		return nil, fmt.Errorf("error preparing query GetChatroomIncludingArchived: %w", err)
+	}
 	if q.getChatroomMembersStmt, err = db.PrepareContext(ctx, getChatroomMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query GetChatroomMembers: %w", err)
 	}
@@ -213,6 +360,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getChatroomWithoutPasswordStmt, err = db.PrepareContext(ctx, getChatroomWithoutPassword); err != nil {
 		return nil, fmt.Errorf("error preparing query GetChatroomWithoutPassword: %w", err)
 	}
+	if q.getDirectConversationStmt, err = db.PrepareContext(ctx, getDirectConversation); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDirectConversation: %w", err)
+	}
+	if q.getDirectConversationPeerStmt, err = db.PrepareContext(ctx, getDirectConversationPeer); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDirectConversationPeer: %w", err)
+	}
+	if q.getFriendRequestByIDStmt, err = db.PrepareContext(ctx, getFriendRequestByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFriendRequestByID: %w", err)
+	}
+	if q.getFriendsStmt, err = db.PrepareContext(ctx, getFriends); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFriends: %w", err)
+	}
+	if q.getFriendsByStatusStmt, err = db.PrepareContext(ctx, getFriendsByStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFriendsByStatus: %w", err)
+	}
 	if q.getGlobalAdminLogsStmt, err = db.PrepareContext(ctx, getGlobalAdminLogs); err != nil {
 		return nil, fmt.Errorf("error preparing query GetGlobalAdminLogs: %w", err)
 	}
@@ -228,6 +390,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getLatestMessagesStmt, err = db.PrepareContext(ctx, getLatestMessages); err != nil {
 		return nil, fmt.Errorf("error preparing query GetLatestMessages: %w", err)
 	}
+	if q.getLongestTenuredAdminStmt, err = db.PrepareContext(ctx, getLongestTenuredAdmin); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLongestTenuredAdmin: %w", err)
+	}
 	if q.getMemberByRelIDStmt, err = db.PrepareContext(ctx, getMemberByRelID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMemberByRelID: %w", err)
 	}
@@ -240,9 +405,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getMemberRoleStmt, err = db.PrepareContext(ctx, getMemberRole); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMemberRole: %w", err)
 	}
+	if q.getMessageByClientMsgIDStmt, err = db.PrepareContext(ctx, getMessageByClientMsgID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMessageByClientMsgID: %w", err)
+	}
 	if q.getMessageByIDStmt, err = db.PrepareContext(ctx, getMessageByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMessageByID: %w", err)
 	}
+	if q.getMessageEditsStmt, err = db.PrepareContext(ctx, getMessageEdits); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMessageEdits: %w", err)
+	}
+	if q.getMessageReactionsStmt, err = db.PrepareContext(ctx, getMessageReactions); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMessageReactions: %w", err)
+	}
+	if q.getMessageReadByStmt, err = db.PrepareContext(ctx, getMessageReadBy); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMessageReadBy: %w", err)
+	}
+	if q.getMessageReceiptsStmt, err = db.PrepareContext(ctx, getMessageReceipts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMessageReceipts: %w", err)
+	}
 	if q.getMessageRoomStmt, err = db.PrepareContext(ctx, getMessageRoom); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMessageRoom: %w", err)
 	}
@@ -291,18 +471,69 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getMutedMembersStmt, err = db.PrepareContext(ctx, getMutedMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMutedMembers: %w", err)
 	}
+	if q.getMutualFriendsStmt, err = db.PrepareContext(ctx, getMutualFriends); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMutualFriends: %w", err)
+	}
+	if q.getNotificationByIDStmt, err = db.PrepareContext(ctx, getNotificationByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNotificationByID: %w", err)
+	}
+	if q.getNotificationStatsStmt, err = db.PrepareContext(ctx, getNotificationStats); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNotificationStats: %w", err)
+	}
 	if q.getOnlineChatroomMembersStmt, err = db.PrepareContext(ctx, getOnlineChatroomMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOnlineChatroomMembers: %w", err)
 	}
+	if q.getOnlineFriendsStmt, err = db.PrepareContext(ctx, getOnlineFriends); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOnlineFriends: %w", err)
+	}
 	if q.getOnlineUsersStmt, err = db.PrepareContext(ctx, getOnlineUsers); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOnlineUsers: %w", err)
 	}
 	if q.getOperatorStatsStmt, err = db.PrepareContext(ctx, getOperatorStats); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOperatorStats: %w", err)
 	}
+	if q.getPendingJoinRequestStmt, err = db.PrepareContext(ctx, getPendingJoinRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPendingJoinRequest: %w", err)
+	}
+	if q.getPendingReceivedRequestsStmt, err = db.PrepareContext(ctx, getPendingReceivedRequests); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPendingReceivedRequests: %w", err)
+	}
+	if q.getPendingRequestBetweenUsersStmt, err = db.PrepareContext(ctx, getPendingRequestBetweenUsers); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPendingRequestBetweenUsers: %w", err)
+	}
+	if q.getPinnedMessagesStmt, err = db.PrepareContext(ctx, getPinnedMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPinnedMessages: %w", err)
+	}
 	if q.getQuotedMessageStmt, err = db.PrepareContext(ctx, getQuotedMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query GetQuotedMessage: %w", err)
 	}
+	if q.getReactionSummariesStmt, err = db.PrepareContext(ctx, getReactionSummaries); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReactionSummaries: %w", err)
+	}
+	if q.getReceivedFriendRequestsStmt, err = db.PrepareContext(ctx, getReceivedFriendRequests); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReceivedFriendRequests: %w", err)
+	}
+	if q.getRoomInviteStmt, err = db.PrepareContext(ctx, getRoomInvite); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRoomInvite: %w", err)
+	}
+	if q.getRoomMessageChangesStmt, err = db.PrepareContext(ctx, getRoomMessageChanges); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRoomMessageChanges: %w", err)
+	}
+	if q.getSearchableUsersByEmailStmt, err = db.PrepareContext(ctx, getSearchableUsersByEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSearchableUsersByEmail: %w", err)
+	}
+	if q.getSearchableUsersByPhoneStmt, err = db.PrepareContext(ctx, getSearchableUsersByPhone); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSearchableUsersByPhone: %w", err)
+	}
+	if q.getSentFriendRequestsStmt, err = db.PrepareContext(ctx, getSentFriendRequests); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSentFriendRequests: %w", err)
+	}
+	if q.getThreadFollowerIDsStmt, err = db.PrepareContext(ctx, getThreadFollowerIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query GetThreadFollowerIDs: %w", err)
+	}
+	if q.getThreadRepliesStmt, err = db.PrepareContext(ctx, getThreadReplies); err != nil {
+		return nil, fmt.Errorf("error preparing query GetThreadReplies: %w", err)
+	}
 	if q.getUnreadMessageCountStmt, err = db.PrepareContext(ctx, getUnreadMessageCount); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUnreadMessageCount: %w", err)
 	}
@@ -324,30 +555,72 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getUserGlobalMuteExpireTimeStmt, err = db.PrepareContext(ctx, getUserGlobalMuteExpireTime); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserGlobalMuteExpireTime: %w", err)
 	}
+	if q.getUserMentionsStmt, err = db.PrepareContext(ctx, getUserMentions); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserMentions: %w", err)
+	}
 	if q.getUserMuteStatusStmt, err = db.PrepareContext(ctx, getUserMuteStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserMuteStatus: %w", err)
 	}
+	if q.getUserNotificationPreferencesStmt, err = db.PrepareContext(ctx, getUserNotificationPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserNotificationPreferences: %w", err)
+	}
+	if q.getUserNotificationsStmt, err = db.PrepareContext(ctx, getUserNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserNotifications: %w", err)
+	}
+	if q.getUserPrivacyPreferencesStmt, err = db.PrepareContext(ctx, getUserPrivacyPreferences); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserPrivacyPreferences: %w", err)
+	}
 	if q.getUserPublicInfoStmt, err = db.PrepareContext(ctx, getUserPublicInfo); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserPublicInfo: %w", err)
 	}
+	if q.getUserSessionByIDStmt, err = db.PrepareContext(ctx, getUserSessionByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserSessionByID: %w", err)
+	}
+	if q.getUserSettingsStmt, err = db.PrepareContext(ctx, getUserSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserSettings: %w", err)
+	}
 	if q.getUserSystemRoleStmt, err = db.PrepareContext(ctx, getUserSystemRole); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserSystemRole: %w", err)
 	}
 	if q.getUserUnreadCountsInAllRoomsStmt, err = db.PrepareContext(ctx, getUserUnreadCountsInAllRooms); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserUnreadCountsInAllRooms: %w", err)
 	}
+	if q.getUserUnreadMentionCountsInAllRoomsStmt, err = db.PrepareContext(ctx, getUserUnreadMentionCountsInAllRooms); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserUnreadMentionCountsInAllRooms: %w", err)
+	}
 	if q.getUsersByIDsStmt, err = db.PrepareContext(ctx, getUsersByIDs); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUsersByIDs: %w", err)
 	}
+	if q.getWSEventStmt, err = db.PrepareContext(ctx, getWSEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWSEvent: %w", err)
+	}
+	if q.handleJoinRequestStmt, err = db.PrepareContext(ctx, handleJoinRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query HandleJoinRequest: %w", err)
+	}
 	if q.incrementChatroomMemberCountStmt, err = db.PrepareContext(ctx, incrementChatroomMemberCount); err != nil {
 		return nil, fmt.Errorf("error preparing query IncrementChatroomMemberCount: %w", err)
 	}
 	if q.incrementChatroomOnlineCountStmt, err = db.PrepareContext(ctx, incrementChatroomOnlineCount); err != nil {
 		return nil, fmt.Errorf("error preparing query IncrementChatroomOnlineCount: %w", err)
 	}
+	if q.isBlockedBetweenStmt, err = db.PrepareContext(ctx, isBlockedBetween); err != nil {
+		return nil, fmt.Errorf("error preparing query IsBlockedBetween: %w", err)
+	}
+	if q.isChatroomArchivedStmt, err = db.PrepareContext(ctx, isChatroomArchived); err != nil {
+		return nil, fmt.Errorf("error preparing query IsChatroomArchived: %w", err)
+	}
 	if q.isChatroomPublicStmt, err = db.PrepareContext(ctx, isChatroomPublic); err != nil {
 		return nil, fmt.Errorf("error preparing query IsChatroomPublic: %w", err)
 	}
+	if q.isDirectChatroomStmt, err = db.PrepareContext(ctx, isDirectChatroom); err != nil {
+		return nil, fmt.Errorf("error preparing query IsDirectChatroom: %w", err)
+	}
+	if q.isDirectConversationBlockedStmt, err = db.PrepareContext(ctx, isDirectConversationBlocked); err != nil {
+		return nil, fmt.Errorf("error preparing query IsDirectConversationBlocked: %w", err)
+	}
+	if q.isFriendStmt, err = db.PrepareContext(ctx, isFriend); err != nil {
+		return nil, fmt.Errorf("error preparing query IsFriend: %w", err)
+	}
 	if q.isMemberMutedStmt, err = db.PrepareContext(ctx, isMemberMuted); err != nil {
 		return nil, fmt.Errorf("error preparing query IsMemberMuted: %w", err)
 	}
@@ -357,6 +630,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.isMessageSenderStmt, err = db.PrepareContext(ctx, isMessageSender); err != nil {
 		return nil, fmt.Errorf("error preparing query IsMessageSender: %w", err)
 	}
+	if q.isThreadFollowerStmt, err = db.PrepareContext(ctx, isThreadFollower); err != nil {
+		return nil, fmt.Errorf("error preparing query IsThreadFollower: %w", err)
+	}
 	if q.isUserAdminStmt, err = db.PrepareContext(ctx, isUserAdmin); err != nil {
 		return nil, fmt.Errorf("error preparing query IsUserAdmin: %w", err)
 	}
@@ -372,6 +648,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.isUserOwnerStmt, err = db.PrepareContext(ctx, isUserOwner); err != nil {
 		return nil, fmt.Errorf("error preparing query IsUserOwner: %w", err)
 	}
+	if q.isUserSearchableByEmailStmt, err = db.PrepareContext(ctx, isUserSearchableByEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query IsUserSearchableByEmail: %w", err)
+	}
+	if q.isUserSearchableByPhoneStmt, err = db.PrepareContext(ctx, isUserSearchableByPhone); err != nil {
+		return nil, fmt.Errorf("error preparing query IsUserSearchableByPhone: %w", err)
+	}
 	if q.joinChatroomStmt, err = db.PrepareContext(ctx, joinChatroom); err != nil {
 		return nil, fmt.Errorf("error preparing query JoinChatroom: %w", err)
 	}
@@ -381,30 +663,99 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.leaveChatroomStmt, err = db.PrepareContext(ctx, leaveChatroom); err != nil {
 		return nil, fmt.Errorf("error preparing query LeaveChatroom: %w", err)
 	}
+	if q.listActiveUserSessionsStmt, err = db.PrepareContext(ctx, listActiveUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListActiveUserSessions: %w", err)
+	}
+	if q.listOwnedChatroomsStmt, err = db.PrepareContext(ctx, listOwnedChatrooms); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOwnedChatrooms: %w", err)
+	}
+	if q.listPendingJoinRequestsStmt, err = db.PrepareContext(ctx, listPendingJoinRequests); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingJoinRequests: %w", err)
+	}
 	if q.listPublicChatroomsStmt, err = db.PrepareContext(ctx, listPublicChatrooms); err != nil {
 		return nil, fmt.Errorf("error preparing query ListPublicChatrooms: %w", err)
 	}
+	if q.listRoomInvitesStmt, err = db.PrepareContext(ctx, listRoomInvites); err != nil {
+		return nil, fmt.Errorf("error preparing query ListRoomInvites: %w", err)
+	}
 	if q.listUserChatroomsStmt, err = db.PrepareContext(ctx, listUserChatrooms); err != nil {
 		return nil, fmt.Errorf("error preparing query ListUserChatrooms: %w", err)
 	}
+	if q.lockRoomPasswordAttemptsStmt, err = db.PrepareContext(ctx, lockRoomPasswordAttempts); err != nil {
+		return nil, fmt.Errorf("error preparing query LockRoomPasswordAttempts: %w", err)
+	}
+	if q.lockUserPresenceStmt, err = db.PrepareContext(ctx, lockUserPresence); err != nil {
+		return nil, fmt.Errorf("error preparing query LockUserPresence: %w", err)
+	}
+	if q.markAllNotificationsAsReadStmt, err = db.PrepareContext(ctx, markAllNotificationsAsRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkAllNotificationsAsRead: %w", err)
+	}
+	if q.markMessageDeliveredStmt, err = db.PrepareContext(ctx, markMessageDelivered); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkMessageDelivered: %w", err)
+	}
+	if q.markMessagesReadUpToStmt, err = db.PrepareContext(ctx, markMessagesReadUpTo); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkMessagesReadUpTo: %w", err)
+	}
+	if q.markNotificationAsReadStmt, err = db.PrepareContext(ctx, markNotificationAsRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationAsRead: %w", err)
+	}
+	if q.markNotificationsByTypeAsReadStmt, err = db.PrepareContext(ctx, markNotificationsByTypeAsRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationsByTypeAsRead: %w", err)
+	}
 	if q.muteMemberStmt, err = db.PrepareContext(ctx, muteMember); err != nil {
 		return nil, fmt.Errorf("error preparing query MuteMember: %w", err)
 	}
+	if q.notifyChannelStmt, err = db.PrepareContext(ctx, notifyChannel); err != nil {
+		return nil, fmt.Errorf("error preparing query NotifyChannel: %w", err)
+	}
+	if q.pinMessageStmt, err = db.PrepareContext(ctx, pinMessage); err != nil {
+		return nil, fmt.Errorf("error preparing query PinMessage: %w", err)
+	}
+	if q.redeemRoomInviteStmt, err = db.PrepareContext(ctx, redeemRoomInvite); err != nil {
+		return nil, fmt.Errorf("error preparing query RedeemRoomInvite: %w", err)
+	}
+	if q.rejectFriendRequestStmt, err = db.PrepareContext(ctx, rejectFriendRequest); err != nil {
+		return nil, fmt.Errorf("error preparing query RejectFriendRequest: %w", err)
+	}
 	if q.removeMemberAdminStmt, err = db.PrepareContext(ctx, removeMemberAdmin); err != nil {
 		return nil, fmt.Errorf("error preparing query RemoveMemberAdmin: %w", err)
 	}
+	if q.removeMessageReactionStmt, err = db.PrepareContext(ctx, removeMessageReaction); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveMessageReaction: %w", err)
+	}
+	if q.reserveRoomPasswordAttemptStmt, err = db.PrepareContext(ctx, reserveRoomPasswordAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query ReserveRoomPasswordAttempt: %w", err)
+	}
+	if q.revokeAllUserSessionsStmt, err = db.PrepareContext(ctx, revokeAllUserSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeAllUserSessions: %w", err)
+	}
+	if q.revokeRoomInviteStmt, err = db.PrepareContext(ctx, revokeRoomInvite); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeRoomInvite: %w", err)
+	}
+	if q.revokeUserSessionStmt, err = db.PrepareContext(ctx, revokeUserSession); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeUserSession: %w", err)
+	}
+	if q.rotateSessionRefreshTokenStmt, err = db.PrepareContext(ctx, rotateSessionRefreshToken); err != nil {
+		return nil, fmt.Errorf("error preparing query RotateSessionRefreshToken: %w", err)
+	}
 	if q.searchChatroomMembersStmt, err = db.PrepareContext(ctx, searchChatroomMembers); err != nil {
 		return nil, fmt.Errorf("error preparing query SearchChatroomMembers: %w", err)
 	}
 	if q.searchChatroomsStmt, err = db.PrepareContext(ctx, searchChatrooms); err != nil {
 		return nil, fmt.Errorf("error preparing query SearchChatrooms: %w", err)
 	}
-	if q.searchMessagesInRoomStmt, err = db.PrepareContext(ctx, searchMessagesInRoom); err != nil {
-		return nil, fmt.Errorf("error preparing query SearchMessagesInRoom: %w", err)
+	if q.searchFriendsStmt, err = db.PrepareContext(ctx, searchFriends); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchFriends: %w", err)
+	}
+	if q.searchMessagesStmt, err = db.PrepareContext(ctx, searchMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchMessages: %w", err)
 	}
 	if q.searchUsersStmt, err = db.PrepareContext(ctx, searchUsers); err != nil {
 		return nil, fmt.Errorf("error preparing query SearchUsers: %w", err)
 	}
+	if q.setChatroomRequireApprovalStmt, err = db.PrepareContext(ctx, setChatroomRequireApproval); err != nil {
+		return nil, fmt.Errorf("error preparing query SetChatroomRequireApproval: %w", err)
+	}
 	if q.setMemberAsAdminStmt, err = db.PrepareContext(ctx, setMemberAsAdmin); err != nil {
 		return nil, fmt.Errorf("error preparing query SetMemberAsAdmin: %w", err)
 	}
@@ -420,6 +771,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.setUserSystemRoleStmt, err = db.PrepareContext(ctx, setUserSystemRole); err != nil {
 		return nil, fmt.Errorf("error preparing query SetUserSystemRole: %w", err)
 	}
+	if q.shouldShowOnlineStatusStmt, err = db.PrepareContext(ctx, shouldShowOnlineStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query ShouldShowOnlineStatus: %w", err)
+	}
 	if q.suspendUserStmt, err = db.PrepareContext(ctx, suspendUser); err != nil {
 		return nil, fmt.Errorf("error preparing query SuspendUser: %w", err)
 	}
@@ -429,12 +783,33 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.syncChatroomOnlineCountStmt, err = db.PrepareContext(ctx, syncChatroomOnlineCount); err != nil {
 		return nil, fmt.Errorf("error preparing query SyncChatroomOnlineCount: %w", err)
 	}
+	if q.syncUserChatroomsOnlineCountStmt, err = db.PrepareContext(ctx, syncUserChatroomsOnlineCount); err != nil {
+		return nil, fmt.Errorf("error preparing query SyncUserChatroomsOnlineCount: %w", err)
+	}
+	if q.touchInstanceConnectionsStmt, err = db.PrepareContext(ctx, touchInstanceConnections); err != nil {
+		return nil, fmt.Errorf("error preparing query TouchInstanceConnections: %w", err)
+	}
+	if q.touchUserSessionStmt, err = db.PrepareContext(ctx, touchUserSession); err != nil {
+		return nil, fmt.Errorf("error preparing query TouchUserSession: %w", err)
+	}
 	if q.transferOwnershipStmt, err = db.PrepareContext(ctx, transferOwnership); err != nil {
 		return nil, fmt.Errorf("error preparing query TransferOwnership: %w", err)
 	}
+	if q.unarchiveChatroomStmt, err = db.PrepareContext(ctx, unarchiveChatroom); err != nil {
+		return nil, fmt.Errorf("error preparing query UnarchiveChatroom: %w", err)
+	}
+	if q.unblockUserStmt, err = db.PrepareContext(ctx, unblockUser); err != nil {
+		return nil, fmt.Errorf("error preparing query UnblockUser: %w", err)
+	}
+	if q.unfollowThreadStmt, err = db.PrepareContext(ctx, unfollowThread); err != nil {
+		return nil, fmt.Errorf("error preparing query UnfollowThread: %w", err)
+	}
 	if q.unmuteMemberStmt, err = db.PrepareContext(ctx, unmuteMember); err != nil {
 		return nil, fmt.Errorf("error preparing query UnmuteMember: %w", err)
 	}
+	if q.unpinMessageStmt, err = db.PrepareContext(ctx, unpinMessage); err != nil {
+		return nil, fmt.Errorf("error preparing query UnpinMessage: %w", err)
+	}
 	if q.updateAccountStatusStmt, err = db.PrepareContext(ctx, updateAccountStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateAccountStatus: %w", err)
 	}
@@ -444,6 +819,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateChatroomLastActiveTimeStmt, err = db.PrepareContext(ctx, updateChatroomLastActiveTime); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateChatroomLastActiveTime: %w", err)
 	}
+	if q.updateLanguageSettingsStmt, err = db.PrepareContext(ctx, updateLanguageSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateLanguageSettings: %w", err)
+	}
 	if q.updateMemberLastReadTimeStmt, err = db.PrepareContext(ctx, updateMemberLastReadTime); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateMemberLastReadTime: %w", err)
 	}
@@ -453,6 +831,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateMessageStmt, err = db.PrepareContext(ctx, updateMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateMessage: %w", err)
 	}
+	if q.updateThemeSettingsStmt, err = db.PrepareContext(ctx, updateThemeSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateThemeSettings: %w", err)
+	}
 	if q.updateUserStmt, err = db.PrepareContext(ctx, updateUser); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUser: %w", err)
 	}
@@ -468,29 +849,49 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateUserPasswordStmt, err = db.PrepareContext(ctx, updateUserPassword); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUserPassword: %w", err)
 	}
-	if q.verifyChatroomPasswordStmt, err = db.PrepareContext(ctx, verifyChatroomPassword); err != nil {
-		return nil, fmt.Errorf("error preparing query VerifyChatroomPassword: %w", err)
+	if q.updateUserSettingsStmt, err = db.PrepareContext(ctx, updateUserSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserSettings: %w", err)
 	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.acceptFriendRequestStmt != nil {
+		if cerr := q.acceptFriendRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing acceptFriendRequestStmt: %w", cerr)
+		}
+	}
 	if q.activateUserStmt != nil {
 		if cerr := q.activateUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing activateUserStmt: %w", cerr)
 		}
 	}
+	if q.addMessageReactionStmt != nil {
+		if cerr := q.addMessageReactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addMessageReactionStmt: %w", cerr)
+		}
+	}
 	if q.archiveChatroomStmt != nil {
 		if cerr := q.archiveChatroomStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing archiveChatroomStmt: %w", cerr)
 		}
 	}
+	if q.blockUserStmt != nil {
+		if cerr := q.blockUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing blockUserStmt: %w", cerr)
+		}
+	}
 	if q.canUserSendMessageInRoomStmt != nil {
 		if cerr := q.canUserSendMessageInRoomStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing canUserSendMessageInRoomStmt: %w", cerr)
 		}
 	}
+	if q.cancelFriendRequestStmt != nil {
+		if cerr := q.cancelFriendRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelFriendRequestStmt: %w", cerr)
+		}
+	}
 	if q.checkEmailExistsStmt != nil {
 		if cerr := q.checkEmailExistsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing checkEmailExistsStmt: %w", cerr)
@@ -511,6 +912,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing clearExpiredMutesStmt: %w", cerr)
 		}
 	}
+	if q.clearRoomPasswordAttemptsStmt != nil {
+		if cerr := q.clearRoomPasswordAttemptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearRoomPasswordAttemptsStmt: %w", cerr)
+		}
+	}
 	if q.countAdminLogsStmt != nil {
 		if cerr := q.countAdminLogsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countAdminLogsStmt: %w", cerr)
@@ -536,6 +942,26 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing countChatroomMembersStmt: %w", cerr)
 		}
 	}
+	if q.countFriendsStmt != nil {
+		if cerr := q.countFriendsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countFriendsStmt: %w", cerr)
+		}
+	}
+	if q.countFriendsByStatusStmt != nil {
+		if cerr := q.countFriendsByStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countFriendsByStatusStmt: %w", cerr)
+		}
+	}
+	if q.countLiveUserConnectionsStmt != nil {
+		if cerr := q.countLiveUserConnectionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countLiveUserConnectionsStmt: %w", cerr)
+		}
+	}
+	if q.countMessageReactionsByEmojiStmt != nil {
+		if cerr := q.countMessageReactionsByEmojiStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countMessageReactionsByEmojiStmt: %w", cerr)
+		}
+	}
 	if q.countMessagesInRoomStmt != nil {
 		if cerr := q.countMessagesInRoomStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countMessagesInRoomStmt: %w", cerr)
@@ -546,11 +972,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing countOnlineChatroomMembersStmt: %w", cerr)
 		}
 	}
+	if q.countOnlineFriendsStmt != nil {
+		if cerr := q.countOnlineFriendsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countOnlineFriendsStmt: %w", cerr)
+		}
+	}
 	if q.countOnlineUsersStmt != nil {
 		if cerr := q.countOnlineUsersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countOnlineUsersStmt: %w", cerr)
 		}
 	}
+	if q.countPendingJoinRequestsStmt != nil {
+		if cerr := q.countPendingJoinRequestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countPendingJoinRequestsStmt: %w", cerr)
+		}
+	}
+	if q.countPendingReceivedRequestsStmt != nil {
+		if cerr := q.countPendingReceivedRequestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countPendingReceivedRequestsStmt: %w", cerr)
+		}
+	}
+	if q.countPinnedMessagesStmt != nil {
+		if cerr := q.countPinnedMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countPinnedMessagesStmt: %w", cerr)
+		}
+	}
+	if q.countReceivedFriendRequestsStmt != nil {
+		if cerr := q.countReceivedFriendRequestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countReceivedFriendRequestsStmt: %w", cerr)
+		}
+	}
 	if q.countSearchChatroomMembersStmt != nil {
 		if cerr := q.countSearchChatroomMembersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countSearchChatroomMembersStmt: %w", cerr)
@@ -561,11 +1012,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing countSearchUsersStmt: %w", cerr)
 		}
 	}
+	if q.countSentFriendRequestsStmt != nil {
+		if cerr := q.countSentFriendRequestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countSentFriendRequestsStmt: %w", cerr)
+		}
+	}
+	if q.countUnreadNotificationsStmt != nil {
+		if cerr := q.countUnreadNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUnreadNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.countUnreadNotificationsByTypeStmt != nil {
+		if cerr := q.countUnreadNotificationsByTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUnreadNotificationsByTypeStmt: %w", cerr)
+		}
+	}
 	if q.countUserChatroomsStmt != nil {
 		if cerr := q.countUserChatroomsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countUserChatroomsStmt: %w", cerr)
 		}
 	}
+	if q.countUserMentionsStmt != nil {
+		if cerr := q.countUserMentionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUserMentionsStmt: %w", cerr)
+		}
+	}
+	if q.countUserNotificationsStmt != nil {
+		if cerr := q.countUserNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUserNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.countUserReactionsOnMessageStmt != nil {
+		if cerr := q.countUserReactionsOnMessageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUserReactionsOnMessageStmt: %w", cerr)
+		}
+	}
 	if q.createAdminLogStmt != nil {
 		if cerr := q.createAdminLogStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createAdminLogStmt: %w", cerr)
@@ -581,16 +1062,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createChatroomStmt: %w", cerr)
 		}
 	}
+	if q.createChatroomNotificationStmt != nil {
+		if cerr := q.createChatroomNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createChatroomNotificationStmt: %w", cerr)
+		}
+	}
 	if q.createDeleteMessageLogStmt != nil {
 		if cerr := q.createDeleteMessageLogStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createDeleteMessageLogStmt: %w", cerr)
 		}
 	}
+	if q.createDirectConversationStmt != nil {
+		if cerr := q.createDirectConversationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createDirectConversationStmt: %w", cerr)
+		}
+	}
+	if q.createFriendNotificationStmt != nil {
+		if cerr := q.createFriendNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFriendNotificationStmt: %w", cerr)
+		}
+	}
+	if q.createFriendRequestStmt != nil {
+		if cerr := q.createFriendRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFriendRequestStmt: %w", cerr)
+		}
+	}
+	if q.createFriendshipStmt != nil {
+		if cerr := q.createFriendshipStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFriendshipStmt: %w", cerr)
+		}
+	}
 	if q.createGlobalMuteRecordStmt != nil {
 		if cerr := q.createGlobalMuteRecordStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createGlobalMuteRecordStmt: %w", cerr)
 		}
 	}
+	if q.createJoinRequestStmt != nil {
+		if cerr := q.createJoinRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createJoinRequestStmt: %w", cerr)
+		}
+	}
 	if q.createKickLogStmt != nil {
 		if cerr := q.createKickLogStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createKickLogStmt: %w", cerr)
@@ -601,6 +1112,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createMessageStmt: %w", cerr)
 		}
 	}
+	if q.createMessageEditStmt != nil {
+		if cerr := q.createMessageEditStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMessageEditStmt: %w", cerr)
+		}
+	}
+	if q.createMessageMentionsStmt != nil {
+		if cerr := q.createMessageMentionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMessageMentionsStmt: %w", cerr)
+		}
+	}
+	if q.createMessageReceiptsStmt != nil {
+		if cerr := q.createMessageReceiptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createMessageReceiptsStmt: %w", cerr)
+		}
+	}
 	if q.createMuteLogStmt != nil {
 		if cerr := q.createMuteLogStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createMuteLogStmt: %w", cerr)
@@ -611,11 +1137,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createMuteRecordStmt: %w", cerr)
 		}
 	}
+	if q.createNotificationStmt != nil {
+		if cerr := q.createNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNotificationStmt: %w", cerr)
+		}
+	}
 	if q.createRoleChangeLogStmt != nil {
 		if cerr := q.createRoleChangeLogStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createRoleChangeLogStmt: %w", cerr)
 		}
 	}
+	if q.createRoomInviteStmt != nil {
+		if cerr := q.createRoomInviteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRoomInviteStmt: %w", cerr)
+		}
+	}
+	if q.createSystemNotificationStmt != nil {
+		if cerr := q.createSystemNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSystemNotificationStmt: %w", cerr)
+		}
+	}
+	if q.createThreadReplyReceiptsStmt != nil {
+		if cerr := q.createThreadReplyReceiptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createThreadReplyReceiptsStmt: %w", cerr)
+		}
+	}
 	if q.createUnmuteLogStmt != nil {
 		if cerr := q.createUnmuteLogStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createUnmuteLogStmt: %w", cerr)
@@ -626,6 +1172,26 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createUserStmt: %w", cerr)
 		}
 	}
+	if q.createUserSessionStmt != nil {
+		if cerr := q.createUserSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createUserSessionStmt: %w", cerr)
+		}
+	}
+	if q.createUserSettingsStmt != nil {
+		if cerr := q.createUserSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createUserSettingsStmt: %w", cerr)
+		}
+	}
+	if q.createWSConnectionStmt != nil {
+		if cerr := q.createWSConnectionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWSConnectionStmt: %w", cerr)
+		}
+	}
+	if q.createWSEventStmt != nil {
+		if cerr := q.createWSEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createWSEventStmt: %w", cerr)
+		}
+	}
 	if q.deactivateGlobalMuteRecordStmt != nil {
 		if cerr := q.deactivateGlobalMuteRecordStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deactivateGlobalMuteRecordStmt: %w", cerr)
@@ -661,6 +1227,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteChatroomStmt: %w", cerr)
 		}
 	}
+	if q.deleteExpiredWSEventsStmt != nil {
+		if cerr := q.deleteExpiredWSEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredWSEventsStmt: %w", cerr)
+		}
+	}
+	if q.deleteFriendshipStmt != nil {
+		if cerr := q.deleteFriendshipStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteFriendshipStmt: %w", cerr)
+		}
+	}
 	if q.deleteMessageStmt != nil {
 		if cerr := q.deleteMessageStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteMessageStmt: %w", cerr)
@@ -686,11 +1262,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteMessagesByUserInRoomStmt: %w", cerr)
 		}
 	}
+	if q.deleteNotificationStmt != nil {
+		if cerr := q.deleteNotificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteNotificationStmt: %w", cerr)
+		}
+	}
+	if q.deleteOldNotificationsStmt != nil {
+		if cerr := q.deleteOldNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOldNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.deleteReadNotificationsStmt != nil {
+		if cerr := q.deleteReadNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReadNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.deleteStaleWSConnectionsStmt != nil {
+		if cerr := q.deleteStaleWSConnectionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStaleWSConnectionsStmt: %w", cerr)
+		}
+	}
 	if q.deleteUserAccountStmt != nil {
 		if cerr := q.deleteUserAccountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteUserAccountStmt: %w", cerr)
 		}
 	}
+	if q.deleteUserSettingsStmt != nil {
+		if cerr := q.deleteUserSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserSettingsStmt: %w", cerr)
+		}
+	}
+	if q.deleteWSConnectionStmt != nil {
+		if cerr := q.deleteWSConnectionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWSConnectionStmt: %w", cerr)
+		}
+	}
 	if q.expireGlobalMuteRecordsStmt != nil {
 		if cerr := q.expireGlobalMuteRecordsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing expireGlobalMuteRecordsStmt: %w", cerr)
@@ -701,6 +1307,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing expireMuteRecordsStmt: %w", cerr)
 		}
 	}
+	if q.followThreadStmt != nil {
+		if cerr := q.followThreadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing followThreadStmt: %w", cerr)
+		}
+	}
 	if q.getActiveGlobalMuteRecordStmt != nil {
 		if cerr := q.getActiveGlobalMuteRecordStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getActiveGlobalMuteRecordStmt: %w", cerr)
@@ -721,6 +1332,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getActiveMuteRecordsByRoomStmt: %w", cerr)
 		}
 	}
+	if q.getActiveUserSessionStmt != nil {
+		if cerr := q.getActiveUserSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getActiveUserSessionStmt: %w", cerr)
+		}
+	}
 	if q.getAdminLogByIDStmt != nil {
 		if cerr := q.getAdminLogByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAdminLogByIDStmt: %w", cerr)
@@ -766,6 +1382,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getAllActiveGlobalMuteRecordsStmt: %w", cerr)
 		}
 	}
+	if q.getBlockedUsersStmt != nil {
+		if cerr := q.getBlockedUsersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBlockedUsersStmt: %w", cerr)
+		}
+	}
 	if q.getChatroomAdminsStmt != nil {
 		if cerr := q.getChatroomAdminsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getChatroomAdminsStmt: %w", cerr)
@@ -776,6 +1397,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getChatroomByIDStmt: %w", cerr)
 		}
 	}
+	if q.getChatroomIncludingArchivedStmt != nil {
+		if cerr := q.getChatroomIncludingArchivedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getChatroomIncludingArchivedStmt: %w", cerr)
+		}
+	}
 	if q.getChatroomMembersStmt != nil {
 		if cerr := q.getChatroomMembersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getChatroomMembersStmt: %w", cerr)
@@ -791,6 +1417,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getChatroomWithoutPasswordStmt: %w", cerr)
 		}
 	}
+	if q.getDirectConversationStmt != nil {
+		if cerr := q.getDirectConversationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDirectConversationStmt: %w", cerr)
+		}
+	}
+	if q.getDirectConversationPeerStmt != nil {
+		if cerr := q.getDirectConversationPeerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDirectConversationPeerStmt: %w", cerr)
+		}
+	}
+	if q.getFriendRequestByIDStmt != nil {
+		if cerr := q.getFriendRequestByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFriendRequestByIDStmt: %w", cerr)
+		}
+	}
+	if q.getFriendsStmt != nil {
+		if cerr := q.getFriendsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFriendsStmt: %w", cerr)
+		}
+	}
+	if q.getFriendsByStatusStmt != nil {
+		if cerr := q.getFriendsByStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFriendsByStatusStmt: %w", cerr)
+		}
+	}
 	if q.getGlobalAdminLogsStmt != nil {
 		if cerr := q.getGlobalAdminLogsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getGlobalAdminLogsStmt: %w", cerr)
@@ -816,6 +1467,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getLatestMessagesStmt: %w", cerr)
 		}
 	}
+	if q.getLongestTenuredAdminStmt != nil {
+		if cerr := q.getLongestTenuredAdminStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLongestTenuredAdminStmt: %w", cerr)
+		}
+	}
 	if q.getMemberByRelIDStmt != nil {
 		if cerr := q.getMemberByRelIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getMemberByRelIDStmt: %w", cerr)
@@ -836,11 +1492,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getMemberRoleStmt: %w", cerr)
 		}
 	}
+	if q.getMessageByClientMsgIDStmt != nil {
+		if cerr := q.getMessageByClientMsgIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMessageByClientMsgIDStmt: %w", cerr)
+		}
+	}
 	if q.getMessageByIDStmt != nil {
 		if cerr := q.getMessageByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getMessageByIDStmt: %w", cerr)
 		}
 	}
+	if q.getMessageEditsStmt != nil {
+		if cerr := q.getMessageEditsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMessageEditsStmt: %w", cerr)
+		}
+	}
+	if q.getMessageReactionsStmt != nil {
+		if cerr := q.getMessageReactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMessageReactionsStmt: %w", cerr)
+		}
+	}
+	if q.getMessageReadByStmt != nil {
+		if cerr := q.getMessageReadByStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMessageReadByStmt: %w", cerr)
+		}
+	}
+	if q.getMessageReceiptsStmt != nil {
+		if cerr := q.getMessageReceiptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMessageReceiptsStmt: %w", cerr)
+		}
+	}
 	if q.getMessageRoomStmt != nil {
 		if cerr := q.getMessageRoomStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getMessageRoomStmt: %w", cerr)
@@ -921,11 +1602,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getMutedMembersStmt: %w", cerr)
 		}
 	}
+	if q.getMutualFriendsStmt != nil {
+		if cerr := q.getMutualFriendsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMutualFriendsStmt: %w", cerr)
+		}
+	}
+	if q.getNotificationByIDStmt != nil {
+		if cerr := q.getNotificationByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNotificationByIDStmt: %w", cerr)
+		}
+	}
+	if q.getNotificationStatsStmt != nil {
+		if cerr := q.getNotificationStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNotificationStatsStmt: %w", cerr)
+		}
+	}
 	if q.getOnlineChatroomMembersStmt != nil {
 		if cerr := q.getOnlineChatroomMembersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getOnlineChatroomMembersStmt: %w", cerr)
 		}
 	}
+	if q.getOnlineFriendsStmt != nil {
+		if cerr := q.getOnlineFriendsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOnlineFriendsStmt: %w", cerr)
+		}
+	}
 	if q.getOnlineUsersStmt != nil {
 		if cerr := q.getOnlineUsersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getOnlineUsersStmt: %w", cerr)
@@ -936,11 +1637,76 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getOperatorStatsStmt: %w", cerr)
 		}
 	}
+	if q.getPendingJoinRequestStmt != nil {
+		if cerr := q.getPendingJoinRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPendingJoinRequestStmt: %w", cerr)
+		}
+	}
+	if q.getPendingReceivedRequestsStmt != nil {
+		if cerr := q.getPendingReceivedRequestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPendingReceivedRequestsStmt: %w", cerr)
+		}
+	}
+	if q.getPendingRequestBetweenUsersStmt != nil {
+		if cerr := q.getPendingRequestBetweenUsersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPendingRequestBetweenUsersStmt: %w", cerr)
+		}
+	}
+	if q.getPinnedMessagesStmt != nil {
+		if cerr := q.getPinnedMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPinnedMessagesStmt: %w", cerr)
+		}
+	}
 	if q.getQuotedMessageStmt != nil {
 		if cerr := q.getQuotedMessageStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getQuotedMessageStmt: %w", cerr)
 		}
 	}
+	if q.getReactionSummariesStmt != nil {
+		if cerr := q.getReactionSummariesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReactionSummariesStmt: %w", cerr)
+		}
+	}
+	if q.getReceivedFriendRequestsStmt != nil {
+		if cerr := q.getReceivedFriendRequestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReceivedFriendRequestsStmt: %w", cerr)
+		}
+	}
+	if q.getRoomInviteStmt != nil {
+		if cerr := q.getRoomInviteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRoomInviteStmt: %w", cerr)
+		}
+	}
+	if q.getRoomMessageChangesStmt != nil {
+		if cerr := q.getRoomMessageChangesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRoomMessageChangesStmt: %w", cerr)
+		}
+	}
+	if q.getSearchableUsersByEmailStmt != nil {
+		if cerr := q.getSearchableUsersByEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSearchableUsersByEmailStmt: %w", cerr)
+		}
+	}
+	if q.getSearchableUsersByPhoneStmt != nil {
+		if cerr := q.getSearchableUsersByPhoneStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSearchableUsersByPhoneStmt: %w", cerr)
+		}
+	}
+	if q.getSentFriendRequestsStmt != nil {
+		if cerr := q.getSentFriendRequestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSentFriendRequestsStmt: %w", cerr)
+		}
+	}
+	if q.getThreadFollowerIDsStmt != nil {
+		if cerr := q.getThreadFollowerIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getThreadFollowerIDsStmt: %w", cerr)
+		}
+	}
+	if q.getThreadRepliesStmt != nil {
+		if cerr := q.getThreadRepliesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getThreadRepliesStmt: %w", cerr)
+		}
+	}
 	if q.getUnreadMessageCountStmt != nil {
 		if cerr := q.getUnreadMessageCountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUnreadMessageCountStmt: %w", cerr)
@@ -976,16 +1742,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserGlobalMuteExpireTimeStmt: %w", cerr)
 		}
 	}
+	if q.getUserMentionsStmt != nil {
+		if cerr := q.getUserMentionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserMentionsStmt: %w", cerr)
+		}
+	}
 	if q.getUserMuteStatusStmt != nil {
 		if cerr := q.getUserMuteStatusStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserMuteStatusStmt: %w", cerr)
 		}
 	}
+	if q.getUserNotificationPreferencesStmt != nil {
+		if cerr := q.getUserNotificationPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserNotificationPreferencesStmt: %w", cerr)
+		}
+	}
+	if q.getUserNotificationsStmt != nil {
+		if cerr := q.getUserNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.getUserPrivacyPreferencesStmt != nil {
+		if cerr := q.getUserPrivacyPreferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserPrivacyPreferencesStmt: %w", cerr)
+		}
+	}
 	if q.getUserPublicInfoStmt != nil {
 		if cerr := q.getUserPublicInfoStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserPublicInfoStmt: %w", cerr)
 		}
 	}
+	if q.getUserSessionByIDStmt != nil {
+		if cerr := q.getUserSessionByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserSessionByIDStmt: %w", cerr)
+		}
+	}
+	if q.getUserSettingsStmt != nil {
+		if cerr := q.getUserSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserSettingsStmt: %w", cerr)
+		}
+	}
 	if q.getUserSystemRoleStmt != nil {
 		if cerr := q.getUserSystemRoleStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserSystemRoleStmt: %w", cerr)
@@ -996,11 +1792,26 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserUnreadCountsInAllRoomsStmt: %w", cerr)
 		}
 	}
+	if q.getUserUnreadMentionCountsInAllRoomsStmt != nil {
+		if cerr := q.getUserUnreadMentionCountsInAllRoomsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserUnreadMentionCountsInAllRoomsStmt: %w", cerr)
+		}
+	}
 	if q.getUsersByIDsStmt != nil {
 		if cerr := q.getUsersByIDsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUsersByIDsStmt: %w", cerr)
 		}
 	}
+	if q.getWSEventStmt != nil {
+		if cerr := q.getWSEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWSEventStmt: %w", cerr)
+		}
+	}
+	if q.handleJoinRequestStmt != nil {
+		if cerr := q.handleJoinRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing handleJoinRequestStmt: %w", cerr)
+		}
+	}
 	if q.incrementChatroomMemberCountStmt != nil {
 		if cerr := q.incrementChatroomMemberCountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing incrementChatroomMemberCountStmt: %w", cerr)
@@ -1011,11 +1822,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing incrementChatroomOnlineCountStmt: %w", cerr)
 		}
 	}
+	if q.isBlockedBetweenStmt != nil {
+		if cerr := q.isBlockedBetweenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isBlockedBetweenStmt: %w", cerr)
+		}
+	}
+	if q.isChatroomArchivedStmt != nil {
+		if cerr := q.isChatroomArchivedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isChatroomArchivedStmt: %w", cerr)
+		}
+	}
 	if q.isChatroomPublicStmt != nil {
 		if cerr := q.isChatroomPublicStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing isChatroomPublicStmt: %w", cerr)
 		}
 	}
+	if q.isDirectChatroomStmt != nil {
+		if cerr := q.isDirectChatroomStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isDirectChatroomStmt: %w", cerr)
+		}
+	}
+	if q.isDirectConversationBlockedStmt != nil {
+		if cerr := q.isDirectConversationBlockedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isDirectConversationBlockedStmt: %w", cerr)
+		}
+	}
+	if q.isFriendStmt != nil {
+		if cerr := q.isFriendStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isFriendStmt: %w", cerr)
+		}
+	}
 	if q.isMemberMutedStmt != nil {
 		if cerr := q.isMemberMutedStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing isMemberMutedStmt: %w", cerr)
@@ -1031,6 +1867,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing isMessageSenderStmt: %w", cerr)
 		}
 	}
+	if q.isThreadFollowerStmt != nil {
+		if cerr := q.isThreadFollowerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isThreadFollowerStmt: %w", cerr)
+		}
+	}
 	if q.isUserAdminStmt != nil {
 		if cerr := q.isUserAdminStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing isUserAdminStmt: %w", cerr)
@@ -1056,6 +1897,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing isUserOwnerStmt: %w", cerr)
 		}
 	}
+	if q.isUserSearchableByEmailStmt != nil {
+		if cerr := q.isUserSearchableByEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isUserSearchableByEmailStmt: %w", cerr)
+		}
+	}
+	if q.isUserSearchableByPhoneStmt != nil {
+		if cerr := q.isUserSearchableByPhoneStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isUserSearchableByPhoneStmt: %w", cerr)
+		}
+	}
 	if q.joinChatroomStmt != nil {
 		if cerr := q.joinChatroomStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing joinChatroomStmt: %w", cerr)
@@ -1071,26 +1922,131 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing leaveChatroomStmt: %w", cerr)
 		}
 	}
+	if q.listActiveUserSessionsStmt != nil {
+		if cerr := q.listActiveUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listActiveUserSessionsStmt: %w", cerr)
+		}
+	}
+	if q.listOwnedChatroomsStmt != nil {
+		if cerr := q.listOwnedChatroomsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOwnedChatroomsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingJoinRequestsStmt != nil {
+		if cerr := q.listPendingJoinRequestsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingJoinRequestsStmt: %w", cerr)
+		}
+	}
 	if q.listPublicChatroomsStmt != nil {
 		if cerr := q.listPublicChatroomsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listPublicChatroomsStmt: %w", cerr)
 		}
 	}
+	if q.listRoomInvitesStmt != nil {
+		if cerr := q.listRoomInvitesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listRoomInvitesStmt: %w", cerr)
+		}
+	}
 	if q.listUserChatroomsStmt != nil {
 		if cerr := q.listUserChatroomsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listUserChatroomsStmt: %w", cerr)
 		}
 	}
+	if q.lockRoomPasswordAttemptsStmt != nil {
+		if cerr := q.lockRoomPasswordAttemptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockRoomPasswordAttemptsStmt: %w", cerr)
+		}
+	}
+	if q.lockUserPresenceStmt != nil {
+		if cerr := q.lockUserPresenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing lockUserPresenceStmt: %w", cerr)
+		}
+	}
+	if q.markAllNotificationsAsReadStmt != nil {
+		if cerr := q.markAllNotificationsAsReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markAllNotificationsAsReadStmt: %w", cerr)
+		}
+	}
+	if q.markMessageDeliveredStmt != nil {
+		if cerr := q.markMessageDeliveredStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markMessageDeliveredStmt: %w", cerr)
+		}
+	}
+	if q.markMessagesReadUpToStmt != nil {
+		if cerr := q.markMessagesReadUpToStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markMessagesReadUpToStmt: %w", cerr)
+		}
+	}
+	if q.markNotificationAsReadStmt != nil {
+		if cerr := q.markNotificationAsReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationAsReadStmt: %w", cerr)
+		}
+	}
+	if q.markNotificationsByTypeAsReadStmt != nil {
+		if cerr := q.markNotificationsByTypeAsReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationsByTypeAsReadStmt: %w", cerr)
+		}
+	}
 	if q.muteMemberStmt != nil {
 		if cerr := q.muteMemberStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing muteMemberStmt: %w", cerr)
 		}
 	}
+	if q.notifyChannelStmt != nil {
+		if cerr := q.notifyChannelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing notifyChannelStmt: %w", cerr)
+		}
+	}
+	if q.pinMessageStmt != nil {
+		if cerr := q.pinMessageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing pinMessageStmt: %w", cerr)
+		}
+	}
+	if q.redeemRoomInviteStmt != nil {
+		if cerr := q.redeemRoomInviteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing redeemRoomInviteStmt: %w", cerr)
+		}
+	}
+	if q.rejectFriendRequestStmt != nil {
+		if cerr := q.rejectFriendRequestStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rejectFriendRequestStmt: %w", cerr)
+		}
+	}
 	if q.removeMemberAdminStmt != nil {
 		if cerr := q.removeMemberAdminStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing removeMemberAdminStmt: %w", cerr)
 		}
 	}
+	if q.removeMessageReactionStmt != nil {
+		if cerr := q.removeMessageReactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeMessageReactionStmt: %w", cerr)
+		}
+	}
+	if q.reserveRoomPasswordAttemptStmt != nil {
+		if cerr := q.reserveRoomPasswordAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reserveRoomPasswordAttemptStmt: %w", cerr)
+		}
+	}
+	if q.revokeAllUserSessionsStmt != nil {
+		if cerr := q.revokeAllUserSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeAllUserSessionsStmt: %w", cerr)
+		}
+	}
+	if q.revokeRoomInviteStmt != nil {
+		if cerr := q.revokeRoomInviteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeRoomInviteStmt: %w", cerr)
+		}
+	}
+	if q.revokeUserSessionStmt != nil {
+		if cerr := q.revokeUserSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeUserSessionStmt: %w", cerr)
+		}
+	}
+	if q.rotateSessionRefreshTokenStmt != nil {
+		if cerr := q.rotateSessionRefreshTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rotateSessionRefreshTokenStmt: %w", cerr)
+		}
+	}
 	if q.searchChatroomMembersStmt != nil {
 		if cerr := q.searchChatroomMembersStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing searchChatroomMembersStmt: %w", cerr)
@@ -1101,9 +2057,14 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing searchChatroomsStmt: %w", cerr)
 		}
 	}
-	if q.searchMessagesInRoomStmt != nil {
-		if cerr := q.searchMessagesInRoomStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing searchMessagesInRoomStmt: %w", cerr)
+	if q.searchFriendsStmt != nil {
+		if cerr := q.searchFriendsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchFriendsStmt: %w", cerr)
+		}
+	}
+	if q.searchMessagesStmt != nil {
+		if cerr := q.searchMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchMessagesStmt: %w", cerr)
 		}
 	}
 	if q.searchUsersStmt != nil {
@@ -1111,6 +2072,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing searchUsersStmt: %w", cerr)
 		}
 	}
+	if q.setChatroomRequireApprovalStmt != nil {
+		if cerr := q.setChatroomRequireApprovalStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setChatroomRequireApprovalStmt: %w", cerr)
+		}
+	}
 	if q.setMemberAsAdminStmt != nil {
 		if cerr := q.setMemberAsAdminStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing setMemberAsAdminStmt: %w", cerr)
@@ -1136,6 +2102,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing setUserSystemRoleStmt: %w", cerr)
 		}
 	}
+	if q.shouldShowOnlineStatusStmt != nil {
+		if cerr := q.shouldShowOnlineStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing shouldShowOnlineStatusStmt: %w", cerr)
+		}
+	}
 	if q.suspendUserStmt != nil {
 		if cerr := q.suspendUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing suspendUserStmt: %w", cerr)
@@ -1151,16 +2122,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing syncChatroomOnlineCountStmt: %w", cerr)
 		}
 	}
+	if q.syncUserChatroomsOnlineCountStmt != nil {
+		if cerr := q.syncUserChatroomsOnlineCountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing syncUserChatroomsOnlineCountStmt: %w", cerr)
+		}
+	}
+	if q.touchInstanceConnectionsStmt != nil {
+		if cerr := q.touchInstanceConnectionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchInstanceConnectionsStmt: %w", cerr)
+		}
+	}
+	if q.touchUserSessionStmt != nil {
+		if cerr := q.touchUserSessionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchUserSessionStmt: %w", cerr)
+		}
+	}
 	if q.transferOwnershipStmt != nil {
 		if cerr := q.transferOwnershipStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing transferOwnershipStmt: %w", cerr)
 		}
 	}
+	if q.unarchiveChatroomStmt != nil {
+		if cerr := q.unarchiveChatroomStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unarchiveChatroomStmt: %w", cerr)
+		}
+	}
+	if q.unblockUserStmt != nil {
+		if cerr := q.unblockUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unblockUserStmt: %w", cerr)
+		}
+	}
+	if q.unfollowThreadStmt != nil {
+		if cerr := q.unfollowThreadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unfollowThreadStmt: %w", cerr)
+		}
+	}
 	if q.unmuteMemberStmt != nil {
 		if cerr := q.unmuteMemberStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing unmuteMemberStmt: %w", cerr)
 		}
 	}
+	if q.unpinMessageStmt != nil {
+		if cerr := q.unpinMessageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing unpinMessageStmt: %w", cerr)
+		}
+	}
 	if q.updateAccountStatusStmt != nil {
 		if cerr := q.updateAccountStatusStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateAccountStatusStmt: %w", cerr)
@@ -1176,6 +2182,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateChatroomLastActiveTimeStmt: %w", cerr)
 		}
 	}
+	if q.updateLanguageSettingsStmt != nil {
+		if cerr := q.updateLanguageSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateLanguageSettingsStmt: %w", cerr)
+		}
+	}
 	if q.updateMemberLastReadTimeStmt != nil {
 		if cerr := q.updateMemberLastReadTimeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateMemberLastReadTimeStmt: %w", cerr)
@@ -1191,6 +2202,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateMessageStmt: %w", cerr)
 		}
 	}
+	if q.updateThemeSettingsStmt != nil {
+		if cerr := q.updateThemeSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateThemeSettingsStmt: %w", cerr)
+		}
+	}
 	if q.updateUserStmt != nil {
 		if cerr := q.updateUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserStmt: %w", cerr)
@@ -1216,9 +2232,9 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateUserPasswordStmt: %w", cerr)
 		}
 	}
-	if q.verifyChatroomPasswordStmt != nil {
-		if cerr := q.verifyChatroomPasswordStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing verifyChatroomPasswordStmt: %w", cerr)
+	if q.updateUserSettingsStmt != nil {
+		if cerr := q.updateUserSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserSettingsStmt: %w", cerr)
 		}
 	}
 	return err
@@ -1258,311 +2274,565 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                 DBTX
-	tx                                 *sql.Tx
-	activateUserStmt                   *sql.Stmt
-	archiveChatroomStmt                *sql.Stmt
-	canUserSendMessageInRoomStmt       *sql.Stmt
-	checkEmailExistsStmt               *sql.Stmt
-	checkPhoneExistsStmt               *sql.Stmt
-	checkUsernameExistsStmt            *sql.Stmt
-	clearExpiredMutesStmt              *sql.Stmt
-	countAdminLogsStmt                 *sql.Stmt
-	countAdminLogsByOperatorStmt       *sql.Stmt
-	countAdminLogsByRoomStmt           *sql.Stmt
-	countAdminLogsByTypeStmt           *sql.Stmt
-	countChatroomMembersStmt           *sql.Stmt
-	countMessagesInRoomStmt            *sql.Stmt
-	countOnlineChatroomMembersStmt     *sql.Stmt
-	countOnlineUsersStmt               *sql.Stmt
-	countSearchChatroomMembersStmt     *sql.Stmt
-	countSearchUsersStmt               *sql.Stmt
-	countUserChatroomsStmt             *sql.Stmt
-	createAdminLogStmt                 *sql.Stmt
-	createBanLogStmt                   *sql.Stmt
-	createChatroomStmt                 *sql.Stmt
-	createDeleteMessageLogStmt         *sql.Stmt
-	createGlobalMuteRecordStmt         *sql.Stmt
-	createKickLogStmt                  *sql.Stmt
-	createMessageStmt                  *sql.Stmt
-	createMuteLogStmt                  *sql.Stmt
-	createMuteRecordStmt               *sql.Stmt
-	createRoleChangeLogStmt            *sql.Stmt
-	createUnmuteLogStmt                *sql.Stmt
-	createUserStmt                     *sql.Stmt
-	deactivateGlobalMuteRecordStmt     *sql.Stmt
-	deactivateGlobalMuteRecordByIDStmt *sql.Stmt
-	deactivateMuteRecordStmt           *sql.Stmt
-	deactivateMuteRecordByIDStmt       *sql.Stmt
-	decrementChatroomMemberCountStmt   *sql.Stmt
-	decrementChatroomOnlineCountStmt   *sql.Stmt
-	deleteChatroomStmt                 *sql.Stmt
-	deleteMessageStmt                  *sql.Stmt
-	deleteMessageSoftStmt              *sql.Stmt
-	deleteMessagesByRoomStmt           *sql.Stmt
-	deleteMessagesByUserStmt           *sql.Stmt
-	deleteMessagesByUserInRoomStmt     *sql.Stmt
-	deleteUserAccountStmt              *sql.Stmt
-	expireGlobalMuteRecordsStmt        *sql.Stmt
-	expireMuteRecordsStmt              *sql.Stmt
-	getActiveGlobalMuteRecordStmt      *sql.Stmt
-	getActiveMembershipStmt            *sql.Stmt
-	getActiveMuteRecordStmt            *sql.Stmt
-	getActiveMuteRecordsByRoomStmt     *sql.Stmt
-	getAdminLogByIDStmt                *sql.Stmt
-	getAdminLogStatsStmt               *sql.Stmt
-	getAdminLogsStmt                   *sql.Stmt
-	getAdminLogsByOperatorStmt         *sql.Stmt
-	getAdminLogsByRoomStmt             *sql.Stmt
-	getAdminLogsByTimeRangeStmt        *sql.Stmt
-	getAdminLogsByTypeStmt             *sql.Stmt
-	getAdminLogsByUserStmt             *sql.Stmt
-	getAllActiveGlobalMuteRecordsStmt  *sql.Stmt
-	getChatroomAdminsStmt              *sql.Stmt
-	getChatroomByIDStmt                *sql.Stmt
-	getChatroomMembersStmt             *sql.Stmt
-	getChatroomOwnerStmt               *sql.Stmt
-	getChatroomWithoutPasswordStmt     *sql.Stmt
-	getGlobalAdminLogsStmt             *sql.Stmt
-	getGlobalMuteRecordByIDStmt        *sql.Stmt
-	getGlobalMuteRecordsByUserStmt     *sql.Stmt
-	getLastMessageInRoomStmt           *sql.Stmt
-	getLatestMessagesStmt              *sql.Stmt
-	getMemberByRelIDStmt               *sql.Stmt
-	getMemberLastReadTimeStmt          *sql.Stmt
-	getMemberMuteExpireTimeStmt        *sql.Stmt
-	getMemberRoleStmt                  *sql.Stmt
-	getMessageByIDStmt                 *sql.Stmt
-	getMessageRoomStmt                 *sql.Stmt
-	getMessageSenderStmt               *sql.Stmt
-	getMessageWithSenderStmt           *sql.Stmt
-	getMessagesAfterStmt               *sql.Stmt
-	getMessagesBeforeStmt              *sql.Stmt
-	getMessagesByIDsStmt               *sql.Stmt
-	getMessagesByRoomStmt              *sql.Stmt
-	getMessagesByRoomAscStmt           *sql.Stmt
-	getMessagesByTimeRangeStmt         *sql.Stmt
-	getMessagesByUserStmt              *sql.Stmt
-	getMessagesByUserInRoomStmt        *sql.Stmt
-	getMessagesQuotingThisStmt         *sql.Stmt
-	getMuteRecordByIDStmt              *sql.Stmt
-	getMuteRecordsByMemberStmt         *sql.Stmt
-	getMuteRecordsByRoomStmt           *sql.Stmt
-	getMutedMembersStmt                *sql.Stmt
-	getOnlineChatroomMembersStmt       *sql.Stmt
-	getOnlineUsersStmt                 *sql.Stmt
-	getOperatorStatsStmt               *sql.Stmt
-	getQuotedMessageStmt               *sql.Stmt
-	getUnreadMessageCountStmt          *sql.Stmt
-	getUnreadMessagesStmt              *sql.Stmt
-	getUserByEmailStmt                 *sql.Stmt
-	getUserByIDStmt                    *sql.Stmt
-	getUserByUsernameStmt              *sql.Stmt
-	getUserChatroomMembershipStmt      *sql.Stmt
-	getUserGlobalMuteExpireTimeStmt    *sql.Stmt
-	getUserMuteStatusStmt              *sql.Stmt
-	getUserPublicInfoStmt              *sql.Stmt
-	getUserSystemRoleStmt              *sql.Stmt
-	getUserUnreadCountsInAllRoomsStmt  *sql.Stmt
-	getUsersByIDsStmt                  *sql.Stmt
-	incrementChatroomMemberCountStmt   *sql.Stmt
-	incrementChatroomOnlineCountStmt   *sql.Stmt
-	isChatroomPublicStmt               *sql.Stmt
-	isMemberMutedStmt                  *sql.Stmt
-	isMemberMutedInRoomStmt            *sql.Stmt
-	isMessageSenderStmt                *sql.Stmt
-	isUserAdminStmt                    *sql.Stmt
-	isUserAdminOrOwnerStmt             *sql.Stmt
-	isUserGloballyMutedStmt            *sql.Stmt
-	isUserInChatroomStmt               *sql.Stmt
-	isUserOwnerStmt                    *sql.Stmt
-	joinChatroomStmt                   *sql.Stmt
-	kickMemberStmt                     *sql.Stmt
-	leaveChatroomStmt                  *sql.Stmt
-	listPublicChatroomsStmt            *sql.Stmt
-	listUserChatroomsStmt              *sql.Stmt
-	muteMemberStmt                     *sql.Stmt
-	removeMemberAdminStmt              *sql.Stmt
-	searchChatroomMembersStmt          *sql.Stmt
-	searchChatroomsStmt                *sql.Stmt
-	searchMessagesInRoomStmt           *sql.Stmt
-	searchUsersStmt                    *sql.Stmt
-	setMemberAsAdminStmt               *sql.Stmt
-	setMemberRoleStmt                  *sql.Stmt
-	setUserOfflineStmt                 *sql.Stmt
-	setUserOnlineStmt                  *sql.Stmt
-	setUserSystemRoleStmt              *sql.Stmt
-	suspendUserStmt                    *sql.Stmt
-	syncChatroomMemberCountStmt        *sql.Stmt
-	syncChatroomOnlineCountStmt        *sql.Stmt
-	transferOwnershipStmt              *sql.Stmt
-	unmuteMemberStmt                   *sql.Stmt
-	updateAccountStatusStmt            *sql.Stmt
-	updateChatroomStmt                 *sql.Stmt
-	updateChatroomLastActiveTimeStmt   *sql.Stmt
-	updateMemberLastReadTimeStmt       *sql.Stmt
-	updateMemberLastReadToMessageStmt  *sql.Stmt
-	updateMessageStmt                  *sql.Stmt
-	updateUserStmt                     *sql.Stmt
-	updateUserAvatarStmt               *sql.Stmt
-	updateUserLastLoginStmt            *sql.Stmt
-	updateUserOnlineStatusStmt         *sql.Stmt
-	updateUserPasswordStmt             *sql.Stmt
-	verifyChatroomPasswordStmt         *sql.Stmt
+	db                                       DBTX
+	tx                                       *sql.Tx
+	acceptFriendRequestStmt                  *sql.Stmt
+	activateUserStmt                         *sql.Stmt
+	addMessageReactionStmt                   *sql.Stmt
+	archiveChatroomStmt                      *sql.Stmt
+	blockUserStmt                            *sql.Stmt
+	canUserSendMessageInRoomStmt             *sql.Stmt
+	cancelFriendRequestStmt                  *sql.Stmt
+	checkEmailExistsStmt                     *sql.Stmt
+	checkPhoneExistsStmt                     *sql.Stmt
+	checkUsernameExistsStmt                  *sql.Stmt
+	clearExpiredMutesStmt                    *sql.Stmt
+	clearRoomPasswordAttemptsStmt            *sql.Stmt
+	countAdminLogsStmt                       *sql.Stmt
+	countAdminLogsByOperatorStmt             *sql.Stmt
+	countAdminLogsByRoomStmt                 *sql.Stmt
+	countAdminLogsByTypeStmt                 *sql.Stmt
+	countChatroomMembersStmt                 *sql.Stmt
+	countFriendsStmt                         *sql.Stmt
+	countFriendsByStatusStmt                 *sql.Stmt
+	countLiveUserConnectionsStmt             *sql.Stmt
+	countMessageReactionsByEmojiStmt         *sql.Stmt
+	countMessagesInRoomStmt                  *sql.Stmt
+	countOnlineChatroomMembersStmt           *sql.Stmt
+	countOnlineFriendsStmt                   *sql.Stmt
+	countOnlineUsersStmt                     *sql.Stmt
+	countPendingJoinRequestsStmt             *sql.Stmt
+	countPendingReceivedRequestsStmt         *sql.Stmt
+	countPinnedMessagesStmt                  *sql.Stmt
+	countReceivedFriendRequestsStmt          *sql.Stmt
+	countSearchChatroomMembersStmt           *sql.Stmt
+	countSearchUsersStmt                     *sql.Stmt
+	countSentFriendRequestsStmt              *sql.Stmt
+	countUnreadNotificationsStmt             *sql.Stmt
+	countUnreadNotificationsByTypeStmt       *sql.Stmt
+	countUserChatroomsStmt                   *sql.Stmt
+	countUserMentionsStmt                    *sql.Stmt
+	countUserNotificationsStmt               *sql.Stmt
+	countUserReactionsOnMessageStmt          *sql.Stmt
+	createAdminLogStmt                       *sql.Stmt
+	createBanLogStmt                         *sql.Stmt
+	createChatroomStmt                       *sql.Stmt
+	createChatroomNotificationStmt           *sql.Stmt
+	createDeleteMessageLogStmt               *sql.Stmt
+	createDirectConversationStmt             *sql.Stmt
+	createFriendNotificationStmt             *sql.Stmt
+	createFriendRequestStmt                  *sql.Stmt
+	createFriendshipStmt                     *sql.Stmt
+	createGlobalMuteRecordStmt               *sql.Stmt
+	createJoinRequestStmt                    *sql.Stmt
+	createKickLogStmt                        *sql.Stmt
+	createMessageStmt                        *sql.Stmt
+	createMessageEditStmt                    *sql.Stmt
+	createMessageMentionsStmt                *sql.Stmt
+	createMessageReceiptsStmt                *sql.Stmt
+	createMuteLogStmt                        *sql.Stmt
+	createMuteRecordStmt                     *sql.Stmt
+	createNotificationStmt                   *sql.Stmt
+	createRoleChangeLogStmt                  *sql.Stmt
+	createRoomInviteStmt                     *sql.Stmt
+	createSystemNotificationStmt             *sql.Stmt
+	createThreadReplyReceiptsStmt            *sql.Stmt
+	createUnmuteLogStmt                      *sql.Stmt
+	createUserStmt                           *sql.Stmt
+	createUserSessionStmt                    *sql.Stmt
+	createUserSettingsStmt                   *sql.Stmt
+	createWSConnectionStmt                   *sql.Stmt
+	createWSEventStmt                        *sql.Stmt
+	deactivateGlobalMuteRecordStmt           *sql.Stmt
+	deactivateGlobalMuteRecordByIDStmt       *sql.Stmt
+	deactivateMuteRecordStmt                 *sql.Stmt
+	deactivateMuteRecordByIDStmt             *sql.Stmt
+	decrementChatroomMemberCountStmt         *sql.Stmt
+	decrementChatroomOnlineCountStmt         *sql.Stmt
+	deleteChatroomStmt                       *sql.Stmt
+	deleteExpiredWSEventsStmt                *sql.Stmt
+	deleteFriendshipStmt                     *sql.Stmt
+	deleteMessageStmt                        *sql.Stmt
+	deleteMessageSoftStmt                    *sql.Stmt
+	deleteMessagesByRoomStmt                 *sql.Stmt
+	deleteMessagesByUserStmt                 *sql.Stmt
+	deleteMessagesByUserInRoomStmt           *sql.Stmt
+	deleteNotificationStmt                   *sql.Stmt
+	deleteOldNotificationsStmt               *sql.Stmt
+	deleteReadNotificationsStmt              *sql.Stmt
+	deleteStaleWSConnectionsStmt             *sql.Stmt
+	deleteUserAccountStmt                    *sql.Stmt
+	deleteUserSettingsStmt                   *sql.Stmt
+	deleteWSConnectionStmt                   *sql.Stmt
+	expireGlobalMuteRecordsStmt              *sql.Stmt
+	expireMuteRecordsStmt                    *sql.Stmt
+	followThreadStmt                         *sql.Stmt
+	getActiveGlobalMuteRecordStmt            *sql.Stmt
+	getActiveMembershipStmt                  *sql.Stmt
+	getActiveMuteRecordStmt                  *sql.Stmt
+	getActiveMuteRecordsByRoomStmt           *sql.Stmt
+	getActiveUserSessionStmt                 *sql.Stmt
+	getAdminLogByIDStmt                      *sql.Stmt
+	getAdminLogStatsStmt                     *sql.Stmt
+	getAdminLogsStmt                         *sql.Stmt
+	getAdminLogsByOperatorStmt               *sql.Stmt
+	getAdminLogsByRoomStmt                   *sql.Stmt
+	getAdminLogsByTimeRangeStmt              *sql.Stmt
+	getAdminLogsByTypeStmt                   *sql.Stmt
+	getAdminLogsByUserStmt                   *sql.Stmt
+	getAllActiveGlobalMuteRecordsStmt        *sql.Stmt
+	getBlockedUsersStmt                      *sql.Stmt
+	getChatroomAdminsStmt                    *sql.Stmt
+	getChatroomByIDStmt                      *sql.Stmt
+	getChatroomIncludingArchivedStmt         *sql.Stmt
+	getChatroomMembersStmt                   *sql.Stmt
+	getChatroomOwnerStmt                     *sql.Stmt
+	getChatroomWithoutPasswordStmt           *sql.Stmt
+	getDirectConversationStmt                *sql.Stmt
+	getDirectConversationPeerStmt            *sql.Stmt
+	getFriendRequestByIDStmt                 *sql.Stmt
+	getFriendsStmt                           *sql.Stmt
+	getFriendsByStatusStmt                   *sql.Stmt
+	getGlobalAdminLogsStmt                   *sql.Stmt
+	getGlobalMuteRecordByIDStmt              *sql.Stmt
+	getGlobalMuteRecordsByUserStmt           *sql.Stmt
+	getLastMessageInRoomStmt                 *sql.Stmt
+	getLatestMessagesStmt                    *sql.Stmt
+	getLongestTenuredAdminStmt               *sql.Stmt
+	getMemberByRelIDStmt                     *sql.Stmt
+	getMemberLastReadTimeStmt                *sql.Stmt
+	getMemberMuteExpireTimeStmt              *sql.Stmt
+	getMemberRoleStmt                        *sql.Stmt
+	getMessageByClientMsgIDStmt              *sql.Stmt
+	getMessageByIDStmt                       *sql.Stmt
+	getMessageEditsStmt                      *sql.Stmt
+	getMessageReactionsStmt                  *sql.Stmt
+	getMessageReadByStmt                     *sql.Stmt
+	getMessageReceiptsStmt                   *sql.Stmt
+	getMessageRoomStmt                       *sql.Stmt
+	getMessageSenderStmt                     *sql.Stmt
+	getMessageWithSenderStmt                 *sql.Stmt
+	getMessagesAfterStmt                     *sql.Stmt
+	getMessagesBeforeStmt                    *sql.Stmt
+	getMessagesByIDsStmt                     *sql.Stmt
+	getMessagesByRoomStmt                    *sql.Stmt
+	getMessagesByRoomAscStmt                 *sql.Stmt
+	getMessagesByTimeRangeStmt               *sql.Stmt
+	getMessagesByUserStmt                    *sql.Stmt
+	getMessagesByUserInRoomStmt              *sql.Stmt
+	getMessagesQuotingThisStmt               *sql.Stmt
+	getMuteRecordByIDStmt                    *sql.Stmt
+	getMuteRecordsByMemberStmt               *sql.Stmt
+	getMuteRecordsByRoomStmt                 *sql.Stmt
+	getMutedMembersStmt                      *sql.Stmt
+	getMutualFriendsStmt                     *sql.Stmt
+	getNotificationByIDStmt                  *sql.Stmt
+	getNotificationStatsStmt                 *sql.Stmt
+	getOnlineChatroomMembersStmt             *sql.Stmt
+	getOnlineFriendsStmt                     *sql.Stmt
+	getOnlineUsersStmt                       *sql.Stmt
+	getOperatorStatsStmt                     *sql.Stmt
+	getPendingJoinRequestStmt                *sql.Stmt
+	getPendingReceivedRequestsStmt           *sql.Stmt
+	getPendingRequestBetweenUsersStmt        *sql.Stmt
+	getPinnedMessagesStmt                    *sql.Stmt
+	getQuotedMessageStmt                     *sql.Stmt
+	getReactionSummariesStmt                 *sql.Stmt
+	getReceivedFriendRequestsStmt            *sql.Stmt
+	getRoomInviteStmt                        *sql.Stmt
+	getRoomMessageChangesStmt                *sql.Stmt
+	getSearchableUsersByEmailStmt            *sql.Stmt
+	getSearchableUsersByPhoneStmt            *sql.Stmt
+	getSentFriendRequestsStmt                *sql.Stmt
+	getThreadFollowerIDsStmt                 *sql.Stmt
+	getThreadRepliesStmt                     *sql.Stmt
+	getUnreadMessageCountStmt                *sql.Stmt
+	getUnreadMessagesStmt                    *sql.Stmt
+	getUserByEmailStmt                       *sql.Stmt
+	getUserByIDStmt                          *sql.Stmt
+	getUserByUsernameStmt                    *sql.Stmt
+	getUserChatroomMembershipStmt            *sql.Stmt
+	getUserGlobalMuteExpireTimeStmt          *sql.Stmt
+	getUserMentionsStmt                      *sql.Stmt
+	getUserMuteStatusStmt                    *sql.Stmt
+	getUserNotificationPreferencesStmt       *sql.Stmt
+	getUserNotificationsStmt                 *sql.Stmt
+	getUserPrivacyPreferencesStmt            *sql.Stmt
+	getUserPublicInfoStmt                    *sql.Stmt
+	getUserSessionByIDStmt                   *sql.Stmt
+	getUserSettingsStmt                      *sql.Stmt
+	getUserSystemRoleStmt                    *sql.Stmt
+	getUserUnreadCountsInAllRoomsStmt        *sql.Stmt
+	getUserUnreadMentionCountsInAllRoomsStmt *sql.Stmt
+	getUsersByIDsStmt                        *sql.Stmt
+	getWSEventStmt                           *sql.Stmt
+	handleJoinRequestStmt                    *sql.Stmt
+	incrementChatroomMemberCountStmt         *sql.Stmt
+	incrementChatroomOnlineCountStmt         *sql.Stmt
+	isBlockedBetweenStmt                     *sql.Stmt
+	isChatroomArchivedStmt                   *sql.Stmt
+	isChatroomPublicStmt                     *sql.Stmt
+	isDirectChatroomStmt                     *sql.Stmt
+	isDirectConversationBlockedStmt          *sql.Stmt
+	isFriendStmt                             *sql.Stmt
+	isMemberMutedStmt                        *sql.Stmt
+	isMemberMutedInRoomStmt                  *sql.Stmt
+	isMessageSenderStmt                      *sql.Stmt
+	isThreadFollowerStmt                     *sql.Stmt
+	isUserAdminStmt                          *sql.Stmt
+	isUserAdminOrOwnerStmt                   *sql.Stmt
+	isUserGloballyMutedStmt                  *sql.Stmt
+	isUserInChatroomStmt                     *sql.Stmt
+	isUserOwnerStmt                          *sql.Stmt
+	isUserSearchableByEmailStmt              *sql.Stmt
+	isUserSearchableByPhoneStmt              *sql.Stmt
+	joinChatroomStmt                         *sql.Stmt
+	kickMemberStmt                           *sql.Stmt
+	leaveChatroomStmt                        *sql.Stmt
+	listActiveUserSessionsStmt               *sql.Stmt
+	listOwnedChatroomsStmt                   *sql.Stmt
+	listPendingJoinRequestsStmt              *sql.Stmt
+	listPublicChatroomsStmt                  *sql.Stmt
+	listRoomInvitesStmt                      *sql.Stmt
+	listUserChatroomsStmt                    *sql.Stmt
+	lockRoomPasswordAttemptsStmt             *sql.Stmt
+	lockUserPresenceStmt                     *sql.Stmt
+	markAllNotificationsAsReadStmt           *sql.Stmt
+	markMessageDeliveredStmt                 *sql.Stmt
+	markMessagesReadUpToStmt                 *sql.Stmt
+	markNotificationAsReadStmt               *sql.Stmt
+	markNotificationsByTypeAsReadStmt        *sql.Stmt
+	muteMemberStmt                           *sql.Stmt
+	notifyChannelStmt                        *sql.Stmt
+	pinMessageStmt                           *sql.Stmt
+	redeemRoomInviteStmt                     *sql.Stmt
+	rejectFriendRequestStmt                  *sql.Stmt
+	removeMemberAdminStmt                    *sql.Stmt
+	removeMessageReactionStmt                *sql.Stmt
+	reserveRoomPasswordAttemptStmt           *sql.Stmt
+	revokeAllUserSessionsStmt                *sql.Stmt
+	revokeRoomInviteStmt                     *sql.Stmt
+	revokeUserSessionStmt                    *sql.Stmt
+	rotateSessionRefreshTokenStmt            *sql.Stmt
+	searchChatroomMembersStmt                *sql.Stmt
+	searchChatroomsStmt                      *sql.Stmt
+	searchFriendsStmt                        *sql.Stmt
+	searchMessagesStmt                       *sql.Stmt
+	searchUsersStmt                          *sql.Stmt
+	setChatroomRequireApprovalStmt           *sql.Stmt
+	setMemberAsAdminStmt                     *sql.Stmt
+	setMemberRoleStmt                        *sql.Stmt
+	setUserOfflineStmt                       *sql.Stmt
+	setUserOnlineStmt                        *sql.Stmt
+	setUserSystemRoleStmt                    *sql.Stmt
+	shouldShowOnlineStatusStmt               *sql.Stmt
+	suspendUserStmt                          *sql.Stmt
+	syncChatroomMemberCountStmt              *sql.Stmt
+	syncChatroomOnlineCountStmt              *sql.Stmt
+	syncUserChatroomsOnlineCountStmt         *sql.Stmt
+	touchInstanceConnectionsStmt             *sql.Stmt
+	touchUserSessionStmt                     *sql.Stmt
+	transferOwnershipStmt                    *sql.Stmt
+	unarchiveChatroomStmt                    *sql.Stmt
+	unblockUserStmt                          *sql.Stmt
+	unfollowThreadStmt                       *sql.Stmt
+	unmuteMemberStmt                         *sql.Stmt
+	unpinMessageStmt                         *sql.Stmt
+	updateAccountStatusStmt                  *sql.Stmt
+	updateChatroomStmt                       *sql.Stmt
+	updateChatroomLastActiveTimeStmt         *sql.Stmt
+	updateLanguageSettingsStmt               *sql.Stmt
+	updateMemberLastReadTimeStmt             *sql.Stmt
+	updateMemberLastReadToMessageStmt        *sql.Stmt
+	updateMessageStmt                        *sql.Stmt
+	updateThemeSettingsStmt                  *sql.Stmt
+	updateUserStmt                           *sql.Stmt
+	updateUserAvatarStmt                     *sql.Stmt
+	updateUserLastLoginStmt                  *sql.Stmt
+	updateUserOnlineStatusStmt               *sql.Stmt
+	updateUserPasswordStmt                   *sql.Stmt
+	updateUserSettingsStmt                   *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                 tx,
-		tx:                                 tx,
-		activateUserStmt:                   q.activateUserStmt,
-		archiveChatroomStmt:                q.archiveChatroomStmt,
-		canUserSendMessageInRoomStmt:       q.canUserSendMessageInRoomStmt,
-		checkEmailExistsStmt:               q.checkEmailExistsStmt,
-		checkPhoneExistsStmt:               q.checkPhoneExistsStmt,
-		checkUsernameExistsStmt:            q.checkUsernameExistsStmt,
-		clearExpiredMutesStmt:              q.clearExpiredMutesStmt,
-		countAdminLogsStmt:                 q.countAdminLogsStmt,
-		countAdminLogsByOperatorStmt:       q.countAdminLogsByOperatorStmt,
-		countAdminLogsByRoomStmt:           q.countAdminLogsByRoomStmt,
-		countAdminLogsByTypeStmt:           q.countAdminLogsByTypeStmt,
-		countChatroomMembersStmt:           q.countChatroomMembersStmt,
-		countMessagesInRoomStmt:            q.countMessagesInRoomStmt,
-		countOnlineChatroomMembersStmt:     q.countOnlineChatroomMembersStmt,
-		countOnlineUsersStmt:               q.countOnlineUsersStmt,
-		countSearchChatroomMembersStmt:     q.countSearchChatroomMembersStmt,
-		countSearchUsersStmt:               q.countSearchUsersStmt,
-		countUserChatroomsStmt:             q.countUserChatroomsStmt,
-		createAdminLogStmt:                 q.createAdminLogStmt,
-		createBanLogStmt:                   q.createBanLogStmt,
-		createChatroomStmt:                 q.createChatroomStmt,
-		createDeleteMessageLogStmt:         q.createDeleteMessageLogStmt,
-		createGlobalMuteRecordStmt:         q.createGlobalMuteRecordStmt,
-		createKickLogStmt:                  q.createKickLogStmt,
-		createMessageStmt:                  q.createMessageStmt,
-		createMuteLogStmt:                  q.createMuteLogStmt,
-		createMuteRecordStmt:               q.createMuteRecordStmt,
-		createRoleChangeLogStmt:            q.createRoleChangeLogStmt,
-		createUnmuteLogStmt:                q.createUnmuteLogStmt,
-		createUserStmt:                     q.createUserStmt,
-		deactivateGlobalMuteRecordStmt:     q.deactivateGlobalMuteRecordStmt,
-		deactivateGlobalMuteRecordByIDStmt: q.deactivateGlobalMuteRecordByIDStmt,
-		deactivateMuteRecordStmt:           q.deactivateMuteRecordStmt,
-		deactivateMuteRecordByIDStmt:       q.deactivateMuteRecordByIDStmt,
-		decrementChatroomMemberCountStmt:   q.decrementChatroomMemberCountStmt,
-		decrementChatroomOnlineCountStmt:   q.decrementChatroomOnlineCountStmt,
-		deleteChatroomStmt:                 q.deleteChatroomStmt,
-		deleteMessageStmt:                  q.deleteMessageStmt,
-		deleteMessageSoftStmt:              q.deleteMessageSoftStmt,
-		deleteMessagesByRoomStmt:           q.deleteMessagesByRoomStmt,
-		deleteMessagesByUserStmt:           q.deleteMessagesByUserStmt,
-		deleteMessagesByUserInRoomStmt:     q.deleteMessagesByUserInRoomStmt,
-		deleteUserAccountStmt:              q.deleteUserAccountStmt,
-		expireGlobalMuteRecordsStmt:        q.expireGlobalMuteRecordsStmt,
-		expireMuteRecordsStmt:              q.expireMuteRecordsStmt,
-		getActiveGlobalMuteRecordStmt:      q.getActiveGlobalMuteRecordStmt,
-		getActiveMembershipStmt:            q.getActiveMembershipStmt,
-		getActiveMuteRecordStmt:            q.getActiveMuteRecordStmt,
-		getActiveMuteRecordsByRoomStmt:     q.getActiveMuteRecordsByRoomStmt,
-		getAdminLogByIDStmt:                q.getAdminLogByIDStmt,
-		getAdminLogStatsStmt:               q.getAdminLogStatsStmt,
-		getAdminLogsStmt:                   q.getAdminLogsStmt,
-		getAdminLogsByOperatorStmt:         q.getAdminLogsByOperatorStmt,
-		getAdminLogsByRoomStmt:             q.getAdminLogsByRoomStmt,
-		getAdminLogsByTimeRangeStmt:        q.getAdminLogsByTimeRangeStmt,
-		getAdminLogsByTypeStmt:             q.getAdminLogsByTypeStmt,
-		getAdminLogsByUserStmt:             q.getAdminLogsByUserStmt,
-		getAllActiveGlobalMuteRecordsStmt:  q.getAllActiveGlobalMuteRecordsStmt,
-		getChatroomAdminsStmt:              q.getChatroomAdminsStmt,
-		getChatroomByIDStmt:                q.getChatroomByIDStmt,
-		getChatroomMembersStmt:             q.getChatroomMembersStmt,
-		getChatroomOwnerStmt:               q.getChatroomOwnerStmt,
-		getChatroomWithoutPasswordStmt:     q.getChatroomWithoutPasswordStmt,
-		getGlobalAdminLogsStmt:             q.getGlobalAdminLogsStmt,
-		getGlobalMuteRecordByIDStmt:        q.getGlobalMuteRecordByIDStmt,
-		getGlobalMuteRecordsByUserStmt:     q.getGlobalMuteRecordsByUserStmt,
-		getLastMessageInRoomStmt:           q.getLastMessageInRoomStmt,
-		getLatestMessagesStmt:              q.getLatestMessagesStmt,
-		getMemberByRelIDStmt:               q.getMemberByRelIDStmt,
-		getMemberLastReadTimeStmt:          q.getMemberLastReadTimeStmt,
-		getMemberMuteExpireTimeStmt:        q.getMemberMuteExpireTimeStmt,
-		getMemberRoleStmt:                  q.getMemberRoleStmt,
-		getMessageByIDStmt:                 q.getMessageByIDStmt,
-		getMessageRoomStmt:                 q.getMessageRoomStmt,
-		getMessageSenderStmt:               q.getMessageSenderStmt,
-		getMessageWithSenderStmt:           q.getMessageWithSenderStmt,
-		getMessagesAfterStmt:               q.getMessagesAfterStmt,
-		getMessagesBeforeStmt:              q.getMessagesBeforeStmt,
-		getMessagesByIDsStmt:               q.getMessagesByIDsStmt,
-		getMessagesByRoomStmt:              q.getMessagesByRoomStmt,
-		getMessagesByRoomAscStmt:           q.getMessagesByRoomAscStmt,
-		getMessagesByTimeRangeStmt:         q.getMessagesByTimeRangeStmt,
-		getMessagesByUserStmt:              q.getMessagesByUserStmt,
-		getMessagesByUserInRoomStmt:        q.getMessagesByUserInRoomStmt,
-		getMessagesQuotingThisStmt:         q.getMessagesQuotingThisStmt,
-		getMuteRecordByIDStmt:              q.getMuteRecordByIDStmt,
-		getMuteRecordsByMemberStmt:         q.getMuteRecordsByMemberStmt,
-		getMuteRecordsByRoomStmt:           q.getMuteRecordsByRoomStmt,
-		getMutedMembersStmt:                q.getMutedMembersStmt,
-		getOnlineChatroomMembersStmt:       q.getOnlineChatroomMembersStmt,
-		getOnlineUsersStmt:                 q.getOnlineUsersStmt,
-		getOperatorStatsStmt:               q.getOperatorStatsStmt,
-		getQuotedMessageStmt:               q.getQuotedMessageStmt,
-		getUnreadMessageCountStmt:          q.getUnreadMessageCountStmt,
-		getUnreadMessagesStmt:              q.getUnreadMessagesStmt,
-		getUserByEmailStmt:                 q.getUserByEmailStmt,
-		getUserByIDStmt:                    q.getUserByIDStmt,
-		getUserByUsernameStmt:              q.getUserByUsernameStmt,
-		getUserChatroomMembershipStmt:      q.getUserChatroomMembershipStmt,
-		getUserGlobalMuteExpireTimeStmt:    q.getUserGlobalMuteExpireTimeStmt,
-		getUserMuteStatusStmt:              q.getUserMuteStatusStmt,
-		getUserPublicInfoStmt:              q.getUserPublicInfoStmt,
-		getUserSystemRoleStmt:              q.getUserSystemRoleStmt,
-		getUserUnreadCountsInAllRoomsStmt:  q.getUserUnreadCountsInAllRoomsStmt,
-		getUsersByIDsStmt:                  q.getUsersByIDsStmt,
-		incrementChatroomMemberCountStmt:   q.incrementChatroomMemberCountStmt,
-		incrementChatroomOnlineCountStmt:   q.incrementChatroomOnlineCountStmt,
-		isChatroomPublicStmt:               q.isChatroomPublicStmt,
-		isMemberMutedStmt:                  q.isMemberMutedStmt,
-		isMemberMutedInRoomStmt:            q.isMemberMutedInRoomStmt,
-		isMessageSenderStmt:                q.isMessageSenderStmt,
-		isUserAdminStmt:                    q.isUserAdminStmt,
-		isUserAdminOrOwnerStmt:             q.isUserAdminOrOwnerStmt,
-		isUserGloballyMutedStmt:            q.isUserGloballyMutedStmt,
-		isUserInChatroomStmt:               q.isUserInChatroomStmt,
-		isUserOwnerStmt:                    q.isUserOwnerStmt,
-		joinChatroomStmt:                   q.joinChatroomStmt,
-		kickMemberStmt:                     q.kickMemberStmt,
-		leaveChatroomStmt:                  q.leaveChatroomStmt,
-		listPublicChatroomsStmt:            q.listPublicChatroomsStmt,
-		listUserChatroomsStmt:              q.listUserChatroomsStmt,
-		muteMemberStmt:                     q.muteMemberStmt,
-		removeMemberAdminStmt:              q.removeMemberAdminStmt,
-		searchChatroomMembersStmt:          q.searchChatroomMembersStmt,
-		searchChatroomsStmt:                q.searchChatroomsStmt,
-		searchMessagesInRoomStmt:           q.searchMessagesInRoomStmt,
-		searchUsersStmt:                    q.searchUsersStmt,
-		setMemberAsAdminStmt:               q.setMemberAsAdminStmt,
-		setMemberRoleStmt:                  q.setMemberRoleStmt,
-		setUserOfflineStmt:                 q.setUserOfflineStmt,
-		setUserOnlineStmt:                  q.setUserOnlineStmt,
-		setUserSystemRoleStmt:              q.setUserSystemRoleStmt,
-		suspendUserStmt:                    q.suspendUserStmt,
-		syncChatroomMemberCountStmt:        q.syncChatroomMemberCountStmt,
-		syncChatroomOnlineCountStmt:        q.syncChatroomOnlineCountStmt,
-		transferOwnershipStmt:              q.transferOwnershipStmt,
-		unmuteMemberStmt:                   q.unmuteMemberStmt,
-		updateAccountStatusStmt:            q.updateAccountStatusStmt,
-		updateChatroomStmt:                 q.updateChatroomStmt,
-		updateChatroomLastActiveTimeStmt:   q.updateChatroomLastActiveTimeStmt,
-		updateMemberLastReadTimeStmt:       q.updateMemberLastReadTimeStmt,
-		updateMemberLastReadToMessageStmt:  q.updateMemberLastReadToMessageStmt,
-		updateMessageStmt:                  q.updateMessageStmt,
-		updateUserStmt:                     q.updateUserStmt,
-		updateUserAvatarStmt:               q.updateUserAvatarStmt,
-		updateUserLastLoginStmt:            q.updateUserLastLoginStmt,
-		updateUserOnlineStatusStmt:         q.updateUserOnlineStatusStmt,
-		updateUserPasswordStmt:             q.updateUserPasswordStmt,
-		verifyChatroomPasswordStmt:         q.verifyChatroomPasswordStmt,
+		db:                                       tx,
+		tx:                                       tx,
+		acceptFriendRequestStmt:                  q.acceptFriendRequestStmt,
+		activateUserStmt:                         q.activateUserStmt,
+		addMessageReactionStmt:                   q.addMessageReactionStmt,
+		archiveChatroomStmt:                      q.archiveChatroomStmt,
+		blockUserStmt:                            q.blockUserStmt,
+		canUserSendMessageInRoomStmt:             q.canUserSendMessageInRoomStmt,
+		cancelFriendRequestStmt:                  q.cancelFriendRequestStmt,
+		checkEmailExistsStmt:                     q.checkEmailExistsStmt,
+		checkPhoneExistsStmt:                     q.checkPhoneExistsStmt,
+		checkUsernameExistsStmt:                  q.checkUsernameExistsStmt,
+		clearExpiredMutesStmt:                    q.clearExpiredMutesStmt,
+		clearRoomPasswordAttemptsStmt:            q.clearRoomPasswordAttemptsStmt,
+		countAdminLogsStmt:                       q.countAdminLogsStmt,
+		countAdminLogsByOperatorStmt:             q.countAdminLogsByOperatorStmt,
+		countAdminLogsByRoomStmt:                 q.countAdminLogsByRoomStmt,
+		countAdminLogsByTypeStmt:                 q.countAdminLogsByTypeStmt,
+		countChatroomMembersStmt:                 q.countChatroomMembersStmt,
+		countFriendsStmt:                         q.countFriendsStmt,
+		countFriendsByStatusStmt:                 q.countFriendsByStatusStmt,
+		countLiveUserConnectionsStmt:             q.countLiveUserConnectionsStmt,
+		countMessageReactionsByEmojiStmt:         q.countMessageReactionsByEmojiStmt,
+		countMessagesInRoomStmt:                  q.countMessagesInRoomStmt,
+		countOnlineChatroomMembersStmt:           q.countOnlineChatroomMembersStmt,
+		countOnlineFriendsStmt:                   q.countOnlineFriendsStmt,
+		countOnlineUsersStmt:                     q.countOnlineUsersStmt,
+		countPendingJoinRequestsStmt:             q.countPendingJoinRequestsStmt,
+		countPendingReceivedRequestsStmt:         q.countPendingReceivedRequestsStmt,
+		countPinnedMessagesStmt:                  q.countPinnedMessagesStmt,
+		countReceivedFriendRequestsStmt:          q.countReceivedFriendRequestsStmt,
+		countSearchChatroomMembersStmt:           q.countSearchChatroomMembersStmt,
+		countSearchUsersStmt:                     q.countSearchUsersStmt,
+		countSentFriendRequestsStmt:              q.countSentFriendRequestsStmt,
+		countUnreadNotificationsStmt:             q.countUnreadNotificationsStmt,
+		countUnreadNotificationsByTypeStmt:       q.countUnreadNotificationsByTypeStmt,
+		countUserChatroomsStmt:                   q.countUserChatroomsStmt,
+		countUserMentionsStmt:                    q.countUserMentionsStmt,
+		countUserNotificationsStmt:               q.countUserNotificationsStmt,
+		countUserReactionsOnMessageStmt:          q.countUserReactionsOnMessageStmt,
+		createAdminLogStmt:                       q.createAdminLogStmt,
+		createBanLogStmt:                         q.createBanLogStmt,
+		createChatroomStmt:                       q.createChatroomStmt,
+		createChatroomNotificationStmt:           q.createChatroomNotificationStmt,
+		createDeleteMessageLogStmt:               q.createDeleteMessageLogStmt,
+		createDirectConversationStmt:             q.createDirectConversationStmt,
+		createFriendNotificationStmt:             q.createFriendNotificationStmt,
+		createFriendRequestStmt:                  q.createFriendRequestStmt,
+		createFriendshipStmt:                     q.createFriendshipStmt,
+		createGlobalMuteRecordStmt:               q.createGlobalMuteRecordStmt,
+		createJoinRequestStmt:                    q.createJoinRequestStmt,
+		createKickLogStmt:                        q.createKickLogStmt,
+		createMessageStmt:                        q.createMessageStmt,
+		createMessageEditStmt:                    q.createMessageEditStmt,
+		createMessageMentionsStmt:                q.createMessageMentionsStmt,
+		createMessageReceiptsStmt:                q.createMessageReceiptsStmt,
+		createMuteLogStmt:                        q.createMuteLogStmt,
+		createMuteRecordStmt:                     q.createMuteRecordStmt,
+		createNotificationStmt:                   q.createNotificationStmt,
+		createRoleChangeLogStmt:                  q.createRoleChangeLogStmt,
+		createRoomInviteStmt:                     q.createRoomInviteStmt,
+		createSystemNotificationStmt:             q.createSystemNotificationStmt,
+		createThreadReplyReceiptsStmt:            q.createThreadReplyReceiptsStmt,
+		createUnmuteLogStmt:                      q.createUnmuteLogStmt,
+		createUserStmt:                           q.createUserStmt,
+		createUserSessionStmt:                    q.createUserSessionStmt,
+		createUserSettingsStmt:                   q.createUserSettingsStmt,
+		createWSConnectionStmt:                   q.createWSConnectionStmt,
+		createWSEventStmt:                        q.createWSEventStmt,
+		deactivateGlobalMuteRecordStmt:           q.deactivateGlobalMuteRecordStmt,
+		deactivateGlobalMuteRecordByIDStmt:       q.deactivateGlobalMuteRecordByIDStmt,
+		deactivateMuteRecordStmt:                 q.deactivateMuteRecordStmt,
+		deactivateMuteRecordByIDStmt:             q.deactivateMuteRecordByIDStmt,
+		decrementChatroomMemberCountStmt:         q.decrementChatroomMemberCountStmt,
+		decrementChatroomOnlineCountStmt:         q.decrementChatroomOnlineCountStmt,
+		deleteChatroomStmt:                       q.deleteChatroomStmt,
+		deleteExpiredWSEventsStmt:                q.deleteExpiredWSEventsStmt,
+		deleteFriendshipStmt:                     q.deleteFriendshipStmt,
+		deleteMessageStmt:                        q.deleteMessageStmt,
+		deleteMessageSoftStmt:                    q.deleteMessageSoftStmt,
+		deleteMessagesByRoomStmt:                 q.deleteMessagesByRoomStmt,
+		deleteMessagesByUserStmt:                 q.deleteMessagesByUserStmt,
+		deleteMessagesByUserInRoomStmt:           q.deleteMessagesByUserInRoomStmt,
+		deleteNotificationStmt:                   q.deleteNotificationStmt,
+		deleteOldNotificationsStmt:               q.deleteOldNotificationsStmt,
+		deleteReadNotificationsStmt:              q.deleteReadNotificationsStmt,
+		deleteStaleWSConnectionsStmt:             q.deleteStaleWSConnectionsStmt,
+		deleteUserAccountStmt:                    q.deleteUserAccountStmt,
+		deleteUserSettingsStmt:                   q.deleteUserSettingsStmt,
+		deleteWSConnectionStmt:                   q.deleteWSConnectionStmt,
+		expireGlobalMuteRecordsStmt:              q.expireGlobalMuteRecordsStmt,
+		expireMuteRecordsStmt:                    q.expireMuteRecordsStmt,
+		followThreadStmt:                         q.followThreadStmt,
+		getActiveGlobalMuteRecordStmt:            q.getActiveGlobalMuteRecordStmt,
+		getActiveMembershipStmt:                  q.getActiveMembershipStmt,
+		getActiveMuteRecordStmt:                  q.getActiveMuteRecordStmt,
+		getActiveMuteRecordsByRoomStmt:           q.getActiveMuteRecordsByRoomStmt,
+		getActiveUserSessionStmt:                 q.getActiveUserSessionStmt,
+		getAdminLogByIDStmt:                      q.getAdminLogByIDStmt,
+		getAdminLogStatsStmt:                     q.getAdminLogStatsStmt,
+		getAdminLogsStmt:                         q.getAdminLogsStmt,
+		getAdminLogsByOperatorStmt:               q.getAdminLogsByOperatorStmt,
+		getAdminLogsByRoomStmt:                   q.getAdminLogsByRoomStmt,
+		getAdminLogsByTimeRangeStmt:              q.getAdminLogsByTimeRangeStmt,
+		getAdminLogsByTypeStmt:                   q.getAdminLogsByTypeStmt,
+		getAdminLogsByUserStmt:                   q.getAdminLogsByUserStmt,
+		getAllActiveGlobalMuteRecordsStmt:        q.getAllActiveGlobalMuteRecordsStmt,
+		getBlockedUsersStmt:                      q.getBlockedUsersStmt,
+		getChatroomAdminsStmt:                    q.getChatroomAdminsStmt,
+		getChatroomByIDStmt:                      q.getChatroomByIDStmt,
+		getChatroomIncludingArchivedStmt:         q.getChatroomIncludingArchivedStmt,
+		getChatroomMembersStmt:                   q.getChatroomMembersStmt,
+		getChatroomOwnerStmt:                     q.getChatroomOwnerStmt,
+		getChatroomWithoutPasswordStmt:           q.getChatroomWithoutPasswordStmt,
+		getDirectConversationStmt:                q.getDirectConversationStmt,
+		getDirectConversationPeerStmt:            q.getDirectConversationPeerStmt,
+		getFriendRequestByIDStmt:                 q.getFriendRequestByIDStmt,
+		getFriendsStmt:                           q.getFriendsStmt,
+		getFriendsByStatusStmt:                   q.getFriendsByStatusStmt,
+		getGlobalAdminLogsStmt:                   q.getGlobalAdminLogsStmt,
+		getGlobalMuteRecordByIDStmt:              q.getGlobalMuteRecordByIDStmt,
+		getGlobalMuteRecordsByUserStmt:           q.getGlobalMuteRecordsByUserStmt,
+		getLastMessageInRoomStmt:                 q.getLastMessageInRoomStmt,
+		getLatestMessagesStmt:                    q.getLatestMessagesStmt,
+		getLongestTenuredAdminStmt:               q.getLongestTenuredAdminStmt,
+		getMemberByRelIDStmt:                     q.getMemberByRelIDStmt,
+		getMemberLastReadTimeStmt:                q.getMemberLastReadTimeStmt,
+		getMemberMuteExpireTimeStmt:              q.getMemberMuteExpireTimeStmt,
+		getMemberRoleStmt:                        q.getMemberRoleStmt,
+		getMessageByClientMsgIDStmt:              q.getMessageByClientMsgIDStmt,
+		getMessageByIDStmt:                       q.getMessageByIDStmt,
+		getMessageEditsStmt:                      q.getMessageEditsStmt,
+		getMessageReactionsStmt:                  q.getMessageReactionsStmt,
+		getMessageReadByStmt:                     q.getMessageReadByStmt,
+		getMessageReceiptsStmt:                   q.getMessageReceiptsStmt,
+		getMessageRoomStmt:                       q.getMessageRoomStmt,
+		getMessageSenderStmt:                     q.getMessageSenderStmt,
+		getMessageWithSenderStmt:                 q.getMessageWithSenderStmt,
+		getMessagesAfterStmt:                     q.getMessagesAfterStmt,
+		getMessagesBeforeStmt:                    q.getMessagesBeforeStmt,
+		getMessagesByIDsStmt:                     q.getMessagesByIDsStmt,
+		getMessagesByRoomStmt:                    q.getMessagesByRoomStmt,
+		getMessagesByRoomAscStmt:                 q.getMessagesByRoomAscStmt,
+		getMessagesByTimeRangeStmt:               q.getMessagesByTimeRangeStmt,
+		getMessagesByUserStmt:                    q.getMessagesByUserStmt,
+		getMessagesByUserInRoomStmt:              q.getMessagesByUserInRoomStmt,
+		getMessagesQuotingThisStmt:               q.getMessagesQuotingThisStmt,
+		getMuteRecordByIDStmt:                    q.getMuteRecordByIDStmt,
+		getMuteRecordsByMemberStmt:               q.getMuteRecordsByMemberStmt,
+		getMuteRecordsByRoomStmt:                 q.getMuteRecordsByRoomStmt,
+		getMutedMembersStmt:                      q.getMutedMembersStmt,
+		getMutualFriendsStmt:                     q.getMutualFriendsStmt,
+		getNotificationByIDStmt:                  q.getNotificationByIDStmt,
+		getNotificationStatsStmt:                 q.getNotificationStatsStmt,
+		getOnlineChatroomMembersStmt:             q.getOnlineChatroomMembersStmt,
+		getOnlineFriendsStmt:                     q.getOnlineFriendsStmt,
+		getOnlineUsersStmt:                       q.getOnlineUsersStmt,
+		getOperatorStatsStmt:                     q.getOperatorStatsStmt,
+		getPendingJoinRequestStmt:                q.getPendingJoinRequestStmt,
+		getPendingReceivedRequestsStmt:           q.getPendingReceivedRequestsStmt,
+		getPendingRequestBetweenUsersStmt:        q.getPendingRequestBetweenUsersStmt,
+		getPinnedMessagesStmt:                    q.getPinnedMessagesStmt,
+		getQuotedMessageStmt:                     q.getQuotedMessageStmt,
+		getReactionSummariesStmt:                 q.getReactionSummariesStmt,
+		getReceivedFriendRequestsStmt:            q.getReceivedFriendRequestsStmt,
+		getRoomInviteStmt:                        q.getRoomInviteStmt,
+		getRoomMessageChangesStmt:                q.getRoomMessageChangesStmt,
+		getSearchableUsersByEmailStmt:            q.getSearchableUsersByEmailStmt,
+		getSearchableUsersByPhoneStmt:            q.getSearchableUsersByPhoneStmt,
+		getSentFriendRequestsStmt:                q.getSentFriendRequestsStmt,
+		getThreadFollowerIDsStmt:                 q.getThreadFollowerIDsStmt,
+		getThreadRepliesStmt:                     q.getThreadRepliesStmt,
+		getUnreadMessageCountStmt:                q.getUnreadMessageCountStmt,
+		getUnreadMessagesStmt:                    q.getUnreadMessagesStmt,
+		getUserByEmailStmt:                       q.getUserByEmailStmt,
+		getUserByIDStmt:                          q.getUserByIDStmt,
+		getUserByUsernameStmt:                    q.getUserByUsernameStmt,
+		getUserChatroomMembershipStmt:            q.getUserChatroomMembershipStmt,
+		getUserGlobalMuteExpireTimeStmt:          q.getUserGlobalMuteExpireTimeStmt,
+		getUserMentionsStmt:                      q.getUserMentionsStmt,
+		getUserMuteStatusStmt:                    q.getUserMuteStatusStmt,
+		getUserNotificationPreferencesStmt:       q.getUserNotificationPreferencesStmt,
+		getUserNotificationsStmt:                 q.getUserNotificationsStmt,
+		getUserPrivacyPreferencesStmt:            q.getUserPrivacyPreferencesStmt,
+		getUserPublicInfoStmt:                    q.getUserPublicInfoStmt,
+		getUserSessionByIDStmt:                   q.getUserSessionByIDStmt,
+		getUserSettingsStmt:                      q.getUserSettingsStmt,
+		getUserSystemRoleStmt:                    q.getUserSystemRoleStmt,
+		getUserUnreadCountsInAllRoomsStmt:        q.getUserUnreadCountsInAllRoomsStmt,
+		getUserUnreadMentionCountsInAllRoomsStmt: q.getUserUnreadMentionCountsInAllRoomsStmt,
+		getUsersByIDsStmt:                        q.getUsersByIDsStmt,
+		getWSEventStmt:                           q.getWSEventStmt,
+		handleJoinRequestStmt:                    q.handleJoinRequestStmt,
+		incrementChatroomMemberCountStmt:         q.incrementChatroomMemberCountStmt,
+		incrementChatroomOnlineCountStmt:         q.incrementChatroomOnlineCountStmt,
+		isBlockedBetweenStmt:                     q.isBlockedBetweenStmt,
+		isChatroomArchivedStmt:                   q.isChatroomArchivedStmt,
+		isChatroomPublicStmt:                     q.isChatroomPublicStmt,
+		isDirectChatroomStmt:                     q.isDirectChatroomStmt,
+		isDirectConversationBlockedStmt:          q.isDirectConversationBlockedStmt,
+		isFriendStmt:                             q.isFriendStmt,
+		isMemberMutedStmt:                        q.isMemberMutedStmt,
+		isMemberMutedInRoomStmt:                  q.isMemberMutedInRoomStmt,
+		isMessageSenderStmt:                      q.isMessageSenderStmt,
+		isThreadFollowerStmt:                     q.isThreadFollowerStmt,
+		isUserAdminStmt:                          q.isUserAdminStmt,
+		isUserAdminOrOwnerStmt:                   q.isUserAdminOrOwnerStmt,
+		isUserGloballyMutedStmt:                  q.isUserGloballyMutedStmt,
+		isUserInChatroomStmt:                     q.isUserInChatroomStmt,
+		isUserOwnerStmt:                          q.isUserOwnerStmt,
+		isUserSearchableByEmailStmt:              q.isUserSearchableByEmailStmt,
+		isUserSearchableByPhoneStmt:              q.isUserSearchableByPhoneStmt,
+		joinChatroomStmt:                         q.joinChatroomStmt,
+		kickMemberStmt:                           q.kickMemberStmt,
+		leaveChatroomStmt:                        q.leaveChatroomStmt,
+		listActiveUserSessionsStmt:               q.listActiveUserSessionsStmt,
+		listOwnedChatroomsStmt:                   q.listOwnedChatroomsStmt,
+		listPendingJoinRequestsStmt:              q.listPendingJoinRequestsStmt,
+		listPublicChatroomsStmt:                  q.listPublicChatroomsStmt,
+		listRoomInvitesStmt:                      q.listRoomInvitesStmt,
+		listUserChatroomsStmt:                    q.listUserChatroomsStmt,
+		lockRoomPasswordAttemptsStmt:             q.lockRoomPasswordAttemptsStmt,
+		lockUserPresenceStmt:                     q.lockUserPresenceStmt,
+		markAllNotificationsAsReadStmt:           q.markAllNotificationsAsReadStmt,
+		markMessageDeliveredStmt:                 q.markMessageDeliveredStmt,
+		markMessagesReadUpToStmt:                 q.markMessagesReadUpToStmt,
+		markNotificationAsReadStmt:               q.markNotificationAsReadStmt,
+		markNotificationsByTypeAsReadStmt:        q.markNotificationsByTypeAsReadStmt,
+		muteMemberStmt:                           q.muteMemberStmt,
+		notifyChannelStmt:                        q.notifyChannelStmt,
+		pinMessageStmt:                           q.pinMessageStmt,
+		redeemRoomInviteStmt:                     q.redeemRoomInviteStmt,
+		rejectFriendRequestStmt:                  q.rejectFriendRequestStmt,
+		removeMemberAdminStmt:                    q.removeMemberAdminStmt,
+		removeMessageReactionStmt:                q.removeMessageReactionStmt,
+		reserveRoomPasswordAttemptStmt:           q.reserveRoomPasswordAttemptStmt,
+		revokeAllUserSessionsStmt:                q.revokeAllUserSessionsStmt,
+		revokeRoomInviteStmt:                     q.revokeRoomInviteStmt,
+		revokeUserSessionStmt:                    q.revokeUserSessionStmt,
+		rotateSessionRefreshTokenStmt:            q.rotateSessionRefreshTokenStmt,
+		searchChatroomMembersStmt:                q.searchChatroomMembersStmt,
+		searchChatroomsStmt:                      q.searchChatroomsStmt,
+		searchFriendsStmt:                        q.searchFriendsStmt,
+		searchMessagesStmt:                       q.searchMessagesStmt,
+		searchUsersStmt:                          q.searchUsersStmt,
+		setChatroomRequireApprovalStmt:           q.setChatroomRequireApprovalStmt,
+		setMemberAsAdminStmt:                     q.setMemberAsAdminStmt,
+		setMemberRoleStmt:                        q.setMemberRoleStmt,
+		setUserOfflineStmt:                       q.setUserOfflineStmt,
+		setUserOnlineStmt:                        q.setUserOnlineStmt,
+		setUserSystemRoleStmt:                    q.setUserSystemRoleStmt,
+		shouldShowOnlineStatusStmt:               q.shouldShowOnlineStatusStmt,
+		suspendUserStmt:                          q.suspendUserStmt,
+		syncChatroomMemberCountStmt:              q.syncChatroomMemberCountStmt,
+		syncChatroomOnlineCountStmt:              q.syncChatroomOnlineCountStmt,
+		syncUserChatroomsOnlineCountStmt:         q.syncUserChatroomsOnlineCountStmt,
+		touchInstanceConnectionsStmt:             q.touchInstanceConnectionsStmt,
+		touchUserSessionStmt:                     q.touchUserSessionStmt,
+		transferOwnershipStmt:                    q.transferOwnershipStmt,
+		unarchiveChatroomStmt:                    q.unarchiveChatroomStmt,
+		unblockUserStmt:                          q.unblockUserStmt,
+		unfollowThreadStmt:                       q.unfollowThreadStmt,
+		unmuteMemberStmt:                         q.unmuteMemberStmt,
+		unpinMessageStmt:                         q.unpinMessageStmt,
+		updateAccountStatusStmt:                  q.updateAccountStatusStmt,
+		updateChatroomStmt:                       q.updateChatroomStmt,
+		updateChatroomLastActiveTimeStmt:         q.updateChatroomLastActiveTimeStmt,
+		updateLanguageSettingsStmt:               q.updateLanguageSettingsStmt,
+		updateMemberLastReadTimeStmt:             q.updateMemberLastReadTimeStmt,
+		updateMemberLastReadToMessageStmt:        q.updateMemberLastReadToMessageStmt,
+		updateMessageStmt:                        q.updateMessageStmt,
+		updateThemeSettingsStmt:                  q.updateThemeSettingsStmt,
+		updateUserStmt:                           q.updateUserStmt,
+		updateUserAvatarStmt:                     q.updateUserAvatarStmt,
+		updateUserLastLoginStmt:                  q.updateUserLastLoginStmt,
+		updateUserOnlineStatusStmt:               q.updateUserOnlineStatusStmt,
+		updateUserPasswordStmt:                   q.updateUserPasswordStmt,
+		updateUserSettingsStmt:                   q.updateUserSettingsStmt,
 	}
 }
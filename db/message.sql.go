@@ -17,13 +17,13 @@ const countMessagesInRoom = `-- name: CountMessagesInRoom :one
 
 SELECT COUNT(*) 
 FROM messages 
-WHERE room_id = $1
+WHERE room_id = $1 AND (thread_root_id IS NULL OR also_send_to_room)
 `
 
 // =============================================
 // 3. 消息统计与未读 (Message Statistics)
 // =============================================
-// 统计聊天室消息数量
+// 统计聊天室时间线消息数量
 func (q *Queries) CountMessagesInRoom(ctx context.Context, roomID string) (int64, error) {
 	row := q.queryRow(ctx, q.countMessagesInRoomStmt, countMessagesInRoom, roomID)
 	var count int64
@@ -33,15 +33,18 @@ func (q *Queries) CountMessagesInRoom(ctx context.Context, roomID string) (int64
 
 const createMessage = `-- name: CreateMessage :one
 
-
 INSERT INTO messages (
     content,
     message_type,
     quoted_message_id,
     sender_id,
-    room_id
+    room_id,
+    media_url,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING 
     message_id,
     sent_at,
@@ -49,7 +52,18 @@ INSERT INTO messages (
     message_type,
     quoted_message_id,
     sender_id,
-    room_id
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at
 `
 
 type CreateMessageParams struct {
@@ -58,6 +72,10 @@ type CreateMessageParams struct {
 	QuotedMessageID sql.NullString `json:"quoted_message_id"`
 	SenderID        sql.NullString `json:"sender_id"`
 	RoomID          string         `json:"room_id"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
 }
 
 // =============================================
@@ -75,6 +93,10 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		arg.QuotedMessageID,
 		arg.SenderID,
 		arg.RoomID,
+		arg.MediaUrl,
+		arg.ClientMsgID,
+		arg.ThreadRootID,
+		arg.AlsoSendToRoom,
 	)
 	var i Message
 	err := row.Scan(
@@ -85,6 +107,66 @@ func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (M
 		&i.QuotedMessageID,
 		&i.SenderID,
 		&i.RoomID,
+		&i.EditedAt,
+		&i.EditCount,
+		&i.MediaUrl,
+		&i.Seq,
+		&i.ChangeSeq,
+		&i.DeletedAt,
+		&i.ClientMsgID,
+		&i.ThreadRootID,
+		&i.AlsoSendToRoom,
+		&i.ReplyCount,
+		&i.LastReplyAt,
+	)
+	return i, err
+}
+
+const createMessageEdit = `-- name: CreateMessageEdit :one
+INSERT INTO message_edits (
+    message_id,
+    revision,
+    old_content,
+    new_content,
+    edited_by
+)
+SELECT 
+    message_id,
+    edit_count + 1,
+    content,
+    $1::text,
+    $2
+FROM messages
+WHERE message_id = $3 AND deleted_at IS NULL
+RETURNING 
+    id,
+    message_id,
+    revision,
+    old_content,
+    new_content,
+    edited_by,
+    edited_at
+`
+
+type CreateMessageEditParams struct {
+	NewContent string         `json:"new_content"`
+	EditedBy   sql.NullString `json:"edited_by"`
+	MessageID  string         `json:"message_id"`
+}
+
+// 记录消息修订（须与 UpdateMessage 在同一事务中、且在其之前执行，以保存编辑前内容）
+// 并发编辑同一消息时，修订号唯一约束保证只有一方成功
+func (q *Queries) CreateMessageEdit(ctx context.Context, arg CreateMessageEditParams) (MessageEdit, error) {
+	row := q.queryRow(ctx, q.createMessageEditStmt, createMessageEdit, arg.NewContent, arg.EditedBy, arg.MessageID)
+	var i MessageEdit
+	err := row.Scan(
+		&i.ID,
+		&i.MessageID,
+		&i.Revision,
+		&i.OldContent,
+		&i.NewContent,
+		&i.EditedBy,
+		&i.EditedAt,
 	)
 	return i, err
 }
@@ -104,8 +186,10 @@ const deleteMessageSoft = `-- name: DeleteMessageSoft :one
 UPDATE messages 
 SET 
     content = '该消息已被撤回',
-    message_type = 'system_notification'
-WHERE message_id = $1
+    message_type = 'system_notification',
+    media_url = NULL,
+    deleted_at = NOW()
+WHERE message_id = $1 AND deleted_at IS NULL
 RETURNING 
     message_id,
     sent_at,
@@ -113,10 +197,21 @@ RETURNING
     message_type,
     quoted_message_id,
     sender_id,
-    room_id
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at
 `
 
-// 软删除消息（将内容置为系统消息提示）
+// 软删除消息（将内容置为系统消息提示，已撤回的消息不会重复处理）
 func (q *Queries) DeleteMessageSoft(ctx context.Context, messageID string) (Message, error) {
 	row := q.queryRow(ctx, q.deleteMessageSoftStmt, deleteMessageSoft, messageID)
 	var i Message
@@ -128,6 +223,17 @@ func (q *Queries) DeleteMessageSoft(ctx context.Context, messageID string) (Mess
 		&i.QuotedMessageID,
 		&i.SenderID,
 		&i.RoomID,
+		&i.EditedAt,
+		&i.EditCount,
+		&i.MediaUrl,
+		&i.Seq,
+		&i.ChangeSeq,
+		&i.DeletedAt,
+		&i.ClientMsgID,
+		&i.ThreadRootID,
+		&i.AlsoSendToRoom,
+		&i.ReplyCount,
+		&i.LastReplyAt,
 	)
 	return i, err
 }
@@ -189,6 +295,7 @@ SELECT
 FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at DESC
 LIMIT 1
 `
@@ -234,12 +341,24 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
 FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at DESC
 LIMIT $2
 `
@@ -257,6 +376,17 @@ type GetLatestMessagesRow struct {
 	QuotedMessageID sql.NullString `json:"quoted_message_id"`
 	SenderID        sql.NullString `json:"sender_id"`
 	RoomID          string         `json:"room_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	EditCount       int32          `json:"edit_count"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	Seq             int64          `json:"seq"`
+	ChangeSeq       int64          `json:"change_seq"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
+	ReplyCount      int32          `json:"reply_count"`
+	LastReplyAt     sql.NullTime   `json:"last_reply_at"`
 	Username        sql.NullString `json:"username"`
 	Nickname        sql.NullString `json:"nickname"`
 	AvatarUrl       sql.NullString `json:"avatar_url"`
@@ -280,6 +410,17 @@ func (q *Queries) GetLatestMessages(ctx context.Context, arg GetLatestMessagesPa
 			&i.QuotedMessageID,
 			&i.SenderID,
 			&i.RoomID,
+			&i.EditedAt,
+			&i.EditCount,
+			&i.MediaUrl,
+			&i.Seq,
+			&i.ChangeSeq,
+			&i.DeletedAt,
+			&i.ClientMsgID,
+			&i.ThreadRootID,
+			&i.AlsoSendToRoom,
+			&i.ReplyCount,
+			&i.LastReplyAt,
 			&i.Username,
 			&i.Nickname,
 			&i.AvatarUrl,
@@ -297,6 +438,62 @@ func (q *Queries) GetLatestMessages(ctx context.Context, arg GetLatestMessagesPa
 	return items, nil
 }
 
+const getMessageByClientMsgID = `-- name: GetMessageByClientMsgID :one
+SELECT 
+    message_id,
+    sent_at,
+    content,
+    message_type,
+    quoted_message_id,
+    sender_id,
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at
+FROM messages 
+WHERE sender_id = $1 AND client_msg_id = $2
+`
+
+type GetMessageByClientMsgIDParams struct {
+	SenderID    sql.NullString `json:"sender_id"`
+	ClientMsgID sql.NullString `json:"client_msg_id"`
+}
+
+// 按客户端消息ID获取发送者已发送的消息，用于重试发送时的幂等处理
+func (q *Queries) GetMessageByClientMsgID(ctx context.Context, arg GetMessageByClientMsgIDParams) (Message, error) {
+	row := q.queryRow(ctx, q.getMessageByClientMsgIDStmt, getMessageByClientMsgID, arg.SenderID, arg.ClientMsgID)
+	var i Message
+	err := row.Scan(
+		&i.MessageID,
+		&i.SentAt,
+		&i.Content,
+		&i.MessageType,
+		&i.QuotedMessageID,
+		&i.SenderID,
+		&i.RoomID,
+		&i.EditedAt,
+		&i.EditCount,
+		&i.MediaUrl,
+		&i.Seq,
+		&i.ChangeSeq,
+		&i.DeletedAt,
+		&i.ClientMsgID,
+		&i.ThreadRootID,
+		&i.AlsoSendToRoom,
+		&i.ReplyCount,
+		&i.LastReplyAt,
+	)
+	return i, err
+}
+
 const getMessageByID = `-- name: GetMessageByID :one
 SELECT 
     message_id,
@@ -305,7 +502,18 @@ SELECT
     message_type,
     quoted_message_id,
     sender_id,
-    room_id
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at
 FROM messages 
 WHERE message_id = $1
 `
@@ -322,10 +530,138 @@ func (q *Queries) GetMessageByID(ctx context.Context, messageID string) (Message
 		&i.QuotedMessageID,
 		&i.SenderID,
 		&i.RoomID,
+		&i.EditedAt,
+		&i.EditCount,
+		&i.MediaUrl,
+		&i.Seq,
+		&i.ChangeSeq,
+		&i.DeletedAt,
+		&i.ClientMsgID,
+		&i.ThreadRootID,
+		&i.AlsoSendToRoom,
+		&i.ReplyCount,
+		&i.LastReplyAt,
 	)
 	return i, err
 }
 
+const getMessageEdits = `-- name: GetMessageEdits :many
+SELECT 
+    e.id,
+    e.message_id,
+    e.revision,
+    e.old_content,
+    e.new_content,
+    e.edited_by,
+    e.edited_at,
+    u.username,
+    u.nickname
+FROM message_edits e
+LEFT JOIN users u ON e.edited_by = u.user_id
+WHERE e.message_id = $1
+ORDER BY e.revision ASC
+`
+
+type GetMessageEditsRow struct {
+	ID         int32          `json:"id"`
+	MessageID  string         `json:"message_id"`
+	Revision   int32          `json:"revision"`
+	OldContent string         `json:"old_content"`
+	NewContent string         `json:"new_content"`
+	EditedBy   sql.NullString `json:"edited_by"`
+	EditedAt   time.Time      `json:"edited_at"`
+	Username   sql.NullString `json:"username"`
+	Nickname   sql.NullString `json:"nickname"`
+}
+
+// 获取消息修订历史（按修订号正序）
+func (q *Queries) GetMessageEdits(ctx context.Context, messageID string) ([]GetMessageEditsRow, error) {
+	rows, err := q.query(ctx, q.getMessageEditsStmt, getMessageEdits, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMessageEditsRow{}
+	for rows.Next() {
+		var i GetMessageEditsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MessageID,
+			&i.Revision,
+			&i.OldContent,
+			&i.NewContent,
+			&i.EditedBy,
+			&i.EditedAt,
+			&i.Username,
+			&i.Nickname,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessageReadBy = `-- name: GetMessageReadBy :many
+SELECT 
+    u.user_id,
+    u.username,
+    u.nickname,
+    u.avatar_url,
+    cm.last_read_at
+FROM messages m
+JOIN chatroom_members cm ON cm.room_id = m.room_id AND cm.is_active = true
+JOIN users u ON cm.user_id = u.user_id
+WHERE m.message_id = $1 
+    AND cm.last_read_at >= m.sent_at
+    AND cm.user_id IS DISTINCT FROM m.sender_id
+ORDER BY cm.last_read_at ASC
+`
+
+type GetMessageReadByRow struct {
+	UserID     string         `json:"user_id"`
+	Username   string         `json:"username"`
+	Nickname   sql.NullString `json:"nickname"`
+	AvatarUrl  sql.NullString `json:"avatar_url"`
+	LastReadAt sql.NullTime   `json:"last_read_at"`
+}
+
+// 获取已读指定消息的成员列表（已读位置不早于该消息发送时间，不含发送者本人）
+func (q *Queries) GetMessageReadBy(ctx context.Context, messageID string) ([]GetMessageReadByRow, error) {
+	rows, err := q.query(ctx, q.getMessageReadByStmt, getMessageReadBy, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMessageReadByRow{}
+	for rows.Next() {
+		var i GetMessageReadByRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Username,
+			&i.Nickname,
+			&i.AvatarUrl,
+			&i.LastReadAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMessageRoom = `-- name: GetMessageRoom :one
 SELECT room_id 
 FROM messages 
@@ -363,6 +699,17 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
@@ -379,6 +726,17 @@ type GetMessageWithSenderRow struct {
 	QuotedMessageID sql.NullString `json:"quoted_message_id"`
 	SenderID        sql.NullString `json:"sender_id"`
 	RoomID          string         `json:"room_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	EditCount       int32          `json:"edit_count"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	Seq             int64          `json:"seq"`
+	ChangeSeq       int64          `json:"change_seq"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
+	ReplyCount      int32          `json:"reply_count"`
+	LastReplyAt     sql.NullTime   `json:"last_reply_at"`
 	Username        sql.NullString `json:"username"`
 	Nickname        sql.NullString `json:"nickname"`
 	AvatarUrl       sql.NullString `json:"avatar_url"`
@@ -396,6 +754,17 @@ func (q *Queries) GetMessageWithSender(ctx context.Context, messageID string) (G
 		&i.QuotedMessageID,
 		&i.SenderID,
 		&i.RoomID,
+		&i.EditedAt,
+		&i.EditCount,
+		&i.MediaUrl,
+		&i.Seq,
+		&i.ChangeSeq,
+		&i.DeletedAt,
+		&i.ClientMsgID,
+		&i.ThreadRootID,
+		&i.AlsoSendToRoom,
+		&i.ReplyCount,
+		&i.LastReplyAt,
 		&i.Username,
 		&i.Nickname,
 		&i.AvatarUrl,
@@ -412,6 +781,17 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
@@ -419,6 +799,7 @@ FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1 
     AND m.sent_at > (SELECT sent_at FROM messages WHERE message_id = $2)
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at ASC
 LIMIT $3
 `
@@ -437,6 +818,17 @@ type GetMessagesAfterRow struct {
 	QuotedMessageID sql.NullString `json:"quoted_message_id"`
 	SenderID        sql.NullString `json:"sender_id"`
 	RoomID          string         `json:"room_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	EditCount       int32          `json:"edit_count"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	Seq             int64          `json:"seq"`
+	ChangeSeq       int64          `json:"change_seq"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
+	ReplyCount      int32          `json:"reply_count"`
+	LastReplyAt     sql.NullTime   `json:"last_reply_at"`
 	Username        sql.NullString `json:"username"`
 	Nickname        sql.NullString `json:"nickname"`
 	AvatarUrl       sql.NullString `json:"avatar_url"`
@@ -460,6 +852,17 @@ func (q *Queries) GetMessagesAfter(ctx context.Context, arg GetMessagesAfterPara
 			&i.QuotedMessageID,
 			&i.SenderID,
 			&i.RoomID,
+			&i.EditedAt,
+			&i.EditCount,
+			&i.MediaUrl,
+			&i.Seq,
+			&i.ChangeSeq,
+			&i.DeletedAt,
+			&i.ClientMsgID,
+			&i.ThreadRootID,
+			&i.AlsoSendToRoom,
+			&i.ReplyCount,
+			&i.LastReplyAt,
 			&i.Username,
 			&i.Nickname,
 			&i.AvatarUrl,
@@ -486,6 +889,17 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
@@ -493,6 +907,7 @@ FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1 
     AND m.sent_at < (SELECT sent_at FROM messages WHERE message_id = $2)
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at DESC
 LIMIT $3
 `
@@ -511,6 +926,17 @@ type GetMessagesBeforeRow struct {
 	QuotedMessageID sql.NullString `json:"quoted_message_id"`
 	SenderID        sql.NullString `json:"sender_id"`
 	RoomID          string         `json:"room_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	EditCount       int32          `json:"edit_count"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	Seq             int64          `json:"seq"`
+	ChangeSeq       int64          `json:"change_seq"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
+	ReplyCount      int32          `json:"reply_count"`
+	LastReplyAt     sql.NullTime   `json:"last_reply_at"`
 	Username        sql.NullString `json:"username"`
 	Nickname        sql.NullString `json:"nickname"`
 	AvatarUrl       sql.NullString `json:"avatar_url"`
@@ -534,6 +960,17 @@ func (q *Queries) GetMessagesBefore(ctx context.Context, arg GetMessagesBeforePa
 			&i.QuotedMessageID,
 			&i.SenderID,
 			&i.RoomID,
+			&i.EditedAt,
+			&i.EditCount,
+			&i.MediaUrl,
+			&i.Seq,
+			&i.ChangeSeq,
+			&i.DeletedAt,
+			&i.ClientMsgID,
+			&i.ThreadRootID,
+			&i.AlsoSendToRoom,
+			&i.ReplyCount,
+			&i.LastReplyAt,
 			&i.Username,
 			&i.Nickname,
 			&i.AvatarUrl,
@@ -627,12 +1064,24 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
 FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -651,6 +1100,17 @@ type GetMessagesByRoomRow struct {
 	QuotedMessageID sql.NullString `json:"quoted_message_id"`
 	SenderID        sql.NullString `json:"sender_id"`
 	RoomID          string         `json:"room_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	EditCount       int32          `json:"edit_count"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	Seq             int64          `json:"seq"`
+	ChangeSeq       int64          `json:"change_seq"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
+	ReplyCount      int32          `json:"reply_count"`
+	LastReplyAt     sql.NullTime   `json:"last_reply_at"`
 	Username        sql.NullString `json:"username"`
 	Nickname        sql.NullString `json:"nickname"`
 	AvatarUrl       sql.NullString `json:"avatar_url"`
@@ -660,6 +1120,7 @@ type GetMessagesByRoomRow struct {
 // 2. 消息列表查询 (Message List Queries)
 // =============================================
 // 获取聊天室消息历史 GET /chatrooms/:roomId/messages
+// 聊天室时间线不含仅发送到话题中的回复（下同）
 func (q *Queries) GetMessagesByRoom(ctx context.Context, arg GetMessagesByRoomParams) ([]GetMessagesByRoomRow, error) {
 	rows, err := q.query(ctx, q.getMessagesByRoomStmt, getMessagesByRoom, arg.RoomID, arg.Limit, arg.Offset)
 	if err != nil {
@@ -677,6 +1138,17 @@ func (q *Queries) GetMessagesByRoom(ctx context.Context, arg GetMessagesByRoomPa
 			&i.QuotedMessageID,
 			&i.SenderID,
 			&i.RoomID,
+			&i.EditedAt,
+			&i.EditCount,
+			&i.MediaUrl,
+			&i.Seq,
+			&i.ChangeSeq,
+			&i.DeletedAt,
+			&i.ClientMsgID,
+			&i.ThreadRootID,
+			&i.AlsoSendToRoom,
+			&i.ReplyCount,
+			&i.LastReplyAt,
 			&i.Username,
 			&i.Nickname,
 			&i.AvatarUrl,
@@ -703,12 +1175,24 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
 FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at ASC
 LIMIT $2 OFFSET $3
 `
@@ -727,6 +1211,17 @@ type GetMessagesByRoomAscRow struct {
 	QuotedMessageID sql.NullString `json:"quoted_message_id"`
 	SenderID        sql.NullString `json:"sender_id"`
 	RoomID          string         `json:"room_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	EditCount       int32          `json:"edit_count"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	Seq             int64          `json:"seq"`
+	ChangeSeq       int64          `json:"change_seq"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
+	ReplyCount      int32          `json:"reply_count"`
+	LastReplyAt     sql.NullTime   `json:"last_reply_at"`
 	Username        sql.NullString `json:"username"`
 	Nickname        sql.NullString `json:"nickname"`
 	AvatarUrl       sql.NullString `json:"avatar_url"`
@@ -750,6 +1245,17 @@ func (q *Queries) GetMessagesByRoomAsc(ctx context.Context, arg GetMessagesByRoo
 			&i.QuotedMessageID,
 			&i.SenderID,
 			&i.RoomID,
+			&i.EditedAt,
+			&i.EditCount,
+			&i.MediaUrl,
+			&i.Seq,
+			&i.ChangeSeq,
+			&i.DeletedAt,
+			&i.ClientMsgID,
+			&i.ThreadRootID,
+			&i.AlsoSendToRoom,
+			&i.ReplyCount,
+			&i.LastReplyAt,
 			&i.Username,
 			&i.Nickname,
 			&i.AvatarUrl,
@@ -849,7 +1355,18 @@ SELECT
     m.message_type,
     m.quoted_message_id,
     m.sender_id,
-    m.room_id
+    m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at
 FROM messages m
 WHERE m.sender_id = $1
 ORDER BY m.sent_at DESC
@@ -880,6 +1397,17 @@ func (q *Queries) GetMessagesByUser(ctx context.Context, arg GetMessagesByUserPa
 			&i.QuotedMessageID,
 			&i.SenderID,
 			&i.RoomID,
+			&i.EditedAt,
+			&i.EditCount,
+			&i.MediaUrl,
+			&i.Seq,
+			&i.ChangeSeq,
+			&i.DeletedAt,
+			&i.ClientMsgID,
+			&i.ThreadRootID,
+			&i.AlsoSendToRoom,
+			&i.ReplyCount,
+			&i.LastReplyAt,
 		); err != nil {
 			return nil, err
 		}
@@ -902,7 +1430,18 @@ SELECT
     m.message_type,
     m.quoted_message_id,
     m.sender_id,
-    m.room_id
+    m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at
 FROM messages m
 WHERE m.sender_id = $1 AND m.room_id = $2
 ORDER BY m.sent_at DESC
@@ -939,6 +1478,17 @@ func (q *Queries) GetMessagesByUserInRoom(ctx context.Context, arg GetMessagesBy
 			&i.QuotedMessageID,
 			&i.SenderID,
 			&i.RoomID,
+			&i.EditedAt,
+			&i.EditCount,
+			&i.MediaUrl,
+			&i.Seq,
+			&i.ChangeSeq,
+			&i.DeletedAt,
+			&i.ClientMsgID,
+			&i.ThreadRootID,
+			&i.AlsoSendToRoom,
+			&i.ReplyCount,
+			&i.LastReplyAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1069,12 +1619,234 @@ func (q *Queries) GetQuotedMessage(ctx context.Context, messageID string) (GetQu
 	return i, err
 }
 
+const getRoomMessageChanges = `-- name: GetRoomMessageChanges :many
+
+SELECT 
+    m.message_id,
+    m.sent_at,
+    m.content,
+    m.message_type,
+    m.quoted_message_id,
+    m.sender_id,
+    m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
+    u.username,
+    u.nickname,
+    u.avatar_url
+FROM messages m
+LEFT JOIN users u ON m.sender_id = u.user_id
+WHERE m.room_id = $1 AND m.change_seq > $2
+ORDER BY m.change_seq ASC
+LIMIT $3
+`
+
+type GetRoomMessageChangesParams struct {
+	RoomID    string `json:"room_id"`
+	ChangeSeq int64  `json:"change_seq"`
+	Limit     int64  `json:"limit"`
+}
+
+type GetRoomMessageChangesRow struct {
+	MessageID       string         `json:"message_id"`
+	SentAt          time.Time      `json:"sent_at"`
+	Content         string         `json:"content"`
+	MessageType     MessageType    `json:"message_type"`
+	QuotedMessageID sql.NullString `json:"quoted_message_id"`
+	SenderID        sql.NullString `json:"sender_id"`
+	RoomID          string         `json:"room_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	EditCount       int32          `json:"edit_count"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	Seq             int64          `json:"seq"`
+	ChangeSeq       int64          `json:"change_seq"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
+	ReplyCount      int32          `json:"reply_count"`
+	LastReplyAt     sql.NullTime   `json:"last_reply_at"`
+	Username        sql.NullString `json:"username"`
+	Nickname        sql.NullString `json:"nickname"`
+	AvatarUrl       sql.NullString `json:"avatar_url"`
+}
+
+// =============================================
+// 8. 消息同步 (Message Sync)
+// =============================================
+// 获取聊天室中变更序号大于指定值的消息（新消息、编辑、撤回），按变更顺序返回
+func (q *Queries) GetRoomMessageChanges(ctx context.Context, arg GetRoomMessageChangesParams) ([]GetRoomMessageChangesRow, error) {
+	rows, err := q.query(ctx, q.getRoomMessageChangesStmt, getRoomMessageChanges, arg.RoomID, arg.ChangeSeq, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRoomMessageChangesRow{}
+	for rows.Next() {
+		var i GetRoomMessageChangesRow
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.SentAt,
+			&i.Content,
+			&i.MessageType,
+			&i.QuotedMessageID,
+			&i.SenderID,
+			&i.RoomID,
+			&i.EditedAt,
+			&i.EditCount,
+			&i.MediaUrl,
+			&i.Seq,
+			&i.ChangeSeq,
+			&i.DeletedAt,
+			&i.ClientMsgID,
+			&i.ThreadRootID,
+			&i.AlsoSendToRoom,
+			&i.ReplyCount,
+			&i.LastReplyAt,
+			&i.Username,
+			&i.Nickname,
+			&i.AvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getThreadReplies = `-- name: GetThreadReplies :many
+
+SELECT 
+    m.message_id,
+    m.sent_at,
+    m.content,
+    m.message_type,
+    m.quoted_message_id,
+    m.sender_id,
+    m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
+    u.username,
+    u.nickname,
+    u.avatar_url
+FROM messages m
+LEFT JOIN users u ON m.sender_id = u.user_id
+WHERE m.thread_root_id = $1 AND m.seq > $2
+ORDER BY m.seq ASC
+LIMIT $3
+`
+
+type GetThreadRepliesParams struct {
+	ThreadRootID sql.NullString `json:"thread_root_id"`
+	Seq          int64          `json:"seq"`
+	Limit        int64          `json:"limit"`
+}
+
+type GetThreadRepliesRow struct {
+	MessageID       string         `json:"message_id"`
+	SentAt          time.Time      `json:"sent_at"`
+	Content         string         `json:"content"`
+	MessageType     MessageType    `json:"message_type"`
+	QuotedMessageID sql.NullString `json:"quoted_message_id"`
+	SenderID        sql.NullString `json:"sender_id"`
+	RoomID          string         `json:"room_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	EditCount       int32          `json:"edit_count"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	Seq             int64          `json:"seq"`
+	ChangeSeq       int64          `json:"change_seq"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
+	ReplyCount      int32          `json:"reply_count"`
+	LastReplyAt     sql.NullTime   `json:"last_reply_at"`
+	Username        sql.NullString `json:"username"`
+	Nickname        sql.NullString `json:"nickname"`
+	AvatarUrl       sql.NullString `json:"avatar_url"`
+}
+
+// =============================================
+// 9. 消息话题 (Message Threads)
+// =============================================
+// 获取话题回复（按序号正序，序号大于指定值）GET /chatroom/:roomId/messages/:messageId/thread
+func (q *Queries) GetThreadReplies(ctx context.Context, arg GetThreadRepliesParams) ([]GetThreadRepliesRow, error) {
+	rows, err := q.query(ctx, q.getThreadRepliesStmt, getThreadReplies, arg.ThreadRootID, arg.Seq, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetThreadRepliesRow{}
+	for rows.Next() {
+		var i GetThreadRepliesRow
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.SentAt,
+			&i.Content,
+			&i.MessageType,
+			&i.QuotedMessageID,
+			&i.SenderID,
+			&i.RoomID,
+			&i.EditedAt,
+			&i.EditCount,
+			&i.MediaUrl,
+			&i.Seq,
+			&i.ChangeSeq,
+			&i.DeletedAt,
+			&i.ClientMsgID,
+			&i.ThreadRootID,
+			&i.AlsoSendToRoom,
+			&i.ReplyCount,
+			&i.LastReplyAt,
+			&i.Username,
+			&i.Nickname,
+			&i.AvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUnreadMessageCount = `-- name: GetUnreadMessageCount :one
 SELECT COUNT(*) 
 FROM messages m
 LEFT JOIN chatroom_members cm ON m.room_id = cm.room_id AND cm.user_id = $1
 WHERE m.room_id = $2 
     AND m.sent_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ)
+    AND m.sender_id IS DISTINCT FROM $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 `
 
 type GetUnreadMessageCountParams struct {
@@ -1082,7 +1854,7 @@ type GetUnreadMessageCountParams struct {
 	RoomID string `json:"room_id"`
 }
 
-// 获取未读消息数量
+// 获取未读消息数量（不含自己发送的消息和仅发送到话题中的回复）
 func (q *Queries) GetUnreadMessageCount(ctx context.Context, arg GetUnreadMessageCountParams) (int64, error) {
 	row := q.queryRow(ctx, q.getUnreadMessageCountStmt, getUnreadMessageCount, arg.UserID, arg.RoomID)
 	var count int64
@@ -1107,6 +1879,7 @@ LEFT JOIN users u ON m.sender_id = u.user_id
 LEFT JOIN chatroom_members cm ON m.room_id = cm.room_id AND cm.user_id = $1
 WHERE m.room_id = $2 
     AND m.sent_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ)
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at ASC
 LIMIT $3
 `
@@ -1172,6 +1945,8 @@ SELECT
 FROM chatroom_members cm
 LEFT JOIN messages m ON m.room_id = cm.room_id 
     AND m.sent_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ)
+    AND m.sender_id IS DISTINCT FROM cm.user_id
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 WHERE cm.user_id = $1 AND cm.is_active = true
 GROUP BY cm.room_id
 `
@@ -1181,7 +1956,7 @@ type GetUserUnreadCountsInAllRoomsRow struct {
 	UnreadCount int64  `json:"unread_count"`
 }
 
-// 获取用户在所有聊天室的未读消息数
+// 获取用户在所有聊天室的未读消息数（不含自己发送的消息和仅发送到话题中的回复）
 func (q *Queries) GetUserUnreadCountsInAllRooms(ctx context.Context, userID string) ([]GetUserUnreadCountsInAllRoomsRow, error) {
 	rows, err := q.query(ctx, q.getUserUnreadCountsInAllRoomsStmt, getUserUnreadCountsInAllRooms, userID)
 	if err != nil {
@@ -1191,7 +1966,10 @@ func (q *Queries) GetUserUnreadCountsInAllRooms(ctx context.Context, userID stri
 	items := []GetUserUnreadCountsInAllRoomsRow{}
 	for rows.Next() {
 		var i GetUserUnreadCountsInAllRoomsRow
-		if err := rows.Scan(&i.RoomID, &i.UnreadCount); err != nil {
+		if err := rows.Scan(
+			&i.RoomID,
+			&i.UnreadCount,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1229,76 +2007,139 @@ func (q *Queries) IsMessageSender(ctx context.Context, arg IsMessageSenderParams
 	return is_sender, err
 }
 
-const searchMessagesInRoom = `-- name: SearchMessagesInRoom :many
+const searchMessages = `-- name: SearchMessages :many
 
 SELECT 
-    m.message_id,
-    m.sent_at,
-    m.content,
-    m.message_type,
-    m.quoted_message_id,
-    m.sender_id,
-    m.room_id,
-    u.username,
-    u.nickname,
-    u.avatar_url
-FROM messages m
-LEFT JOIN users u ON m.sender_id = u.user_id
-WHERE m.room_id = $1 
-    AND m.content ILIKE '%' || $2 || '%'
-ORDER BY m.sent_at DESC
-LIMIT $3 OFFSET $4
+    r.message_id,
+    r.sent_at,
+    r.content,
+    r.message_type,
+    r.sender_id,
+    r.room_id,
+    r.seq,
+    r.thread_root_id,
+    r.username,
+    r.nickname,
+    r.avatar_url,
+    r.room_name,
+    r.rank
+FROM (
+    SELECT 
+        m.message_id,
+        m.sent_at,
+        m.content,
+        m.message_type,
+        m.sender_id,
+        m.room_id,
+        m.seq,
+        m.thread_root_id,
+        u.username,
+        u.nickname,
+        u.avatar_url,
+        c.room_name,
+        similarity(m.content, $1::text)::real AS rank
+    FROM messages m
+    JOIN chatroom_members cm ON cm.room_id = m.room_id AND cm.user_id = $2 AND cm.is_active = true
+    JOIN chatrooms c ON c.room_id = m.room_id
+    LEFT JOIN users u ON m.sender_id = u.user_id
+    WHERE m.deleted_at IS NULL
+        AND m.message_type <> 'system_notification'
+        AND m.content ILIKE $3::text
+        AND m.content ILIKE ALL($4::text[])
+        AND message_bigrams(m.content) @> $5::text[]
+        AND ($6::text = '' OR m.room_id = $6::text)
+        AND ($7::text = '' OR m.sender_id = $7::text)
+        AND ($8::text = '' OR m.message_type::text = $8::text)
+        AND ($9::timestamptz IS NULL OR m.sent_at >= $9::timestamptz)
+        AND ($10::timestamptz IS NULL OR m.sent_at < $10::timestamptz)
+) r
+WHERE NOT $11::boolean
+    OR (r.rank, r.sent_at, r.message_id) < ($12::real, $13::timestamptz, $14::text)
+ORDER BY r.rank DESC, r.sent_at DESC, r.message_id DESC
+LIMIT $15
 `
 
-type SearchMessagesInRoomParams struct {
-	RoomID  string         `json:"room_id"`
-	Column2 sql.NullString `json:"column_2"`
-	Limit   int64          `json:"limit"`
-	Offset  int64          `json:"offset"`
+type SearchMessagesParams struct {
+	Query           string       `json:"query"`
+	UserID          string       `json:"user_id"`
+	Pattern         string       `json:"pattern"`
+	TermPatterns    []string     `json:"term_patterns"`
+	Bigrams         []string     `json:"bigrams"`
+	RoomID          string       `json:"room_id"`
+	SenderID        string       `json:"sender_id"`
+	MessageType     string       `json:"message_type"`
+	FromTime        sql.NullTime `json:"from_time"`
+	ToTime          sql.NullTime `json:"to_time"`
+	HasCursor       bool         `json:"has_cursor"`
+	CursorRank      float32      `json:"cursor_rank"`
+	CursorSentAt    time.Time    `json:"cursor_sent_at"`
+	CursorMessageID string       `json:"cursor_message_id"`
+	Limit           int64        `json:"limit"`
 }
 
-type SearchMessagesInRoomRow struct {
-	MessageID       string         `json:"message_id"`
-	SentAt          time.Time      `json:"sent_at"`
-	Content         string         `json:"content"`
-	MessageType     MessageType    `json:"message_type"`
-	QuotedMessageID sql.NullString `json:"quoted_message_id"`
-	SenderID        sql.NullString `json:"sender_id"`
-	RoomID          string         `json:"room_id"`
-	Username        sql.NullString `json:"username"`
-	Nickname        sql.NullString `json:"nickname"`
-	AvatarUrl       sql.NullString `json:"avatar_url"`
+type SearchMessagesRow struct {
+	MessageID    string         `json:"message_id"`
+	SentAt       time.Time      `json:"sent_at"`
+	Content      string         `json:"content"`
+	MessageType  MessageType    `json:"message_type"`
+	SenderID     sql.NullString `json:"sender_id"`
+	RoomID       string         `json:"room_id"`
+	Seq          int64          `json:"seq"`
+	ThreadRootID sql.NullString `json:"thread_root_id"`
+	Username     sql.NullString `json:"username"`
+	Nickname     sql.NullString `json:"nickname"`
+	AvatarUrl    sql.NullString `json:"avatar_url"`
+	RoomName     string         `json:"room_name"`
+	Rank         float32        `json:"rank"`
 }
 
 // =============================================
 // 4. 消息搜索 (Message Search)
 // =============================================
-// 在聊天室中搜索消息
-func (q *Queries) SearchMessagesInRoom(ctx context.Context, arg SearchMessagesInRoomParams) ([]SearchMessagesInRoomRow, error) {
-	rows, err := q.query(ctx, q.searchMessagesInRoomStmt, searchMessagesInRoom,
+// 在用户所在的所有聊天室中搜索消息 GET /chatroom/search
+// pattern 为最长关键词的 ILIKE 模式（使用三元组索引），term_patterns 为全部关键词的模式（须全部匹配），
+// bigrams 为全部关键词的二元组（使用二元组索引，支持少于 3 个字符的关键词）；
+// 按与查询的三元组相似度、发送时间倒序排列，has_cursor 为 true 时返回游标之后的结果。
+// room_id / sender_id / message_type 为空时不过滤，from_time / to_time 为 NULL 时不限
+func (q *Queries) SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error) {
+	rows, err := q.query(ctx, q.searchMessagesStmt, searchMessages,
+		arg.Query,
+		arg.UserID,
+		arg.Pattern,
+		pq.Array(arg.TermPatterns),
+		pq.Array(arg.Bigrams),
 		arg.RoomID,
-		arg.Column2,
+		arg.SenderID,
+		arg.MessageType,
+		arg.FromTime,
+		arg.ToTime,
+		arg.HasCursor,
+		arg.CursorRank,
+		arg.CursorSentAt,
+		arg.CursorMessageID,
 		arg.Limit,
-		arg.Offset,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []SearchMessagesInRoomRow{}
+	items := []SearchMessagesRow{}
 	for rows.Next() {
-		var i SearchMessagesInRoomRow
+		var i SearchMessagesRow
 		if err := rows.Scan(
 			&i.MessageID,
 			&i.SentAt,
 			&i.Content,
 			&i.MessageType,
-			&i.QuotedMessageID,
 			&i.SenderID,
 			&i.RoomID,
+			&i.Seq,
+			&i.ThreadRootID,
 			&i.Username,
 			&i.Nickname,
 			&i.AvatarUrl,
+			&i.RoomName,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -1317,8 +2158,9 @@ const updateMessage = `-- name: UpdateMessage :one
 UPDATE messages 
 SET 
     content = $2,
-    sent_at = NOW()
-WHERE message_id = $1
+    edited_at = NOW(),
+    edit_count = edit_count + 1
+WHERE message_id = $1 AND deleted_at IS NULL
 RETURNING 
     message_id,
     sent_at,
@@ -1326,7 +2168,18 @@ RETURNING
     message_type,
     quoted_message_id,
     sender_id,
-    room_id
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at
 `
 
 type UpdateMessageParams struct {
@@ -1334,7 +2187,8 @@ type UpdateMessageParams struct {
 	Content   string `json:"content"`
 }
 
-// 编辑消息 PUT /chatrooms/:roomId/messages/:messageId
+// 编辑消息 POST /chatrooms/:roomId/messages/:messageId/edit
+// 发送时间保持不变，记录最后编辑时间并递增编辑次数；已撤回的消息不会被更新
 func (q *Queries) UpdateMessage(ctx context.Context, arg UpdateMessageParams) (Message, error) {
 	row := q.queryRow(ctx, q.updateMessageStmt, updateMessage, arg.MessageID, arg.Content)
 	var i Message
@@ -1346,6 +2200,17 @@ func (q *Queries) UpdateMessage(ctx context.Context, arg UpdateMessageParams) (M
 		&i.QuotedMessageID,
 		&i.SenderID,
 		&i.RoomID,
+		&i.EditedAt,
+		&i.EditCount,
+		&i.MediaUrl,
+		&i.Seq,
+		&i.ChangeSeq,
+		&i.DeletedAt,
+		&i.ClientMsgID,
+		&i.ThreadRootID,
+		&i.AlsoSendToRoom,
+		&i.ReplyCount,
+		&i.LastReplyAt,
 	)
 	return i, err
 }
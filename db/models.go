@@ -7,6 +7,7 @@ package sqlcdb
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -62,6 +63,7 @@ const (
 	ChatroomTypePublic            ChatroomType = "public"
 	ChatroomTypePrivatePassword   ChatroomType = "private_password"
 	ChatroomTypePrivateInviteOnly ChatroomType = "private_invite_only"
+	ChatroomTypeDirect            ChatroomType = "direct"
 )
 
 func (e *ChatroomType) Scan(src interface{}) error {
@@ -184,6 +186,49 @@ func (ns NullMemberRole) Value() (driver.Value, error) {
 	return string(ns.MemberRole), nil
 }
 
+type MentionType string
+
+const (
+	MentionTypeUser   MentionType = "user"
+	MentionTypeAll    MentionType = "all"
+	MentionTypeAdmins MentionType = "admins"
+)
+
+func (e *MentionType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MentionType(s)
+	case string:
+		*e = MentionType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MentionType: %T", src)
+	}
+	return nil
+}
+
+type NullMentionType struct {
+	MentionType MentionType `json:"mention_type"`
+	Valid       bool        `json:"valid"` // Valid is true if MentionType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMentionType) Scan(value interface{}) error {
+	if value == nil {
+		ns.MentionType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MentionType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMentionType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MentionType), nil
+}
+
 type MessageStatus string
 
 const (
@@ -416,17 +461,18 @@ type AdminLog struct {
 }
 
 type Chatroom struct {
-	RoomID         string         `json:"room_id"`
-	RoomName       string         `json:"room_name"`
-	Description    sql.NullString `json:"description"`
-	IconUrl        sql.NullString `json:"icon_url"`
-	RoomType       ChatroomType   `json:"room_type"`
-	AccessPassword sql.NullString `json:"access_password"`
-	MemberCount    int32          `json:"member_count"`
-	OnlineCount    int32          `json:"online_count"`
-	RoomStatus     ChatroomStatus `json:"room_status"`
-	CreatedAt      time.Time      `json:"created_at"`
-	LastActiveAt   sql.NullTime   `json:"last_active_at"`
+	RoomID          string         `json:"room_id"`
+	RoomName        string         `json:"room_name"`
+	Description     sql.NullString `json:"description"`
+	IconUrl         sql.NullString `json:"icon_url"`
+	RoomType        ChatroomType   `json:"room_type"`
+	AccessPassword  sql.NullString `json:"access_password"`
+	MemberCount     int32          `json:"member_count"`
+	OnlineCount     int32          `json:"online_count"`
+	RoomStatus      ChatroomStatus `json:"room_status"`
+	CreatedAt       time.Time      `json:"created_at"`
+	LastActiveAt    sql.NullTime   `json:"last_active_at"`
+	RequireApproval bool           `json:"require_approval"`
 }
 
 type ChatroomMember struct {
@@ -440,6 +486,36 @@ type ChatroomMember struct {
 	MuteStatus    MemberMuteStatus `json:"mute_status"`
 	MuteExpiresAt sql.NullTime     `json:"mute_expires_at"`
 	IsActive      bool             `json:"is_active"`
+	InvitedBy     sql.NullString   `json:"invited_by"`
+}
+
+type ChatroomMessageSeq struct {
+	RoomID  string `json:"room_id"`
+	LastSeq int64  `json:"last_seq"`
+}
+
+type DirectConversation struct {
+	RoomID    string    `json:"room_id"`
+	UserLow   string    `json:"user_low"`
+	UserHigh  string    `json:"user_high"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Friend struct {
+	ID          int32     `json:"id"`
+	UserID      string    `json:"user_id"`
+	FriendID    string    `json:"friend_id"`
+	FriendSince time.Time `json:"friend_since"`
+}
+
+type FriendRequest struct {
+	RequestID  string         `json:"request_id"`
+	SenderID   string         `json:"sender_id"`
+	ReceiverID string         `json:"receiver_id"`
+	Message    sql.NullString `json:"message"`
+	Status     string         `json:"status"`
+	CreatedAt  time.Time      `json:"created_at"`
+	HandledAt  sql.NullTime   `json:"handled_at"`
 }
 
 type GlobalMuteRecord struct {
@@ -460,6 +536,56 @@ type Message struct {
 	QuotedMessageID sql.NullString `json:"quoted_message_id"`
 	SenderID        sql.NullString `json:"sender_id"`
 	RoomID          string         `json:"room_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	EditCount       int32          `json:"edit_count"`
+	MediaUrl        sql.NullString `json:"media_url"`
+	Seq             int64          `json:"seq"`
+	ChangeSeq       int64          `json:"change_seq"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	ClientMsgID     sql.NullString `json:"client_msg_id"`
+	ThreadRootID    sql.NullString `json:"thread_root_id"`
+	AlsoSendToRoom  bool           `json:"also_send_to_room"`
+	ReplyCount      int32          `json:"reply_count"`
+	LastReplyAt     sql.NullTime   `json:"last_reply_at"`
+}
+
+type MessageEdit struct {
+	ID         int32          `json:"id"`
+	MessageID  string         `json:"message_id"`
+	Revision   int32          `json:"revision"`
+	OldContent string         `json:"old_content"`
+	NewContent string         `json:"new_content"`
+	EditedBy   sql.NullString `json:"edited_by"`
+	EditedAt   time.Time      `json:"edited_at"`
+}
+
+type MessageMention struct {
+	MessageID   string      `json:"message_id"`
+	UserID      string      `json:"user_id"`
+	RoomID      string      `json:"room_id"`
+	MentionType MentionType `json:"mention_type"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+type MessageReaction struct {
+	MessageID string    `json:"message_id"`
+	UserID    string    `json:"user_id"`
+	Emoji     string    `json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MessageReceipt struct {
+	MessageID   string        `json:"message_id"`
+	UserID      string        `json:"user_id"`
+	Status      MessageStatus `json:"status"`
+	DeliveredAt sql.NullTime  `json:"delivered_at"`
+	ReadAt      sql.NullTime  `json:"read_at"`
+}
+
+type MessageThreadFollower struct {
+	RootMessageID string    `json:"root_message_id"`
+	UserID        string    `json:"user_id"`
+	FollowedAt    time.Time `json:"followed_at"`
 }
 
 type MuteRecord struct {
@@ -472,6 +598,56 @@ type MuteRecord struct {
 	AdminID      sql.NullString `json:"admin_id"`
 }
 
+type Notification struct {
+	NotificationID   string          `json:"notification_id"`
+	ReceiverID       string          `json:"receiver_id"`
+	NotificationType string          `json:"notification_type"`
+	Title            string          `json:"title"`
+	Content          string          `json:"content"`
+	Data             json.RawMessage `json:"data"`
+	IsRead           bool            `json:"is_read"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+type PinnedMessage struct {
+	MessageID string         `json:"message_id"`
+	RoomID    string         `json:"room_id"`
+	PinnedBy  sql.NullString `json:"pinned_by"`
+	PinnedAt  time.Time      `json:"pinned_at"`
+}
+
+type RoomInvite struct {
+	InviteCode string         `json:"invite_code"`
+	RoomID     string         `json:"room_id"`
+	CreatedBy  sql.NullString `json:"created_by"`
+	MemberRole MemberRole     `json:"member_role"`
+	MaxUses    sql.NullInt32  `json:"max_uses"`
+	UseCount   int32          `json:"use_count"`
+	ExpiresAt  sql.NullTime   `json:"expires_at"`
+	RevokedAt  sql.NullTime   `json:"revoked_at"`
+	RevokedBy  sql.NullString `json:"revoked_by"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type RoomJoinRequest struct {
+	RequestID string         `json:"request_id"`
+	RoomID    string         `json:"room_id"`
+	UserID    string         `json:"user_id"`
+	Message   sql.NullString `json:"message"`
+	Status    string         `json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	HandledBy sql.NullString `json:"handled_by"`
+	HandledAt sql.NullTime   `json:"handled_at"`
+}
+
+type RoomPasswordAttempt struct {
+	RoomID          string       `json:"room_id"`
+	UserID          string       `json:"user_id"`
+	FailedCount     int32        `json:"failed_count"`
+	WindowStartedAt time.Time    `json:"window_started_at"`
+	LockedUntil     sql.NullTime `json:"locked_until"`
+}
+
 type User struct {
 	UserID         string                `json:"user_id"`
 	Username       string                `json:"username"`
@@ -487,3 +663,51 @@ type User struct {
 	RegisteredAt   time.Time             `json:"registered_at"`
 	LastLoginAt    sql.NullTime          `json:"last_login_at"`
 }
+
+type UserBlock struct {
+	BlockerID string    `json:"blocker_id"`
+	BlockedID string    `json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserSession struct {
+	SessionID    string         `json:"session_id"`
+	UserID       string         `json:"user_id"`
+	Device       sql.NullString `json:"device"`
+	UserAgent    sql.NullString `json:"user_agent"`
+	IpAddress    sql.NullString `json:"ip_address"`
+	CreatedAt    time.Time      `json:"created_at"`
+	LastActiveAt time.Time      `json:"last_active_at"`
+	ExpiresAt    time.Time      `json:"expires_at"`
+	RevokedAt    sql.NullTime   `json:"revoked_at"`
+	RefreshJti   string         `json:"refresh_jti"`
+}
+
+type UserSetting struct {
+	UserID                    string    `json:"user_id"`
+	EnableFriendRequest       bool      `json:"enable_friend_request"`
+	EnableChatroomMessage     bool      `json:"enable_chatroom_message"`
+	EnableSystemNotice        bool      `json:"enable_system_notice"`
+	EnableSound               bool      `json:"enable_sound"`
+	EnableDesktopNotification bool      `json:"enable_desktop_notification"`
+	AllowSearchByPhone        bool      `json:"allow_search_by_phone"`
+	AllowSearchByEmail        bool      `json:"allow_search_by_email"`
+	ShowOnlineStatus          bool      `json:"show_online_status"`
+	Theme                     string    `json:"theme"`
+	Language                  string    `json:"language"`
+	UpdatedAt                 time.Time `json:"updated_at"`
+}
+
+type WsConnection struct {
+	ConnID      string    `json:"conn_id"`
+	UserID      string    `json:"user_id"`
+	InstanceID  string    `json:"instance_id"`
+	ConnectedAt time.Time `json:"connected_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+type WsEvent struct {
+	EventID   int64     `json:"event_id"`
+	Payload   string    `json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -11,15 +11,33 @@ import (
 )
 
 type Querier interface {
+	// 接受好友请求 POST /friends/request/:requestId/handle
+	AcceptFriendRequest(ctx context.Context, arg AcceptFriendRequestParams) (FriendRequest, error)
 	// 激活用户账号
 	ActivateUser(ctx context.Context, userID string) error
-	// 归档聊天室
-	ArchiveChatroom(ctx context.Context, roomID string) error
+	// =============================================
+	// 消息表情回应相关SQL查询 (Message Reaction Queries)
+	// 对应API: 消息表情回应接口
+	// 表结构见 migration 000013_message_reactions
+	// =============================================
+	// 添加表情回应，已存在时不做处理（返回 0）
+	AddMessageReaction(ctx context.Context, arg AddMessageReactionParams) (int64, error)
+	// 归档聊天室（只读），仅对正常状态的聊天室生效
+	ArchiveChatroom(ctx context.Context, roomID string) (int64, error)
+	// =============================================
+	// 用户屏蔽相关SQL查询 (User Block Queries)
+	// 对应API: POST /users/:userid/block, POST /users/:userid/unblock, GET /users/me/blocks
+	// 表结构见 migration 000018_direct_conversations
+	// =============================================
+	// 屏蔽用户，已屏蔽时不做处理（返回 0）
+	BlockUser(ctx context.Context, arg BlockUserParams) (int64, error)
 	// =============================================
 	// 3. 综合禁言检查 (Combined Mute Checks)
 	// =============================================
 	// 检查用户是否可以在聊天室发送消息（综合检查全局禁言和聊天室禁言）
 	CanUserSendMessageInRoom(ctx context.Context, arg CanUserSendMessageInRoomParams) (sql.NullBool, error)
+	// 取消好友请求
+	CancelFriendRequest(ctx context.Context, arg CancelFriendRequestParams) error
 	// 检查邮箱是否已存在
 	CheckEmailExists(ctx context.Context, email sql.NullString) (bool, error)
 	// 检查手机号是否已存在
@@ -28,6 +46,8 @@ type Querier interface {
 	CheckUsernameExists(ctx context.Context, username string) (bool, error)
 	// 清除过期的禁言
 	ClearExpiredMutes(ctx context.Context) error
+	// 密码验证成功后清除错误记录
+	ClearRoomPasswordAttempts(ctx context.Context, arg ClearRoomPasswordAttemptsParams) error
 	// =============================================
 	// 3. 日志统计 (Log Statistics)
 	// =============================================
@@ -41,21 +61,54 @@ type Querier interface {
 	CountAdminLogsByType(ctx context.Context, operationType string) (int64, error)
 	// 统计聊天室成员数量
 	CountChatroomMembers(ctx context.Context, roomID string) (int64, error)
+	// 统计好友数量
+	CountFriends(ctx context.Context, userID string) (int64, error)
+	// 按在线状态统计好友数量
+	CountFriendsByStatus(ctx context.Context, arg CountFriendsByStatusParams) (int64, error)
+	// 统计用户在所有实例上的有效连接数（超过 timeout_seconds 未刷新的视为已失效）
+	CountLiveUserConnections(ctx context.Context, arg CountLiveUserConnectionsParams) (int64, error)
+	// 统计消息某个表情的回应数
+	CountMessageReactionsByEmoji(ctx context.Context, arg CountMessageReactionsByEmojiParams) (int64, error)
 	// =============================================
 	// 3. 消息统计与未读 (Message Statistics)
 	// =============================================
-	// 统计聊天室消息数量
+	// 统计聊天室时间线消息数量
 	CountMessagesInRoom(ctx context.Context, roomID string) (int64, error)
 	// 统计聊天室在线成员数量
 	CountOnlineChatroomMembers(ctx context.Context, roomID string) (int64, error)
+	// 统计在线好友数量
+	CountOnlineFriends(ctx context.Context, userID string) (int64, error)
 	// 统计在线用户数
 	CountOnlineUsers(ctx context.Context) (int64, error)
+	// 统计聊天室的待处理申请数
+	CountPendingJoinRequests(ctx context.Context, roomID string) (int64, error)
+	// 统计待处理的收到的好友请求数量
+	CountPendingReceivedRequests(ctx context.Context, receiverID string) (int64, error)
+	// 统计聊天室置顶消息数量（不含已撤回的消息）
+	CountPinnedMessages(ctx context.Context, roomID string) (int64, error)
+	// 统计收到的好友请求数量（status 为空时不过滤）
+	CountReceivedFriendRequests(ctx context.Context, arg CountReceivedFriendRequestsParams) (int64, error)
 	// 统计搜索结果数量
 	CountSearchChatroomMembers(ctx context.Context, arg CountSearchChatroomMembersParams) (int64, error)
-	// 搜索用户计数
-	CountSearchUsers(ctx context.Context, dollar_1 sql.NullString) (int64, error)
+	// 搜索用户计数（匹配规则同 SearchUsers）
+	CountSearchUsers(ctx context.Context, keyword string) (int64, error)
+	// 统计发送的好友请求数量（status 为空时不过滤）
+	CountSentFriendRequests(ctx context.Context, arg CountSentFriendRequestsParams) (int64, error)
+	// 统计未读通知数量
+	CountUnreadNotifications(ctx context.Context, receiverID string) (int64, error)
+	// 按类型统计未读通知数量
+	CountUnreadNotificationsByType(ctx context.Context, arg CountUnreadNotificationsByTypeParams) (int64, error)
 	// 统计用户加入的聊天室数量
 	CountUserChatrooms(ctx context.Context, userID string) (int64, error)
+	// 统计提及当前用户的消息数（过滤条件同 GetUserMentions）
+	CountUserMentions(ctx context.Context, arg CountUserMentionsParams) (int64, error)
+	// =============================================
+	// 4. 通知统计 (Notification Statistics)
+	// =============================================
+	// 统计用户通知总数（过滤条件同 GetUserNotifications）
+	CountUserNotifications(ctx context.Context, arg CountUserNotificationsParams) (int64, error)
+	// 统计用户对消息使用的不同表情数
+	CountUserReactionsOnMessage(ctx context.Context, arg CountUserReactionsOnMessageParams) (int64, error)
 	// =============================================
 	// 管理操作日志相关SQL查询 (Admin Log Queries)
 	// 对应API: 系统管理接口
@@ -76,13 +129,46 @@ type Querier interface {
 	// =============================================
 	// 创建聊天室 POST /chatrooms
 	CreateChatroom(ctx context.Context, arg CreateChatroomParams) (Chatroom, error)
+	// 创建聊天室相关通知
+	CreateChatroomNotification(ctx context.Context, arg CreateChatroomNotificationParams) (Notification, error)
 	// 创建删除消息操作日志
 	CreateDeleteMessageLog(ctx context.Context, arg CreateDeleteMessageLogParams) (AdminLog, error)
 	// =============================================
+	// 私聊会话相关SQL查询 (Direct Conversation Queries)
+	// 对应API: POST /users/:userid/dm
+	// 表结构见 migration 000018_direct_conversations
+	// =============================================
+	// 记录私聊会话，两名成员已有会话时不做处理（返回 0）
+	CreateDirectConversation(ctx context.Context, arg CreateDirectConversationParams) (int64, error)
+	// 创建好友相关通知
+	CreateFriendNotification(ctx context.Context, arg CreateFriendNotificationParams) (Notification, error)
+	// =============================================
+	// 好友关系相关SQL查询 (Friend Queries)
+	// 对应API: 好友关系接口
+	// 表结构见 migration 000005_friends
+	// =============================================
+	// =============================================
+	// 1. 好友请求操作 (Friend Request Operations)
+	// =============================================
+	// 发送好友请求 POST /friends/request
+	CreateFriendRequest(ctx context.Context, arg CreateFriendRequestParams) (FriendRequest, error)
+	// =============================================
+	// 3. 好友关系操作 (Friendship Operations)
+	// =============================================
+	// 创建好友关系（双向）
+	CreateFriendship(ctx context.Context, arg CreateFriendshipParams) error
+	// =============================================
 	// 2. 全局禁言记录 (Global Mute Records)
 	// =============================================
 	// 创建全局禁言记录（超级管理员操作）
 	CreateGlobalMuteRecord(ctx context.Context, arg CreateGlobalMuteRecordParams) (GlobalMuteRecord, error)
+	// =============================================
+	// 加入申请相关SQL查询 (Join Request Queries)
+	// 对应API: POST /chatroom/joinroom, /chatroom/:roomid/join-requests
+	// 表结构见 migration 000020_join_requests
+	// =============================================
+	// 提交加入申请
+	CreateJoinRequest(ctx context.Context, arg CreateJoinRequestParams) (RoomJoinRequest, error)
 	// 创建踢人操作日志
 	CreateKickLog(ctx context.Context, arg CreateKickLogParams) (AdminLog, error)
 	// =============================================
@@ -94,6 +180,25 @@ type Querier interface {
 	// =============================================
 	// 发送消息 POST /chatrooms/:roomId/messages
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
+	// 记录消息修订（须与 UpdateMessage 在同一事务中、且在其之前执行，以保存编辑前内容）
+	// 并发编辑同一消息时，修订号唯一约束保证只有一方成功
+	CreateMessageEdit(ctx context.Context, arg CreateMessageEditParams) (MessageEdit, error)
+	// =============================================
+	// 消息提及相关SQL查询 (Message Mention Queries)
+	// 对应API: @提及、提及收件箱
+	// 表结构见 migration 000016_message_mentions
+	// 提及的已读状态由成员在聊天室的已读位置 (chatroom_members.last_read_at) 决定
+	// =============================================
+	// 将消息中的提及解析为聊天室有效成员并保存（不含发送者本人），返回被提及的成员及提及方式
+	// 同时命中多种提及方式时优先为 user；提及时间取消息发送时间，与已读位置比较
+	CreateMessageMentions(ctx context.Context, arg CreateMessageMentionsParams) ([]CreateMessageMentionsRow, error)
+	// =============================================
+	// 消息送达与已读状态相关SQL查询 (Message Receipt Queries)
+	// 对应API: 消息投递状态接口
+	// 表结构见 migration 000012_message_receipts
+	// =============================================
+	// 为聊天室其他成员创建消息投递记录（状态 sent），成员数超过上限的聊天室不记录
+	CreateMessageReceipts(ctx context.Context, arg CreateMessageReceiptsParams) (int64, error)
 	// 创建禁言操作日志
 	CreateMuteLog(ctx context.Context, arg CreateMuteLogParams) (AdminLog, error)
 	// =============================================
@@ -105,8 +210,29 @@ type Querier interface {
 	// =============================================
 	// 创建禁言记录 POST /chatrooms/:roomId/members/:userId/mute
 	CreateMuteRecord(ctx context.Context, arg CreateMuteRecordParams) (MuteRecord, error)
+	// =============================================
+	// 通知系统相关SQL查询 (Notification Queries)
+	// 对应API: 通知系统接口
+	// 表结构见 migration 000006_notifications
+	// =============================================
+	// =============================================
+	// 1. 通知创建 (Notification Creation)
+	// =============================================
+	// 创建通知
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	// 创建角色变更操作日志
 	CreateRoleChangeLog(ctx context.Context, arg CreateRoleChangeLogParams) (AdminLog, error)
+	// =============================================
+	// 聊天室邀请相关SQL查询 (Room Invite Queries)
+	// 对应API: /chatroom/:roomid/invites, POST /chatroom/joinroom
+	// 表结构见 migration 000019_room_invites
+	// =============================================
+	// 创建邀请码
+	CreateRoomInvite(ctx context.Context, arg CreateRoomInviteParams) (RoomInvite, error)
+	// 创建系统通知
+	CreateSystemNotification(ctx context.Context, arg CreateSystemNotificationParams) (Notification, error)
+	// 为话题关注者创建话题回复的投递记录（未同时发送到聊天室的回复只投递给关注者），成员数超过上限的聊天室不记录
+	CreateThreadReplyReceipts(ctx context.Context, arg CreateThreadReplyReceiptsParams) (int64, error)
 	// 创建解除禁言操作日志
 	CreateUnmuteLog(ctx context.Context, arg CreateUnmuteLogParams) (AdminLog, error)
 	// =============================================
@@ -118,6 +244,26 @@ type Querier interface {
 	// =============================================
 	// 用户注册 POST /auth/register
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// =============================================
+	// 登录会话相关SQL查询 (Session Queries)
+	// 对应API: 认证接口 / 会话管理接口
+	// =============================================
+	// 创建登录会话（登录/注册时）
+	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error)
+	// =============================================
+	// 用户设置相关SQL查询 (User Settings Queries)
+	// 对应API: 用户设置接口
+	// 表结构见 migration 000007_user_settings
+	// =============================================
+	// =============================================
+	// 1. 用户设置操作 (User Settings Operations)
+	// =============================================
+	// 创建用户设置（用户注册时调用）
+	CreateUserSettings(ctx context.Context, userID string) (UserSetting, error)
+	// 登记连接
+	CreateWSConnection(ctx context.Context, arg CreateWSConnectionParams) error
+	// 保存超出 NOTIFY 负载上限的事件
+	CreateWSEvent(ctx context.Context, payload string) (int64, error)
 	// 解除全局禁言
 	DeactivateGlobalMuteRecord(ctx context.Context, mutedUserID string) error
 	// 通过ID解除全局禁言
@@ -132,9 +278,13 @@ type Querier interface {
 	DecrementChatroomOnlineCount(ctx context.Context, roomID string) error
 	// 删除聊天室（软删除）DELETE /chatrooms/:roomId
 	DeleteChatroom(ctx context.Context, roomID string) error
+	// 清理过期事件
+	DeleteExpiredWSEvents(ctx context.Context, createdAt time.Time) error
+	// 删除好友关系 DELETE /friends/:userId
+	DeleteFriendship(ctx context.Context, arg DeleteFriendshipParams) error
 	// 删除消息 DELETE /chatrooms/:roomId/messages/:messageId
 	DeleteMessage(ctx context.Context, messageID string) error
-	// 软删除消息（将内容置为系统消息提示）
+	// 软删除消息（将内容置为系统消息提示，已撤回的消息不会重复处理）
 	DeleteMessageSoft(ctx context.Context, messageID string) (Message, error)
 	// =============================================
 	// 7. 批量操作 (Batch Operations)
@@ -145,12 +295,31 @@ type Querier interface {
 	DeleteMessagesByUser(ctx context.Context, senderID sql.NullString) error
 	// 删除用户在指定聊天室的所有消息
 	DeleteMessagesByUserInRoom(ctx context.Context, arg DeleteMessagesByUserInRoomParams) error
+	// 删除通知
+	DeleteNotification(ctx context.Context, arg DeleteNotificationParams) (int64, error)
+	// 删除指定时间之前的通知
+	DeleteOldNotifications(ctx context.Context, createdAt time.Time) error
+	// 删除已读通知
+	DeleteReadNotifications(ctx context.Context, receiverID string) error
+	// 清理超过 timeout_seconds 未刷新的连接（实例已退出），返回受影响的用户
+	DeleteStaleWSConnections(ctx context.Context, timeoutSeconds int32) ([]string, error)
 	// 删除用户账号（软删除）
 	DeleteUserAccount(ctx context.Context, userID string) error
+	// 删除用户设置（用户注销时调用）
+	DeleteUserSettings(ctx context.Context, userID string) error
+	// 注销连接
+	DeleteWSConnection(ctx context.Context, arg DeleteWSConnectionParams) error
 	// 批量过期全局禁言记录
 	ExpireGlobalMuteRecords(ctx context.Context) error
 	// 批量过期禁言记录
 	ExpireMuteRecords(ctx context.Context) error
+	// =============================================
+	// 消息话题相关SQL查询 (Message Thread Queries)
+	// 对应API: 消息话题接口
+	// 表结构见 migration 000014_message_threads
+	// =============================================
+	// 关注话题，已关注时不做处理
+	FollowThread(ctx context.Context, arg FollowThreadParams) error
 	// 获取用户当前有效的全局禁言记录
 	GetActiveGlobalMuteRecord(ctx context.Context, mutedUserID string) (GlobalMuteRecord, error)
 	// 获取有效的成员关系
@@ -159,6 +328,8 @@ type Querier interface {
 	GetActiveMuteRecord(ctx context.Context, memberRelID string) (MuteRecord, error)
 	// 获取聊天室当前有效的禁言记录
 	GetActiveMuteRecordsByRoom(ctx context.Context, roomID string) ([]GetActiveMuteRecordsByRoomRow, error)
+	// 获取未吊销且未过期的会话（鉴权时校验）
+	GetActiveUserSession(ctx context.Context, sessionID string) (UserSession, error)
 	// =============================================
 	// 2. 日志查询 (Log Queries)
 	// =============================================
@@ -180,10 +351,14 @@ type Querier interface {
 	GetAdminLogsByUser(ctx context.Context, arg GetAdminLogsByUserParams) ([]GetAdminLogsByUserRow, error)
 	// 获取所有有效的全局禁言记录
 	GetAllActiveGlobalMuteRecords(ctx context.Context, arg GetAllActiveGlobalMuteRecordsParams) ([]GetAllActiveGlobalMuteRecordsRow, error)
+	// 获取用户屏蔽的用户列表 GET /users/me/blocks
+	GetBlockedUsers(ctx context.Context, blockerID string) ([]GetBlockedUsersRow, error)
 	// 获取聊天室管理员列表
 	GetChatroomAdmins(ctx context.Context, roomID string) ([]GetChatroomAdminsRow, error)
 	// 获取聊天室详情 GET /chatrooms/:roomId
 	GetChatroomByID(ctx context.Context, roomID string) (Chatroom, error)
+	// 获取聊天室详情，包含已归档的聊天室（用于查看成员、退出等只读或离开操作）
+	GetChatroomIncludingArchived(ctx context.Context, roomID string) (Chatroom, error)
 	// =============================================
 	// 4. 成员列表查询 (Member List Queries)
 	// =============================================
@@ -191,8 +366,22 @@ type Querier interface {
 	GetChatroomMembers(ctx context.Context, arg GetChatroomMembersParams) ([]GetChatroomMembersRow, error)
 	// 获取聊天室房主
 	GetChatroomOwner(ctx context.Context, roomID string) (GetChatroomOwnerRow, error)
-	// 获取聊天室详情（不含密码，用于公开展示）
+	// 获取聊天室详情（不含密码，用于公开展示），包含已归档的聊天室
 	GetChatroomWithoutPassword(ctx context.Context, roomID string) (GetChatroomWithoutPasswordRow, error)
+	// 获取两名成员之间的私聊会话（user_low < user_high）
+	GetDirectConversation(ctx context.Context, arg GetDirectConversationParams) (DirectConversation, error)
+	// 获取私聊会话中另一名成员的信息
+	GetDirectConversationPeer(ctx context.Context, arg GetDirectConversationPeerParams) (GetDirectConversationPeerRow, error)
+	// 获取好友请求详情
+	GetFriendRequestByID(ctx context.Context, requestID string) (FriendRequest, error)
+	// =============================================
+	// 4. 好友列表查询 (Friend List Queries)
+	// =============================================
+	// 获取好友列表 GET /users/me/friends
+	GetFriends(ctx context.Context, arg GetFriendsParams) ([]GetFriendsRow, error)
+	// 按在线状态获取好友列表 GET /users/me/friends?status=online|away|offline
+	// 隐藏在线状态的好友视为 offline
+	GetFriendsByStatus(ctx context.Context, arg GetFriendsByStatusParams) ([]GetFriendsByStatusRow, error)
 	// 获取全局管理日志
 	GetGlobalAdminLogs(ctx context.Context, arg GetGlobalAdminLogsParams) ([]GetGlobalAdminLogsRow, error)
 	// 获取全局禁言记录
@@ -203,6 +392,8 @@ type Querier interface {
 	GetLastMessageInRoom(ctx context.Context, roomID string) (GetLastMessageInRoomRow, error)
 	// 获取最新消息
 	GetLatestMessages(ctx context.Context, arg GetLatestMessagesParams) ([]GetLatestMessagesRow, error)
+	// 获取加入最早的管理员（账号状态正常），用于房主退出或注销时自动接任
+	GetLongestTenuredAdmin(ctx context.Context, roomID string) (string, error)
 	// 通过关系ID获取成员信息
 	GetMemberByRelID(ctx context.Context, memberRelID string) (ChatroomMember, error)
 	// 获取成员最后阅读时间
@@ -211,8 +402,18 @@ type Querier interface {
 	GetMemberMuteExpireTime(ctx context.Context, arg GetMemberMuteExpireTimeParams) (time.Time, error)
 	// 获取成员角色
 	GetMemberRole(ctx context.Context, arg GetMemberRoleParams) (MemberRole, error)
+	// 按客户端消息ID获取发送者已发送的消息，用于重试发送时的幂等处理
+	GetMessageByClientMsgID(ctx context.Context, arg GetMessageByClientMsgIDParams) (Message, error)
 	// 获取单条消息
 	GetMessageByID(ctx context.Context, messageID string) (Message, error)
+	// 获取消息修订历史（按修订号正序）
+	GetMessageEdits(ctx context.Context, messageID string) ([]GetMessageEditsRow, error)
+	// 获取消息的所有表情回应及回应者信息（按回应时间正序）
+	GetMessageReactions(ctx context.Context, messageID string) ([]GetMessageReactionsRow, error)
+	// 获取已读指定消息的成员列表（已读位置不早于该消息发送时间，不含发送者本人）
+	GetMessageReadBy(ctx context.Context, messageID string) ([]GetMessageReadByRow, error)
+	// 获取消息各接收者的投递状态
+	GetMessageReceipts(ctx context.Context, messageID string) ([]GetMessageReceiptsRow, error)
 	// 获取消息所属聊天室ID
 	GetMessageRoom(ctx context.Context, messageID string) (string, error)
 	// 获取消息发送者ID
@@ -229,6 +430,7 @@ type Querier interface {
 	// 2. 消息列表查询 (Message List Queries)
 	// =============================================
 	// 获取聊天室消息历史 GET /chatrooms/:roomId/messages
+	// 聊天室时间线不含仅发送到话题中的回复（下同）
 	GetMessagesByRoom(ctx context.Context, arg GetMessagesByRoomParams) ([]GetMessagesByRoomRow, error)
 	// 获取聊天室消息历史（时间正序）
 	GetMessagesByRoomAsc(ctx context.Context, arg GetMessagesByRoomAscParams) ([]GetMessagesByRoomAscRow, error)
@@ -248,18 +450,67 @@ type Querier interface {
 	GetMuteRecordsByRoom(ctx context.Context, arg GetMuteRecordsByRoomParams) ([]GetMuteRecordsByRoomRow, error)
 	// 获取被禁言的成员列表
 	GetMutedMembers(ctx context.Context, roomID string) ([]GetMutedMembersRow, error)
+	// 获取共同好友
+	GetMutualFriends(ctx context.Context, arg GetMutualFriendsParams) ([]GetMutualFriendsRow, error)
+	// =============================================
+	// 2. 通知查询 (Notification Queries)
+	// =============================================
+	// 获取单条通知
+	GetNotificationByID(ctx context.Context, notificationID string) (Notification, error)
+	// 获取通知统计（总数、未读数、各类型未读数）
+	GetNotificationStats(ctx context.Context, receiverID string) (GetNotificationStatsRow, error)
 	// 获取聊天室在线成员列表 GET /chatrooms/:roomId/members?status=online
 	GetOnlineChatroomMembers(ctx context.Context, arg GetOnlineChatroomMembersParams) ([]GetOnlineChatroomMembersRow, error)
+	// 获取在线好友列表（不含隐藏在线状态的好友）
+	GetOnlineFriends(ctx context.Context, arg GetOnlineFriendsParams) ([]GetOnlineFriendsRow, error)
 	// 获取在线用户列表
 	GetOnlineUsers(ctx context.Context, arg GetOnlineUsersParams) ([]GetOnlineUsersRow, error)
 	// 获取各操作员的操作统计
 	GetOperatorStats(ctx context.Context, limit int64) ([]GetOperatorStatsRow, error)
+	// 获取用户在聊天室的待处理申请
+	GetPendingJoinRequest(ctx context.Context, arg GetPendingJoinRequestParams) (RoomJoinRequest, error)
+	// 获取待处理的收到的好友请求
+	GetPendingReceivedRequests(ctx context.Context, arg GetPendingReceivedRequestsParams) ([]GetPendingReceivedRequestsRow, error)
+	// 检查两个用户之间是否有待处理的请求
+	GetPendingRequestBetweenUsers(ctx context.Context, arg GetPendingRequestBetweenUsersParams) (FriendRequest, error)
+	// 获取聊天室置顶消息（最近置顶的在前，不含已撤回的消息）
+	GetPinnedMessages(ctx context.Context, roomID string) ([]GetPinnedMessagesRow, error)
 	// =============================================
 	// 5. 引用消息 (Quoted Messages)
 	// =============================================
 	// 获取被引用的消息
 	GetQuotedMessage(ctx context.Context, messageID string) (GetQuotedMessageRow, error)
-	// 获取未读消息数量
+	// 批量统计多条消息的表情回应数及当前用户是否回应（同一消息内按首次回应时间排序）
+	GetReactionSummaries(ctx context.Context, arg GetReactionSummariesParams) ([]GetReactionSummariesRow, error)
+	// =============================================
+	// 2. 好友请求列表 (Friend Request Lists)
+	// =============================================
+	// 获取收到的好友请求 GET /users/me/friend-requests?type=received
+	GetReceivedFriendRequests(ctx context.Context, arg GetReceivedFriendRequestsParams) ([]GetReceivedFriendRequestsRow, error)
+	// 通过邀请码获取邀请
+	GetRoomInvite(ctx context.Context, inviteCode string) (RoomInvite, error)
+	// =============================================
+	// 8. 消息同步 (Message Sync)
+	// =============================================
+	// 获取聊天室中变更序号大于指定值的消息（新消息、编辑、撤回），按变更顺序返回
+	GetRoomMessageChanges(ctx context.Context, arg GetRoomMessageChangesParams) ([]GetRoomMessageChangesRow, error)
+	// 获取允许通过邮箱搜索的用户
+	GetSearchableUsersByEmail(ctx context.Context, email sql.NullString) ([]GetSearchableUsersByEmailRow, error)
+	// =============================================
+	// 3. 搜索辅助 (Search Helpers)
+	// =============================================
+	// 获取允许通过手机号搜索的用户
+	GetSearchableUsersByPhone(ctx context.Context, phoneNumber sql.NullString) ([]GetSearchableUsersByPhoneRow, error)
+	// 获取发送的好友请求 GET /users/me/friend-requests?type=sent
+	GetSentFriendRequests(ctx context.Context, arg GetSentFriendRequestsParams) ([]GetSentFriendRequestsRow, error)
+	// 获取话题关注者中仍在聊天室内的用户
+	GetThreadFollowerIDs(ctx context.Context, rootMessageID string) ([]string, error)
+	// =============================================
+	// 9. 消息话题 (Message Threads)
+	// =============================================
+	// 获取话题回复（按序号正序，序号大于指定值）GET /chatroom/:roomId/messages/:messageId/thread
+	GetThreadReplies(ctx context.Context, arg GetThreadRepliesParams) ([]GetThreadRepliesRow, error)
+	// 获取未读消息数量（不含自己发送的消息和仅发送到话题中的回复）
 	GetUnreadMessageCount(ctx context.Context, arg GetUnreadMessageCountParams) (int64, error)
 	// 获取未读消息列表
 	GetUnreadMessages(ctx context.Context, arg GetUnreadMessagesParams) ([]GetUnreadMessagesRow, error)
@@ -273,19 +524,39 @@ type Querier interface {
 	GetUserChatroomMembership(ctx context.Context, arg GetUserChatroomMembershipParams) (ChatroomMember, error)
 	// 获取用户全局禁言到期时间
 	GetUserGlobalMuteExpireTime(ctx context.Context, mutedUserID string) (time.Time, error)
+	// 获取提及当前用户的消息 GET /users/me/mentions?roomId=&status=
+	// room_id 为空时不过滤聊天室；status: unread | 空（全部）。不含已撤回的消息和已退出的聊天室
+	GetUserMentions(ctx context.Context, arg GetUserMentionsParams) ([]GetUserMentionsRow, error)
 	// 获取用户的禁言状态（返回全局禁言和聊天室禁言状态）
 	GetUserMuteStatus(ctx context.Context, arg GetUserMuteStatusParams) (GetUserMuteStatusRow, error)
+	// 获取用户通知偏好
+	GetUserNotificationPreferences(ctx context.Context, userID string) (GetUserNotificationPreferencesRow, error)
+	// 获取用户通知列表 GET /users/me/notifications?type=&status=
+	// notification_type 为空时不过滤类型；status: unread | read | 空（全部）
+	GetUserNotifications(ctx context.Context, arg GetUserNotificationsParams) ([]Notification, error)
+	// 获取用户隐私偏好
+	GetUserPrivacyPreferences(ctx context.Context, userID string) (GetUserPrivacyPreferencesRow, error)
 	// 获取用户公开信息（不含敏感信息）GET /users/:userId
 	GetUserPublicInfo(ctx context.Context, userID string) (GetUserPublicInfoRow, error)
+	// 获取会话（含已吊销/已过期，用于 refresh token 重放检测）
+	GetUserSessionByID(ctx context.Context, sessionID string) (UserSession, error)
+	// 获取用户设置 GET /users/me/settings
+	GetUserSettings(ctx context.Context, userID string) (UserSetting, error)
 	// 获取用户系统角色
 	GetUserSystemRole(ctx context.Context, userID string) (NullUserSystemRole, error)
-	// 获取用户在所有聊天室的未读消息数
+	// 获取用户在所有聊天室的未读消息数（不含自己发送的消息和仅发送到话题中的回复）
 	GetUserUnreadCountsInAllRooms(ctx context.Context, userID string) ([]GetUserUnreadCountsInAllRoomsRow, error)
+	// 获取用户在各聊天室的未读提及数（仅返回有未读提及的聊天室，不含已撤回的消息）
+	GetUserUnreadMentionCountsInAllRooms(ctx context.Context, userID string) ([]GetUserUnreadMentionCountsInAllRoomsRow, error)
 	// =============================================
 	// 6. 批量查询 (Batch Queries)
 	// =============================================
 	// 批量获取用户信息
 	GetUsersByIDs(ctx context.Context, dollar_1 []string) ([]GetUsersByIDsRow, error)
+	// 获取事件内容
+	GetWSEvent(ctx context.Context, eventID int64) (string, error)
+	// 处理加入申请（approved / rejected），已处理的申请不返回行
+	HandleJoinRequest(ctx context.Context, arg HandleJoinRequestParams) (RoomJoinRequest, error)
 	// =============================================
 	// 8. 聊天室统计 (Chatroom Statistics)
 	// =============================================
@@ -293,8 +564,18 @@ type Querier interface {
 	IncrementChatroomMemberCount(ctx context.Context, roomID string) error
 	// 增加在线人数
 	IncrementChatroomOnlineCount(ctx context.Context, roomID string) error
+	// 检查两名用户之间是否存在任一方向的屏蔽
+	IsBlockedBetween(ctx context.Context, arg IsBlockedBetweenParams) (bool, error)
+	// 检查聊天室是否已归档
+	IsChatroomArchived(ctx context.Context, roomID string) (bool, error)
 	// 检查聊天室是否为公开
 	IsChatroomPublic(ctx context.Context, roomID string) (bool, error)
+	// 检查聊天室是否为私聊会话
+	IsDirectChatroom(ctx context.Context, roomID string) (bool, error)
+	// 检查私聊会话的任一成员是否屏蔽了另一方（非私聊会话返回 false）
+	IsDirectConversationBlocked(ctx context.Context, roomID string) (bool, error)
+	// 检查是否是好友
+	IsFriend(ctx context.Context, arg IsFriendParams) (bool, error)
 	// 检查成员是否被禁言
 	IsMemberMuted(ctx context.Context, arg IsMemberMutedParams) (bool, error)
 	// 检查成员在聊天室是否被禁言
@@ -304,6 +585,8 @@ type Querier interface {
 	// =============================================
 	// 检查用户是否是消息发送者
 	IsMessageSender(ctx context.Context, arg IsMessageSenderParams) (bool, error)
+	// 检查用户是否关注话题
+	IsThreadFollower(ctx context.Context, arg IsThreadFollowerParams) (bool, error)
 	// 检查用户是否为管理员
 	IsUserAdmin(ctx context.Context, userID string) (bool, error)
 	// 检查用户是否为管理员或房主
@@ -314,43 +597,125 @@ type Querier interface {
 	IsUserInChatroom(ctx context.Context, arg IsUserInChatroomParams) (bool, error)
 	// 检查用户是否为房主
 	IsUserOwner(ctx context.Context, arg IsUserOwnerParams) (bool, error)
+	// 检查用户是否允许通过邮箱搜索
+	IsUserSearchableByEmail(ctx context.Context, userID string) (bool, error)
+	// =============================================
+	// 2. 设置查询辅助 (Settings Query Helpers)
+	// =============================================
+	// 检查用户是否允许通过手机号搜索
+	IsUserSearchableByPhone(ctx context.Context, userID string) (bool, error)
 	// =============================================
 	// 3. 聊天室成员操作 (Member Operations)
 	// =============================================
-	// 加入聊天室 POST /chatrooms/:roomId/join
+	// 加入聊天室 POST /chatrooms/:roomId/join（invited_by 为邀请人，直接加入时为 NULL）
 	JoinChatroom(ctx context.Context, arg JoinChatroomParams) (ChatroomMember, error)
 	// 踢出成员 POST /chatrooms/:roomId/members/:userId/kick
 	KickMember(ctx context.Context, arg KickMemberParams) error
 	// 退出聊天室 POST /chatrooms/:roomId/leave
 	LeaveChatroom(ctx context.Context, arg LeaveChatroomParams) error
+	// 获取用户所有有效会话 GET /users/me/sessions
+	ListActiveUserSessions(ctx context.Context, userID string) ([]UserSession, error)
+	// 获取用户担任房主的聊天室（不含已删除的聊天室）
+	ListOwnedChatrooms(ctx context.Context, userID string) ([]ListOwnedChatroomsRow, error)
+	// 获取聊天室的待处理申请（最早的在前）GET /chatroom/:roomid/join-requests
+	ListPendingJoinRequests(ctx context.Context, arg ListPendingJoinRequestsParams) ([]ListPendingJoinRequestsRow, error)
 	// 获取公开聊天室列表
 	ListPublicChatrooms(ctx context.Context, arg ListPublicChatroomsParams) ([]ListPublicChatroomsRow, error)
+	// 获取聊天室的邀请列表（最新的在前），include_inactive 为 false 时仅返回仍可使用的邀请
+	ListRoomInvites(ctx context.Context, arg ListRoomInvitesParams) ([]ListRoomInvitesRow, error)
 	// =============================================
 	// 2. 聊天室列表查询 (Chatroom List Queries)
 	// =============================================
-	// 获取用户的聊天室列表 GET /users/me/chatrooms
+	// 获取用户的聊天室列表 GET /users/me/chatrooms（包含已归档的聊天室）
 	ListUserChatrooms(ctx context.Context, arg ListUserChatroomsParams) ([]ListUserChatroomsRow, error)
+	// 错误次数达到上限后锁定，锁定期间的尝试在校验密码前即被拒绝
+	LockRoomPasswordAttempts(ctx context.Context, arg LockRoomPasswordAttemptsParams) error
+	// =============================================
+	// WebSocket 连接登记相关SQL查询 (WS Connection Queries)
+	// 多实例部署时按用户统计全局连接数，维护在线状态
+	// 表结构见 migration 000022_ws_connections
+	// =============================================
+	// 在事务中锁定用户的在线状态变更（事务结束时释放），串行化各实例的连接与断开
+	LockUserPresence(ctx context.Context, userID string) error
+	// 标记所有通知已读 POST /users/me/notifications/read-all
+	MarkAllNotificationsAsRead(ctx context.Context, receiverID string) (int64, error)
+	// 标记消息已送达指定接收者（仅 sent 状态会被更新）
+	MarkMessageDelivered(ctx context.Context, arg MarkMessageDeliveredParams) (MarkMessageDeliveredRow, error)
+	// 将接收者在聊天室中指定时间及之前的消息标记为已读（已读位置前进时调用）
+	MarkMessagesReadUpTo(ctx context.Context, arg MarkMessagesReadUpToParams) ([]MarkMessagesReadUpToRow, error)
+	// =============================================
+	// 3. 通知状态管理 (Notification Status Management)
+	// =============================================
+	// 标记通知已读 POST /notifications/:notificationId/read
+	MarkNotificationAsRead(ctx context.Context, arg MarkNotificationAsReadParams) (int64, error)
+	// 按类型标记通知已读
+	MarkNotificationsByTypeAsRead(ctx context.Context, arg MarkNotificationsByTypeAsReadParams) (int64, error)
 	// =============================================
 	// 6. 禁言管理 (Mute Management)
 	// =============================================
 	// 禁言成员 POST /chatrooms/:roomId/members/:userId/mute
 	MuteMember(ctx context.Context, arg MuteMemberParams) error
+	// =============================================
+	// WebSocket 跨实例事件相关SQL查询 (WS Event Queries)
+	// 用于 PostgreSQL LISTEN/NOTIFY 背板
+	// =============================================
+	// 向指定频道发送通知
+	NotifyChannel(ctx context.Context, arg NotifyChannelParams) error
+	// =============================================
+	// 置顶消息相关SQL查询 (Pinned Message Queries)
+	// 对应API: 置顶消息接口
+	// 表结构见 migration 000015_pinned_messages
+	// =============================================
+	// 置顶消息，已置顶时不返回行
+	PinMessage(ctx context.Context, arg PinMessageParams) (PinnedMessage, error)
+	// 使用邀请，邀请已撤销、过期或次数用尽时无返回行（并发使用时不会超出次数）
+	RedeemRoomInvite(ctx context.Context, arg RedeemRoomInviteParams) (RoomInvite, error)
+	// 拒绝好友请求 POST /friends/request/:requestId/handle
+	RejectFriendRequest(ctx context.Context, arg RejectFriendRequestParams) (FriendRequest, error)
 	// 取消管理员 POST /chatrooms/:roomId/members/:userId/remove-admin
 	RemoveMemberAdmin(ctx context.Context, arg RemoveMemberAdminParams) error
+	// 取消表情回应
+	RemoveMessageReaction(ctx context.Context, arg RemoveMessageReactionParams) (int64, error)
+	// =============================================
+	// 聊天室密码错误限制相关SQL查询 (Room Password Attempt Queries)
+	// 对应API: POST /chatroom/joinroom
+	// 表结构见 migration 000021_room_password_hash
+	// =============================================
+	// 在校验密码之前原子地占用一次尝试次数，并发请求依次递增，不会同时读到旧的计数。
+	// 锁定期间继续递增（计数保持大于上限）；锁定已过期或统计窗口开始时间早于 window_started_at 时重新计数；
+	// 计数超过上限 max_failures 时锁定到 locked_until。返回的 failed_count 大于上限表示本次尝试应被拒绝
+	ReserveRoomPasswordAttempt(ctx context.Context, arg ReserveRoomPasswordAttemptParams) (RoomPasswordAttempt, error)
+	// 吊销用户的所有会话 POST /users/me/sessions/revokeall
+	RevokeAllUserSessions(ctx context.Context, userID string) (int64, error)
+	// 撤销邀请，已撤销的邀请不做处理（无返回行）
+	RevokeRoomInvite(ctx context.Context, arg RevokeRoomInviteParams) (RoomInvite, error)
+	// 吊销用户的指定会话 POST /users/me/sessions/:sessionid/revoke
+	RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error)
+	// 轮换 refresh token：仅当旧 jti 仍为当前有效 jti 时成功 POST /auth/refresh
+	RotateSessionRefreshToken(ctx context.Context, arg RotateSessionRefreshTokenParams) (int64, error)
 	// 在聊天室内搜索成员（模糊查询用户名或昵称）
 	SearchChatroomMembers(ctx context.Context, arg SearchChatroomMembersParams) ([]SearchChatroomMembersRow, error)
 	// 搜索聊天室
 	SearchChatrooms(ctx context.Context, arg SearchChatroomsParams) ([]SearchChatroomsRow, error)
+	// 搜索好友
+	SearchFriends(ctx context.Context, arg SearchFriendsParams) ([]SearchFriendsRow, error)
 	// =============================================
 	// 4. 消息搜索 (Message Search)
 	// =============================================
-	// 在聊天室中搜索消息
-	SearchMessagesInRoom(ctx context.Context, arg SearchMessagesInRoomParams) ([]SearchMessagesInRoomRow, error)
+	// 在用户所在的所有聊天室中搜索消息 GET /chatroom/search
+	// pattern 为最长关键词的 ILIKE 模式（使用三元组索引），term_patterns 为全部关键词的模式（须全部匹配），
+	// bigrams 为全部关键词的二元组（使用二元组索引，支持少于 3 个字符的关键词）；
+	// 按与查询的三元组相似度、发送时间倒序排列，has_cursor 为 true 时返回游标之后的结果。
+	// room_id / sender_id / message_type 为空时不过滤，from_time / to_time 为 NULL 时不限
+	SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error)
 	// =============================================
 	// 4. 用户搜索 (User Search)
 	// =============================================
 	// 搜索用户 GET /users/search
+	// 用户名、昵称模糊匹配；邮箱、手机号仅精确匹配，且需对方在隐私设置中允许
 	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	// 设置加入是否需要审核 POST /chatroom/:roomid/update
+	SetChatroomRequireApproval(ctx context.Context, arg SetChatroomRequireApprovalParams) error
 	// 设置管理员 POST /chatrooms/:roomId/members/:userId/set-admin
 	SetMemberAsAdmin(ctx context.Context, arg SetMemberAsAdminParams) error
 	// =============================================
@@ -364,16 +729,34 @@ type Querier interface {
 	SetUserOnline(ctx context.Context, userID string) error
 	// 设置用户系统角色（超级管理员操作）
 	SetUserSystemRole(ctx context.Context, arg SetUserSystemRoleParams) error
+	// 检查用户是否显示在线状态（没有设置记录时默认显示）
+	ShouldShowOnlineStatus(ctx context.Context, userID string) (bool, error)
 	// 封禁用户账号（管理员操作）
 	SuspendUser(ctx context.Context, userID string) error
 	// 同步成员计数（用于数据修复）
 	SyncChatroomMemberCount(ctx context.Context, dollar_1 sql.NullString) error
-	// 同步在线人数（用于数据修复）
+	// 同步在线人数（用于数据修复），关闭了"显示在线状态"的成员不计入
 	SyncChatroomOnlineCount(ctx context.Context, dollar_1 sql.NullString) error
-	// 转让房主
-	TransferOwnership(ctx context.Context, arg TransferOwnershipParams) error
+	// 重新统计用户所在聊天室的在线人数（用户上线、离线或修改在线状态可见性后调用，各实例重复执行结果相同）。
+	// 关闭了"显示在线状态"的成员不计入
+	SyncUserChatroomsOnlineCount(ctx context.Context, userID string) error
+	// 刷新实例持有的所有连接
+	TouchInstanceConnections(ctx context.Context, instanceID string) error
+	// 更新会话最后活跃时间
+	TouchUserSession(ctx context.Context, sessionID string) error
+	// 转让房主：原房主 $1 降为管理员，$2 成为房主。
+	// $1 须仍是房主且两人都须为有效成员，影响行数不为 2 时调用方应回滚事务
+	TransferOwnership(ctx context.Context, arg TransferOwnershipParams) (int64, error)
+	// 取消归档，恢复为正常状态
+	UnarchiveChatroom(ctx context.Context, roomID string) (int64, error)
+	// 取消屏蔽
+	UnblockUser(ctx context.Context, arg UnblockUserParams) (int64, error)
+	// 取消关注话题
+	UnfollowThread(ctx context.Context, arg UnfollowThreadParams) (int64, error)
 	// 解除禁言 POST /chatrooms/:roomId/members/:userId/unmute
 	UnmuteMember(ctx context.Context, arg UnmuteMemberParams) error
+	// 取消置顶
+	UnpinMessage(ctx context.Context, arg UnpinMessageParams) (int64, error)
 	// =============================================
 	// 5. 账号管理 (Account Management)
 	// =============================================
@@ -383,15 +766,20 @@ type Querier interface {
 	UpdateChatroom(ctx context.Context, arg UpdateChatroomParams) (Chatroom, error)
 	// 更新最后活跃时间
 	UpdateChatroomLastActiveTime(ctx context.Context, roomID string) error
+	// 更新语言设置
+	UpdateLanguageSettings(ctx context.Context, arg UpdateLanguageSettingsParams) error
 	// =============================================
 	// 7. 消息已读管理 (Read Status Management)
 	// =============================================
 	// 更新最后阅读时间 POST /chatrooms/:roomId/messages/read
 	UpdateMemberLastReadTime(ctx context.Context, arg UpdateMemberLastReadTimeParams) error
-	// 更新最后阅读到指定消息
-	UpdateMemberLastReadToMessage(ctx context.Context, arg UpdateMemberLastReadToMessageParams) error
-	// 编辑消息 PUT /chatrooms/:roomId/messages/:messageId
+	// 更新最后阅读到指定消息（消息须属于该聊天室，已读位置只前进不后退）
+	UpdateMemberLastReadToMessage(ctx context.Context, arg UpdateMemberLastReadToMessageParams) (UpdateMemberLastReadToMessageRow, error)
+	// 编辑消息 POST /chatrooms/:roomId/messages/:messageId/edit
+	// 发送时间保持不变，记录最后编辑时间并递增编辑次数；已撤回的消息不会被更新
 	UpdateMessage(ctx context.Context, arg UpdateMessageParams) (Message, error)
+	// 更新主题设置
+	UpdateThemeSettings(ctx context.Context, arg UpdateThemeSettingsParams) error
 	// =============================================
 	// 2. 用户信息管理 (User Profile Management)
 	// =============================================
@@ -408,8 +796,8 @@ type Querier interface {
 	UpdateUserOnlineStatus(ctx context.Context, arg UpdateUserOnlineStatusParams) error
 	// 修改密码 POST /auth/change-password
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
-	// 验证聊天室密码
-	VerifyChatroomPassword(ctx context.Context, arg VerifyChatroomPasswordParams) (bool, error)
+	// 更新用户设置 PUT /users/me/settings（未提供的字段保持不变）
+	UpdateUserSettings(ctx context.Context, arg UpdateUserSettingsParams) (UserSetting, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -77,18 +77,21 @@ func (q *Queries) CountOnlineUsers(ctx context.Context) (int64, error) {
 
 const countSearchUsers = `-- name: CountSearchUsers :one
 SELECT COUNT(*) 
-FROM users 
+FROM users u
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE 
-    account_status = 'active'
+    u.account_status = 'active'
     AND (
-        username ILIKE '%' || $1 || '%' 
-        OR nickname ILIKE '%' || $1 || '%'
+        u.username ILIKE '%' || $1::text || '%' 
+        OR u.nickname ILIKE '%' || $1::text || '%'
+        OR (u.email = $1::text AND COALESCE(us.allow_search_by_email, true))
+        OR (u.phone_number = $1::text AND COALESCE(us.allow_search_by_phone, true))
     )
 `
 
-// 搜索用户计数
-func (q *Queries) CountSearchUsers(ctx context.Context, dollar_1 sql.NullString) (int64, error) {
-	row := q.queryRow(ctx, q.countSearchUsersStmt, countSearchUsers, dollar_1)
+// 搜索用户计数（匹配规则同 SearchUsers）
+func (q *Queries) CountSearchUsers(ctx context.Context, keyword string) (int64, error) {
+	row := q.queryRow(ctx, q.countSearchUsersStmt, countSearchUsers, keyword)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -488,51 +491,59 @@ func (q *Queries) IsUserAdmin(ctx context.Context, userID string) (bool, error)
 const searchUsers = `-- name: SearchUsers :many
 
 SELECT 
-    user_id,
-    username,
-    nickname,
-    avatar_url,
-    bio,
-    online_status
-FROM users 
+    u.user_id,
+    u.username,
+    u.nickname,
+    u.avatar_url,
+    u.bio,
+    u.online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
+FROM users u
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE 
-    account_status = 'active'
+    u.account_status = 'active'
     AND (
-        username ILIKE '%' || $1 || '%' 
-        OR nickname ILIKE '%' || $1 || '%'
+        u.username ILIKE '%' || $1::text || '%' 
+        OR u.nickname ILIKE '%' || $1::text || '%'
+        OR (u.email = $1::text AND COALESCE(us.allow_search_by_email, true))
+        OR (u.phone_number = $1::text AND COALESCE(us.allow_search_by_phone, true))
     )
 ORDER BY 
-    CASE WHEN username = $1 THEN 0
-         WHEN username ILIKE $1 || '%' THEN 1
-         WHEN nickname = $1 THEN 2
-         WHEN nickname ILIKE $1 || '%' THEN 3
+    CASE WHEN u.username = $1::text THEN 0
+         WHEN u.email = $1::text AND COALESCE(us.allow_search_by_email, true) THEN 0
+         WHEN u.phone_number = $1::text AND COALESCE(us.allow_search_by_phone, true) THEN 0
+         WHEN u.username ILIKE $1::text || '%' THEN 1
+         WHEN u.nickname = $1::text THEN 2
+         WHEN u.nickname ILIKE $1::text || '%' THEN 3
          ELSE 4
     END,
-    username
+    u.username
 LIMIT $2 OFFSET $3
 `
 
 type SearchUsersParams struct {
-	Column1 sql.NullString `json:"column_1"`
-	Limit   int64          `json:"limit"`
-	Offset  int64          `json:"offset"`
+	Keyword string `json:"keyword"`
+	Limit   int64  `json:"limit"`
+	Offset  int64  `json:"offset"`
 }
 
 type SearchUsersRow struct {
-	UserID       string               `json:"user_id"`
-	Username     string               `json:"username"`
-	Nickname     sql.NullString       `json:"nickname"`
-	AvatarUrl    sql.NullString       `json:"avatar_url"`
-	Bio          sql.NullString       `json:"bio"`
-	OnlineStatus NullUserOnlineStatus `json:"online_status"`
+	UserID           string               `json:"user_id"`
+	Username         string               `json:"username"`
+	Nickname         sql.NullString       `json:"nickname"`
+	AvatarUrl        sql.NullString       `json:"avatar_url"`
+	Bio              sql.NullString       `json:"bio"`
+	OnlineStatus     NullUserOnlineStatus `json:"online_status"`
+	ShowOnlineStatus bool                 `json:"show_online_status"`
 }
 
 // =============================================
 // 4. 用户搜索 (User Search)
 // =============================================
 // 搜索用户 GET /users/search
+// 用户名、昵称模糊匹配；邮箱、手机号仅精确匹配，且需对方在隐私设置中允许
 func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error) {
-	rows, err := q.query(ctx, q.searchUsersStmt, searchUsers, arg.Column1, arg.Limit, arg.Offset)
+	rows, err := q.query(ctx, q.searchUsersStmt, searchUsers, arg.Keyword, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -547,6 +558,7 @@ func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]Sea
 			&i.AvatarUrl,
 			&i.Bio,
 			&i.OnlineStatus,
+			&i.ShowOnlineStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,12 @@
+-- ----------------------------
+-- WebSocket 跨实例事件（PostgreSQL LISTEN/NOTIFY 背板）
+-- ----------------------------
+
+-- NOTIFY 负载上限约 8000 字节，超出时事件内容写入此表，通知中仅携带事件编号
+CREATE TABLE "ws_events" (
+                             "event_id" BIGSERIAL PRIMARY KEY,                          -- 事件编号
+                             "payload" TEXT NOT NULL,                                   -- 事件内容（JSON）
+                             "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP -- 创建时间
+);
+
+CREATE INDEX "idx_ws_events_created_at" ON "ws_events" ("created_at");
@@ -0,0 +1,21 @@
+-- ----------------------------
+-- 登录会话（服务端 Token 吊销）
+-- ----------------------------
+
+-- 每次登录/注册创建一个会话，access/refresh token 通过 sid 声明关联到会话
+CREATE TABLE "user_sessions" (
+                                 "session_id" varchar(17) primary key,                          -- 会话编号（JWT sid 声明）
+                                 "user_id" varchar(10) NOT NULL,                                 -- 用户编号
+                                 "device" VARCHAR(50),                                           -- 设备类型
+                                 "user_agent" TEXT,                                              -- 客户端 User-Agent
+                                 "ip_address" VARCHAR(64),                                       -- 登录 IP
+                                 "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,   -- 创建时间
+                                 "last_active_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- 最后活跃时间
+                                 "expires_at" TIMESTAMPTZ NOT NULL,                             -- 过期时间（与 refresh token 一致）
+                                 "revoked_at" TIMESTAMPTZ                                       -- 吊销时间，NULL 表示有效
+);
+
+ALTER TABLE "user_sessions" ADD CONSTRAINT "fk_user_sessions_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+CREATE INDEX "idx_user_sessions_user_id" ON "user_sessions" ("user_id");
@@ -0,0 +1 @@
+ALTER TABLE "user_sessions" DROP COLUMN IF EXISTS "refresh_jti";
@@ -0,0 +1,8 @@
+-- ----------------------------
+-- Refresh Token 轮换（一次性使用 + 重放检测）
+-- ----------------------------
+
+-- 会话即 token 家族：仅记录当前有效的 refresh token 编号（jti），
+-- 旧 refresh token 被再次使用时视为泄露，吊销整个会话
+ALTER TABLE "user_sessions"
+    ADD COLUMN "refresh_jti" varchar(17) NOT NULL DEFAULT ''; -- 当前有效 refresh token 的 jti
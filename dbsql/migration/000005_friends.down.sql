@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "friends" CASCADE;
+DROP TABLE IF EXISTS "friend_requests" CASCADE;
+drop function generateFriendRequestID() cascade;
+drop sequence FriendRequest_idSeq;
@@ -0,0 +1,66 @@
+-- ----------------------------
+-- 好友关系 (Friends)
+-- ----------------------------
+
+-- 表: friend_requests (好友请求)
+CREATE SEQUENCE FriendRequest_idSeq
+    START WITH 1
+    INCREMENT BY 1
+    MINVALUE 1;
+CREATE OR REPLACE FUNCTION generateFriendRequestID()
+    RETURNS TRIGGER AS $$
+DECLARE
+    next_id BIGINT;
+BEGIN
+    next_id := nextval('FriendRequest_idSeq');
+
+    NEW.request_id := 'FR' || LPAD(next_id::text, 8, '0');
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE "friend_requests" (
+                                   "request_id" varchar(10) primary key,                          -- 请求编号
+                                   "sender_id" varchar(10) NOT NULL,                              -- 发送者编号
+                                   "receiver_id" varchar(10) NOT NULL,                            -- 接收者编号
+                                   "message" TEXT,                                                -- 附言
+                                   "status" VARCHAR(20) NOT NULL DEFAULT 'pending',               -- 状态: pending, accepted, rejected
+                                   "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+                                   "handled_at" TIMESTAMPTZ,                                      -- 处理时间
+                                   CONSTRAINT "chk_friend_requests_status" CHECK ("status" IN ('pending', 'accepted', 'rejected')),
+                                   CONSTRAINT "chk_friend_requests_not_self" CHECK ("sender_id" <> "receiver_id")
+);
+create trigger beforeInsertFriendRequest
+    before insert on "friend_requests"
+    for each row
+execute function generateFriendRequestID();
+
+-- 表: friends (好友关系，双向各存一行)
+CREATE TABLE "friends" (
+                           "id" SERIAL PRIMARY KEY,
+                           "user_id" varchar(10) NOT NULL,                                 -- 用户编号
+                           "friend_id" varchar(10) NOT NULL,                               -- 好友编号
+                           "friend_since" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- 成为好友的时间
+                           CONSTRAINT "unique_friendship" UNIQUE ("user_id", "friend_id")
+);
+
+ALTER TABLE "friend_requests" ADD CONSTRAINT "fk_friend_requests_sender"
+    FOREIGN KEY ("sender_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+ALTER TABLE "friend_requests" ADD CONSTRAINT "fk_friend_requests_receiver"
+    FOREIGN KEY ("receiver_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+ALTER TABLE "friends" ADD CONSTRAINT "fk_friends_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+ALTER TABLE "friends" ADD CONSTRAINT "fk_friends_friend"
+    FOREIGN KEY ("friend_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+-- 两个用户之间同一时间只能有一条待处理请求（不区分方向），处理后可再次发送
+CREATE UNIQUE INDEX "idx_friend_requests_pending_pair" ON "friend_requests" (LEAST("sender_id", "receiver_id"), GREATEST("sender_id", "receiver_id"))
+    WHERE "status" = 'pending';
+CREATE INDEX "idx_friend_requests_receiver_id" ON "friend_requests" ("receiver_id", "created_at" DESC);
+CREATE INDEX "idx_friend_requests_sender_id" ON "friend_requests" ("sender_id", "created_at" DESC);
+
+CREATE INDEX "idx_friends_friend_id" ON "friends" ("friend_id");
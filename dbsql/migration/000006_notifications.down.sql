@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "notifications" CASCADE;
+drop function generateNotificationID() cascade;
+drop sequence Notification_idSeq;
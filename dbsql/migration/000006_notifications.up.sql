@@ -0,0 +1,43 @@
+-- ----------------------------
+-- 通知中心 (Notifications)
+-- ----------------------------
+
+-- 表: notifications (通知)
+CREATE SEQUENCE Notification_idSeq
+    START WITH 1
+    INCREMENT BY 1
+    MINVALUE 1;
+CREATE OR REPLACE FUNCTION generateNotificationID()
+    RETURNS TRIGGER AS $$
+DECLARE
+    next_id BIGINT;
+BEGIN
+    next_id := nextval('Notification_idSeq');
+
+    NEW.notification_id := 'N' || LPAD(next_id::text, 15, '0');
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE "notifications" (
+                                 "notification_id" varchar(16) primary key,                     -- 通知编号
+                                 "receiver_id" varchar(10) NOT NULL,                            -- 接收者编号
+                                 "notification_type" VARCHAR(20) NOT NULL,                      -- 类型: friend, chatroom, system
+                                 "title" VARCHAR(255) NOT NULL,                                 -- 标题
+                                 "content" TEXT NOT NULL,                                       -- 内容
+                                 "data" JSONB NOT NULL DEFAULT '{}'::jsonb,                     -- 附加数据
+                                 "is_read" BOOLEAN NOT NULL DEFAULT FALSE,                      -- 是否已读
+                                 "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- 创建时间
+                                 CONSTRAINT "chk_notifications_type" CHECK ("notification_type" IN ('friend', 'chatroom', 'system'))
+);
+create trigger beforeInsertNotification
+    before insert on "notifications"
+    for each row
+execute function generateNotificationID();
+
+ALTER TABLE "notifications" ADD CONSTRAINT "fk_notifications_receiver"
+    FOREIGN KEY ("receiver_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+CREATE INDEX "idx_notifications_receiver_id" ON "notifications" ("receiver_id", "created_at" DESC);
+CREATE INDEX "idx_notifications_receiver_unread" ON "notifications" ("receiver_id") WHERE "is_read" = false;
@@ -0,0 +1,30 @@
+-- ----------------------------
+-- 用户设置 (User Settings)
+-- ----------------------------
+
+-- 表: user_settings (用户设置，每个用户一行)
+CREATE TABLE "user_settings" (
+                                 "user_id" varchar(10) primary key,                              -- 用户编号
+                                 -- 通知设置
+                                 "enable_friend_request" BOOLEAN NOT NULL DEFAULT TRUE,         -- 好友请求通知
+                                 "enable_chatroom_message" BOOLEAN NOT NULL DEFAULT TRUE,       -- 聊天室消息通知
+                                 "enable_system_notice" BOOLEAN NOT NULL DEFAULT TRUE,          -- 系统通知
+                                 "enable_sound" BOOLEAN NOT NULL DEFAULT TRUE,                  -- 提示音
+                                 "enable_desktop_notification" BOOLEAN NOT NULL DEFAULT TRUE,   -- 桌面通知
+                                 -- 隐私设置
+                                 "allow_search_by_phone" BOOLEAN NOT NULL DEFAULT TRUE,         -- 允许通过手机号搜索
+                                 "allow_search_by_email" BOOLEAN NOT NULL DEFAULT TRUE,         -- 允许通过邮箱搜索
+                                 "show_online_status" BOOLEAN NOT NULL DEFAULT TRUE,            -- 对他人显示在线状态
+                                 -- 其他设置
+                                 "theme" VARCHAR(20) NOT NULL DEFAULT 'light',                  -- 主题
+                                 "language" VARCHAR(10) NOT NULL DEFAULT 'zh-CN',               -- 语言
+                                 "updated_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP   -- 更新时间
+);
+
+ALTER TABLE "user_settings" ADD CONSTRAINT "fk_user_settings_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+-- 为已有用户补齐默认设置
+INSERT INTO "user_settings" ("user_id")
+SELECT "user_id" FROM "users"
+ON CONFLICT ("user_id") DO NOTHING;
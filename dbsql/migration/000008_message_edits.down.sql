@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "message_edits" CASCADE;
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "edit_count";
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "edited_at";
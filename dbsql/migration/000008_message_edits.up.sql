@@ -0,0 +1,26 @@
+-- ----------------------------
+-- 消息编辑历史 (Message Edits)
+-- ----------------------------
+
+-- 编辑消息不再修改 sent_at，只记录最后编辑时间与编辑次数（即最新修订号）
+ALTER TABLE "messages"
+    ADD COLUMN "edited_at" TIMESTAMPTZ,                  -- 最后编辑时间，未编辑为 NULL
+    ADD COLUMN "edit_count" INTEGER NOT NULL DEFAULT 0;  -- 编辑次数
+
+-- 表: message_edits (消息修订记录，每次编辑一行)
+CREATE TABLE "message_edits" (
+                                 "id" SERIAL PRIMARY KEY,
+                                 "message_id" varchar(21) NOT NULL,                             -- 消息编号
+                                 "revision" INTEGER NOT NULL,                                   -- 修订号，从 1 开始
+                                 "old_content" TEXT NOT NULL,                                   -- 编辑前内容
+                                 "new_content" TEXT NOT NULL,                                   -- 编辑后内容
+                                 "edited_by" varchar(10),                                       -- 编辑者编号
+                                 "edited_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,   -- 编辑时间
+                                 CONSTRAINT "unique_message_revision" UNIQUE ("message_id", "revision")
+);
+
+ALTER TABLE "message_edits" ADD CONSTRAINT "fk_message_edits_message"
+    FOREIGN KEY ("message_id") REFERENCES "messages"("message_id") ON DELETE CASCADE;
+
+ALTER TABLE "message_edits" ADD CONSTRAINT "fk_message_edits_editor"
+    FOREIGN KEY ("edited_by") REFERENCES "users"("user_id") ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+-- ----------------------------
+-- 消息媒体地址 (Message Media)
+-- ----------------------------
+
+-- 图片、文件消息的媒体地址，文本消息为 NULL
+ALTER TABLE "messages"
+    ADD COLUMN "media_url" varchar(512); -- 媒体地址
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS beforeWriteMessageSeq ON "messages";
+drop function assignMessageSeq() cascade;
+drop function nextRoomMessageSeq(varchar) cascade;
+DROP INDEX IF EXISTS "idx_messages_room_change_seq";
+ALTER TABLE "messages" DROP CONSTRAINT IF EXISTS "unique_messages_room_seq";
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "deleted_at";
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "change_seq";
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "seq";
+DROP TABLE IF EXISTS "chatroom_message_seq" CASCADE;
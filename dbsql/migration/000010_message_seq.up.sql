@@ -0,0 +1,78 @@
+-- ----------------------------
+-- 聊天室内消息序号 (Per-room Message Sequence)
+-- ----------------------------
+
+-- 表: chatroom_message_seq (每个聊天室的消息序号计数器)
+CREATE TABLE "chatroom_message_seq" (
+                                        "room_id" varchar(9) primary key,                  -- 聊天室编号
+                                        "last_seq" BIGINT NOT NULL DEFAULT 0                -- 已分配的最大序号
+);
+
+ALTER TABLE "chatroom_message_seq" ADD CONSTRAINT "fk_chatroom_message_seq_room"
+    FOREIGN KEY ("room_id") REFERENCES "chatrooms"("room_id") ON DELETE CASCADE;
+
+-- seq: 消息在聊天室内的创建序号；change_seq: 消息最后一次变更（创建、编辑、撤回）时分配的序号。
+-- 两者共用同一个计数器，客户端记录收到的最大序号，断线重连后据此补齐遗漏的新消息、编辑与撤回
+ALTER TABLE "messages"
+    ADD COLUMN "seq" BIGINT,                 -- 聊天室内创建序号
+    ADD COLUMN "change_seq" BIGINT,          -- 最后变更序号
+    ADD COLUMN "deleted_at" TIMESTAMPTZ;     -- 撤回时间，未撤回为 NULL
+
+-- 为已有消息按发送时间补齐序号
+UPDATE "messages" m
+SET "seq" = s.rn, "change_seq" = s.rn
+FROM (
+    SELECT "message_id", ROW_NUMBER() OVER (PARTITION BY "room_id" ORDER BY "sent_at", "message_id") AS rn
+    FROM "messages"
+) s
+WHERE m."message_id" = s."message_id";
+
+-- 此前撤回的消息只保留了提示内容，撤回时间无法还原，以发送时间代替
+UPDATE "messages"
+SET "deleted_at" = "sent_at"
+WHERE "message_type" = 'system_notification' AND "content" = '该消息已被撤回';
+
+INSERT INTO "chatroom_message_seq" ("room_id", "last_seq")
+SELECT "room_id", MAX("seq") FROM "messages" GROUP BY "room_id";
+
+ALTER TABLE "messages"
+    ALTER COLUMN "seq" SET NOT NULL,
+    ALTER COLUMN "change_seq" SET NOT NULL;
+
+-- 分配下一个序号；计数器行锁持有到事务结束，保证同一聊天室的序号按提交顺序连续
+CREATE OR REPLACE FUNCTION nextRoomMessageSeq(p_room_id varchar)
+    RETURNS BIGINT AS $$
+DECLARE
+    next_seq BIGINT;
+BEGIN
+    INSERT INTO "chatroom_message_seq" ("room_id", "last_seq") VALUES (p_room_id, 1)
+    ON CONFLICT ("room_id") DO UPDATE SET "last_seq" = "chatroom_message_seq"."last_seq" + 1
+    RETURNING "last_seq" INTO next_seq;
+
+    RETURN next_seq;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION assignMessageSeq()
+    RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        NEW.seq := nextRoomMessageSeq(NEW.room_id);
+        NEW.change_seq := NEW.seq;
+    ELSE
+        NEW.seq := OLD.seq;
+        NEW.change_seq := nextRoomMessageSeq(NEW.room_id);
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- 仅内容相关列的变更分配新序号（如发送者注销导致 sender_id 置空不算变更）
+create trigger beforeWriteMessageSeq
+    before insert or update of "content", "message_type", "media_url", "deleted_at" on "messages"
+    for each row
+execute function assignMessageSeq();
+
+ALTER TABLE "messages" ADD CONSTRAINT "unique_messages_room_seq" UNIQUE ("room_id", "seq");
+CREATE INDEX "idx_messages_room_change_seq" ON "messages" ("room_id", "change_seq");
@@ -0,0 +1,2 @@
+ALTER TABLE "messages" DROP CONSTRAINT IF EXISTS "unique_messages_sender_client_msg_id";
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "client_msg_id";
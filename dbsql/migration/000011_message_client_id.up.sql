@@ -0,0 +1,10 @@
+-- ----------------------------
+-- 客户端消息ID (Client Message ID)
+-- ----------------------------
+
+-- 客户端生成的消息ID，网络中断后重试发送时据此返回已保存的消息，避免重复发送
+ALTER TABLE "messages"
+    ADD COLUMN "client_msg_id" varchar(64); -- 客户端消息ID，未提供为 NULL
+
+-- 同一发送者的客户端消息ID唯一（NULL 不参与唯一性比较）
+ALTER TABLE "messages" ADD CONSTRAINT "unique_messages_sender_client_msg_id" UNIQUE ("sender_id", "client_msg_id");
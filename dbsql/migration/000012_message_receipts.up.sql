@@ -0,0 +1,23 @@
+-- ----------------------------
+-- 消息送达与已读状态 (Message Receipts)
+-- ----------------------------
+
+-- 表: message_receipts (每条消息对每个接收者的投递状态，仅小型聊天室记录)
+-- 状态流转: sent（已保存）-> delivered（已写入接收者的 WebSocket 连接）-> read（接收者已读）
+CREATE TABLE "message_receipts" (
+                                    "message_id" varchar(21) NOT NULL,                             -- 消息编号
+                                    "user_id" varchar(10) NOT NULL,                                -- 接收者编号
+                                    "status" message_status NOT NULL DEFAULT 'sent',               -- 投递状态
+                                    "delivered_at" TIMESTAMPTZ,                                    -- 送达时间
+                                    "read_at" TIMESTAMPTZ,                                         -- 已读时间
+                                    PRIMARY KEY ("message_id", "user_id")
+);
+
+ALTER TABLE "message_receipts" ADD CONSTRAINT "fk_message_receipts_message"
+    FOREIGN KEY ("message_id") REFERENCES "messages"("message_id") ON DELETE CASCADE;
+
+ALTER TABLE "message_receipts" ADD CONSTRAINT "fk_message_receipts_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+-- 已读时按接收者查找尚未已读的记录
+CREATE INDEX "idx_message_receipts_user_status" ON "message_receipts" ("user_id", "status");
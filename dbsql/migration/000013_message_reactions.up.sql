@@ -0,0 +1,18 @@
+-- ----------------------------
+-- 消息表情回应 (Message Reactions)
+-- ----------------------------
+
+-- 表: message_reactions (每个用户对每条消息的每种表情最多一条)
+CREATE TABLE "message_reactions" (
+                                     "message_id" varchar(21) NOT NULL,                            -- 消息编号
+                                     "user_id" varchar(10) NOT NULL,                               -- 用户编号
+                                     "emoji" varchar(32) NOT NULL,                                 -- 表情
+                                     "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- 回应时间
+                                     PRIMARY KEY ("message_id", "user_id", "emoji")
+);
+
+ALTER TABLE "message_reactions" ADD CONSTRAINT "fk_message_reactions_message"
+    FOREIGN KEY ("message_id") REFERENCES "messages"("message_id") ON DELETE CASCADE;
+
+ALTER TABLE "message_reactions" ADD CONSTRAINT "fk_message_reactions_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "message_thread_followers" CASCADE;
+DROP TRIGGER IF EXISTS afterInsertThreadReply ON "messages";
+drop function updateThreadStats() cascade;
+DROP INDEX IF EXISTS "idx_messages_thread_root";
+ALTER TABLE "messages" DROP CONSTRAINT IF EXISTS "fk_messages_thread_root";
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "last_reply_at";
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "reply_count";
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "also_send_to_room";
+ALTER TABLE "messages" DROP COLUMN IF EXISTS "thread_root_id";
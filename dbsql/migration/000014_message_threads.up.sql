@@ -0,0 +1,48 @@
+-- ----------------------------
+-- 消息话题 (Message Threads)
+-- ----------------------------
+
+-- 话题回复指向话题根消息；未勾选"同时发送到聊天室"的回复只出现在话题中，不出现在聊天室时间线
+ALTER TABLE "messages"
+    ADD COLUMN "thread_root_id" varchar(21),                         -- 话题根消息编号，非话题回复为 NULL
+    ADD COLUMN "also_send_to_room" BOOLEAN NOT NULL DEFAULT FALSE,   -- 话题回复是否同时出现在聊天室时间线
+    ADD COLUMN "reply_count" INTEGER NOT NULL DEFAULT 0,             -- 话题回复数（仅根消息）
+    ADD COLUMN "last_reply_at" TIMESTAMPTZ;                          -- 最后回复时间（仅根消息）
+
+ALTER TABLE "messages" ADD CONSTRAINT "fk_messages_thread_root"
+    FOREIGN KEY ("thread_root_id") REFERENCES "messages"("message_id") ON DELETE CASCADE;
+
+CREATE INDEX "idx_messages_thread_root" ON "messages" ("thread_root_id", "seq") WHERE "thread_root_id" IS NOT NULL;
+
+-- 话题回复写入后更新根消息的回复数与最后回复时间
+CREATE OR REPLACE FUNCTION updateThreadStats()
+    RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE "messages"
+    SET "reply_count" = "reply_count" + 1,
+        "last_reply_at" = NEW.sent_at
+    WHERE "message_id" = NEW.thread_root_id;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+create trigger afterInsertThreadReply
+    after insert on "messages"
+    for each row
+    when (NEW.thread_root_id IS NOT NULL)
+execute function updateThreadStats();
+
+-- 表: message_thread_followers (话题关注者，关注者会收到话题内的新回复)
+CREATE TABLE "message_thread_followers" (
+                                            "root_message_id" varchar(21) NOT NULL,                       -- 话题根消息编号
+                                            "user_id" varchar(10) NOT NULL,                               -- 用户编号
+                                            "followed_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- 关注时间
+                                            PRIMARY KEY ("root_message_id", "user_id")
+);
+
+ALTER TABLE "message_thread_followers" ADD CONSTRAINT "fk_thread_followers_message"
+    FOREIGN KEY ("root_message_id") REFERENCES "messages"("message_id") ON DELETE CASCADE;
+
+ALTER TABLE "message_thread_followers" ADD CONSTRAINT "fk_thread_followers_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
@@ -0,0 +1,22 @@
+-- ----------------------------
+-- 置顶消息 (Pinned Messages)
+-- ----------------------------
+
+-- 表: pinned_messages (聊天室置顶消息，由管理员或房主设置)
+CREATE TABLE "pinned_messages" (
+                                   "message_id" varchar(21) primary key,                        -- 消息编号
+                                   "room_id" varchar(9) NOT NULL,                               -- 聊天室编号
+                                   "pinned_by" varchar(10),                                     -- 置顶操作者编号
+                                   "pinned_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP   -- 置顶时间
+);
+
+ALTER TABLE "pinned_messages" ADD CONSTRAINT "fk_pinned_messages_message"
+    FOREIGN KEY ("message_id") REFERENCES "messages"("message_id") ON DELETE CASCADE;
+
+ALTER TABLE "pinned_messages" ADD CONSTRAINT "fk_pinned_messages_room"
+    FOREIGN KEY ("room_id") REFERENCES "chatrooms"("room_id") ON DELETE CASCADE;
+
+ALTER TABLE "pinned_messages" ADD CONSTRAINT "fk_pinned_messages_operator"
+    FOREIGN KEY ("pinned_by") REFERENCES "users"("user_id") ON DELETE SET NULL;
+
+CREATE INDEX "idx_pinned_messages_room" ON "pinned_messages" ("room_id", "pinned_at" DESC);
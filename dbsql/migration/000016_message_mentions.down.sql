@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "message_mentions" CASCADE;
+DROP TYPE IF EXISTS mention_type;
@@ -0,0 +1,33 @@
+-- ----------------------------
+-- 消息提及 (Message Mentions)
+-- ----------------------------
+
+-- 提及方式
+CREATE TYPE mention_type AS ENUM (
+    'user',     -- @用户名
+    'all',      -- @all，仅管理员和房主可用
+    'admins'    -- @admins，提及所有管理员和房主
+    );
+
+-- 表: message_mentions (发送消息时解析出的被提及成员，每条消息每个成员一行)
+-- 提及的已读状态由成员在聊天室的已读位置 (chatroom_members.last_read_at) 决定
+CREATE TABLE "message_mentions" (
+                                    "message_id" varchar(21) NOT NULL,                           -- 消息编号
+                                    "user_id" varchar(10) NOT NULL,                              -- 被提及的成员编号
+                                    "room_id" varchar(9) NOT NULL,                               -- 聊天室编号
+                                    "mention_type" mention_type NOT NULL DEFAULT 'user',         -- 提及方式，同时命中多种时优先为 user
+                                    "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- 提及时间（即消息发送时间）
+                                    PRIMARY KEY ("message_id", "user_id")
+);
+
+ALTER TABLE "message_mentions" ADD CONSTRAINT "fk_message_mentions_message"
+    FOREIGN KEY ("message_id") REFERENCES "messages"("message_id") ON DELETE CASCADE;
+
+ALTER TABLE "message_mentions" ADD CONSTRAINT "fk_message_mentions_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+ALTER TABLE "message_mentions" ADD CONSTRAINT "fk_message_mentions_room"
+    FOREIGN KEY ("room_id") REFERENCES "chatrooms"("room_id") ON DELETE CASCADE;
+
+CREATE INDEX "idx_message_mentions_user" ON "message_mentions" ("user_id", "created_at" DESC);
+CREATE INDEX "idx_message_mentions_user_room" ON "message_mentions" ("user_id", "room_id", "created_at");
@@ -0,0 +1,13 @@
+-- ----------------------------
+-- 消息搜索 (Message Search)
+-- ----------------------------
+
+-- 使用 pg_trgm 三元组索引支持任意子串的 ILIKE 匹配与相似度排序。
+-- 中文没有空格分词，全文检索 (tsvector) 无法切分，三元组按字符切分可直接匹配中文子串。
+-- 注意：数据库的 LC_CTYPE 需为 UTF-8 区域（如 zh_CN.UTF-8、en_US.UTF-8），
+-- 在 C 区域下 pg_trgm 会忽略非 ASCII 字符，中文查询无法使用索引（结果仍然正确，只是退化为扫描）。
+-- 少于 3 个字符的关键词无法提取三元组，同样不使用该索引。
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX "idx_messages_content_trgm" ON "messages" USING gin ("content" gin_trgm_ops)
+    WHERE "deleted_at" IS NULL;
@@ -0,0 +1,4 @@
+-- PostgreSQL 不支持删除枚举值，回滚时删除私聊会话对应的聊天室，chatroom_type 中保留 'direct'
+DELETE FROM "chatrooms" WHERE "room_id" IN (SELECT "room_id" FROM "direct_conversations");
+DROP TABLE IF EXISTS "user_blocks" CASCADE;
+DROP TABLE IF EXISTS "direct_conversations" CASCADE;
@@ -0,0 +1,45 @@
+-- ----------------------------
+-- 私聊会话 (Direct Conversations)
+-- ----------------------------
+
+-- 私聊会话复用聊天室与消息的全部能力，房间类型为 direct，固定两名成员，没有房主和管理员。
+-- 注意：新增的枚举值不能在同一事务中使用，本迁移中不引用 'direct'
+ALTER TYPE chatroom_type ADD VALUE IF NOT EXISTS 'direct';
+
+-- 表: direct_conversations (私聊会话与两名成员的对应关系，每对用户最多一个会话)
+CREATE TABLE "direct_conversations" (
+                                        "room_id" varchar(9) PRIMARY KEY,                             -- 聊天室编号
+                                        "user_low" varchar(10) NOT NULL,                              -- 编号较小的成员
+                                        "user_high" varchar(10) NOT NULL,                             -- 编号较大的成员
+                                        "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- 创建时间
+                                        CONSTRAINT "unique_direct_conversation_pair" UNIQUE ("user_low", "user_high"),
+                                        CONSTRAINT "chk_direct_conversations_order" CHECK ("user_low" < "user_high")
+);
+
+ALTER TABLE "direct_conversations" ADD CONSTRAINT "fk_direct_conversations_room"
+    FOREIGN KEY ("room_id") REFERENCES "chatrooms"("room_id") ON DELETE CASCADE;
+
+ALTER TABLE "direct_conversations" ADD CONSTRAINT "fk_direct_conversations_user_low"
+    FOREIGN KEY ("user_low") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+ALTER TABLE "direct_conversations" ADD CONSTRAINT "fk_direct_conversations_user_high"
+    FOREIGN KEY ("user_high") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+CREATE INDEX "idx_direct_conversations_user_high" ON "direct_conversations" ("user_high");
+
+-- 表: user_blocks (用户屏蔽关系，单向；任一方屏蔽另一方后双方都不能在私聊中发送消息)
+CREATE TABLE "user_blocks" (
+                               "blocker_id" varchar(10) NOT NULL,                            -- 屏蔽者编号
+                               "blocked_id" varchar(10) NOT NULL,                            -- 被屏蔽者编号
+                               "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- 屏蔽时间
+                               PRIMARY KEY ("blocker_id", "blocked_id"),
+                               CONSTRAINT "chk_user_blocks_not_self" CHECK ("blocker_id" <> "blocked_id")
+);
+
+ALTER TABLE "user_blocks" ADD CONSTRAINT "fk_user_blocks_blocker"
+    FOREIGN KEY ("blocker_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+ALTER TABLE "user_blocks" ADD CONSTRAINT "fk_user_blocks_blocked"
+    FOREIGN KEY ("blocked_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+CREATE INDEX "idx_user_blocks_blocked_id" ON "user_blocks" ("blocked_id");
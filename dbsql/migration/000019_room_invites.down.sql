@@ -0,0 +1,3 @@
+ALTER TABLE "chatroom_members" DROP CONSTRAINT IF EXISTS "fk_chatroom_members_inviter";
+ALTER TABLE "chatroom_members" DROP COLUMN IF EXISTS "invited_by";
+DROP TABLE IF EXISTS "room_invites" CASCADE;
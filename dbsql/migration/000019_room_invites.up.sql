@@ -0,0 +1,36 @@
+-- ----------------------------
+-- 聊天室邀请 (Room Invites)
+-- ----------------------------
+
+-- 表: room_invites (邀请码，由管理员或房主创建，可设置有效期、使用次数与加入后的角色)
+CREATE TABLE "room_invites" (
+                                "invite_code" varchar(16) PRIMARY KEY,                        -- 邀请码
+                                "room_id" varchar(9) NOT NULL,                                -- 聊天室编号
+                                "created_by" varchar(10),                                     -- 创建者编号
+                                "member_role" member_role NOT NULL DEFAULT 'member',          -- 通过邀请加入后的角色
+                                "max_uses" INT,                                               -- 最大使用次数，NULL 表示不限
+                                "use_count" INT NOT NULL DEFAULT 0,                           -- 已使用次数
+                                "expires_at" TIMESTAMPTZ,                                     -- 过期时间，NULL 表示永不过期
+                                "revoked_at" TIMESTAMPTZ,                                     -- 撤销时间
+                                "revoked_by" varchar(10),                                     -- 撤销者编号
+                                "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- 创建时间
+                                CONSTRAINT "chk_room_invites_role" CHECK ("member_role" <> 'owner'),
+                                CONSTRAINT "chk_room_invites_max_uses" CHECK ("max_uses" IS NULL OR "max_uses" > 0)
+);
+
+ALTER TABLE "room_invites" ADD CONSTRAINT "fk_room_invites_room"
+    FOREIGN KEY ("room_id") REFERENCES "chatrooms"("room_id") ON DELETE CASCADE;
+
+ALTER TABLE "room_invites" ADD CONSTRAINT "fk_room_invites_creator"
+    FOREIGN KEY ("created_by") REFERENCES "users"("user_id") ON DELETE SET NULL;
+
+ALTER TABLE "room_invites" ADD CONSTRAINT "fk_room_invites_revoker"
+    FOREIGN KEY ("revoked_by") REFERENCES "users"("user_id") ON DELETE SET NULL;
+
+CREATE INDEX "idx_room_invites_room" ON "room_invites" ("room_id", "created_at" DESC);
+
+-- 成员关系记录邀请人（通过邀请码加入时为邀请码的创建者）
+ALTER TABLE "chatroom_members" ADD COLUMN "invited_by" varchar(10); -- 邀请人编号
+
+ALTER TABLE "chatroom_members" ADD CONSTRAINT "fk_chatroom_members_inviter"
+    FOREIGN KEY ("invited_by") REFERENCES "users"("user_id") ON DELETE SET NULL;
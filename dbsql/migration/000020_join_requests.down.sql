@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "room_join_requests" CASCADE;
+DROP FUNCTION IF EXISTS generateJoinRequestID();
+DROP SEQUENCE IF EXISTS JoinRequest_idSeq;
+ALTER TABLE "chatrooms" DROP COLUMN IF EXISTS "require_approval";
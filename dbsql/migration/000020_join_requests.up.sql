@@ -0,0 +1,54 @@
+-- ----------------------------
+-- 加入申请 (Join Requests)
+-- ----------------------------
+
+-- 开启后加入聊天室需要管理员或房主审核（通过邀请码加入不受影响）
+ALTER TABLE "chatrooms" ADD COLUMN "require_approval" BOOLEAN NOT NULL DEFAULT FALSE; -- 加入是否需要审核
+
+-- 表: room_join_requests (加入申请)
+CREATE SEQUENCE JoinRequest_idSeq
+    START WITH 1
+    INCREMENT BY 1
+    MINVALUE 1;
+CREATE OR REPLACE FUNCTION generateJoinRequestID()
+    RETURNS TRIGGER AS $$
+DECLARE
+    next_id BIGINT;
+BEGIN
+    next_id := nextval('JoinRequest_idSeq');
+
+    NEW.request_id := 'JR' || LPAD(next_id::text, 8, '0');
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE "room_join_requests" (
+                                      "request_id" varchar(10) primary key,                          -- 申请编号
+                                      "room_id" varchar(9) NOT NULL,                                 -- 聊天室编号
+                                      "user_id" varchar(10) NOT NULL,                                -- 申请人编号
+                                      "message" TEXT,                                                -- 附言
+                                      "status" VARCHAR(20) NOT NULL DEFAULT 'pending',               -- 状态: pending, approved, rejected
+                                      "created_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- 申请时间
+                                      "handled_by" varchar(10),                                      -- 处理人编号
+                                      "handled_at" TIMESTAMPTZ,                                      -- 处理时间
+                                      CONSTRAINT "chk_room_join_requests_status" CHECK ("status" IN ('pending', 'approved', 'rejected'))
+);
+create trigger beforeInsertJoinRequest
+    before insert on "room_join_requests"
+    for each row
+execute function generateJoinRequestID();
+
+ALTER TABLE "room_join_requests" ADD CONSTRAINT "fk_room_join_requests_room"
+    FOREIGN KEY ("room_id") REFERENCES "chatrooms"("room_id") ON DELETE CASCADE;
+
+ALTER TABLE "room_join_requests" ADD CONSTRAINT "fk_room_join_requests_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
+
+ALTER TABLE "room_join_requests" ADD CONSTRAINT "fk_room_join_requests_handler"
+    FOREIGN KEY ("handled_by") REFERENCES "users"("user_id") ON DELETE SET NULL;
+
+-- 同一用户在同一聊天室同一时间只能有一条待处理申请，处理后可再次申请
+CREATE UNIQUE INDEX "idx_room_join_requests_pending" ON "room_join_requests" ("room_id", "user_id")
+    WHERE "status" = 'pending';
+CREATE INDEX "idx_room_join_requests_room" ON "room_join_requests" ("room_id", "created_at");
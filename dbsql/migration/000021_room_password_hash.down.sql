@@ -0,0 +1,5 @@
+-- 删除密码错误记录表
+DROP TABLE IF EXISTS "room_password_attempts";
+
+-- 注意：bcrypt 哈希无法还原为明文，回滚后已哈希的访问密码保持不变，
+-- 受密码保护的聊天室需由管理员重新设置密码。
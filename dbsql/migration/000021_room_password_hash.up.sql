@@ -0,0 +1,31 @@
+-- ----------------------------
+-- 聊天室访问密码哈希 (Room Password Hash)
+-- ----------------------------
+
+-- access_password 改为保存 bcrypt 哈希，与 users.hashed_password 相同，校验在应用层完成。
+-- 使用 pgcrypto 的 crypt(..., gen_salt('bf', 10)) 将现有明文密码重新哈希，
+-- 生成的 $2a$ 哈希（cost 10，即 bcrypt.DefaultCost）可直接由 golang.org/x/crypto/bcrypt 校验。
+-- 已是 bcrypt 哈希的值保持不变，因此重复执行是安全的。
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+UPDATE "chatrooms"
+SET "access_password" = crypt("access_password", gen_salt('bf', 10))
+WHERE "access_password" IS NOT NULL
+  AND "access_password" !~ '^\$2[abxy]\$[0-9]{2}\$';
+
+-- 表: room_password_attempts (聊天室密码错误记录)
+-- 按聊天室与用户统计一段时间内的密码错误次数，超过上限后锁定一段时间
+CREATE TABLE "room_password_attempts" (
+                                          "room_id" varchar(9) NOT NULL,                                       -- 聊天室编号
+                                          "user_id" varchar(10) NOT NULL,                                      -- 用户编号
+                                          "failed_count" INT NOT NULL DEFAULT 0,                               -- 当前统计窗口内的错误次数
+                                          "window_started_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,  -- 统计窗口开始时间
+                                          "locked_until" TIMESTAMPTZ,                                          -- 锁定截止时间
+                                          PRIMARY KEY ("room_id", "user_id")
+);
+
+ALTER TABLE "room_password_attempts" ADD CONSTRAINT "fk_room_password_attempts_room"
+    FOREIGN KEY ("room_id") REFERENCES "chatrooms"("room_id") ON DELETE CASCADE;
+
+ALTER TABLE "room_password_attempts" ADD CONSTRAINT "fk_room_password_attempts_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
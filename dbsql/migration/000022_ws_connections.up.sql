@@ -0,0 +1,20 @@
+-- ----------------------------
+-- WebSocket 连接登记（多实例部署时的全局在线状态）
+-- ----------------------------
+
+-- 每个实例登记自己持有的连接，并定期刷新 last_seen_at；
+-- 用户的全局连接数在 0 与 1 之间变化时才设置在线/离线，长时间未刷新的连接（实例已退出）会被清理
+CREATE TABLE "ws_connections" (
+                                  "conn_id" varchar(64) NOT NULL,                                -- 连接编号（实例内唯一）
+                                  "user_id" varchar(10) NOT NULL,                                -- 用户编号
+                                  "instance_id" varchar(64) NOT NULL,                            -- 持有连接的服务实例编号
+                                  "connected_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- 连接时间
+                                  "last_seen_at" TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, -- 实例最近一次刷新时间
+                                  PRIMARY KEY ("instance_id", "conn_id")
+);
+
+CREATE INDEX "idx_ws_connections_user" ON "ws_connections" ("user_id");
+CREATE INDEX "idx_ws_connections_last_seen" ON "ws_connections" ("last_seen_at");
+
+ALTER TABLE "ws_connections" ADD CONSTRAINT "fk_ws_connections_user"
+    FOREIGN KEY ("user_id") REFERENCES "users"("user_id") ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_messages_content_bigrams";
+DROP FUNCTION IF EXISTS message_bigrams(text);
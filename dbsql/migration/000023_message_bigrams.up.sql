@@ -0,0 +1,17 @@
+-- ----------------------------
+-- 消息搜索二元组索引 (Message Search Bigrams)
+-- ----------------------------
+
+-- 三元组索引（000017）无法处理少于 3 个字符的关键词，而中文搜索多为两个字，会退化为全表扫描。
+-- message_bigrams 将内容转为小写后按字符切分为相邻两字的数组，表达式 GIN 索引支持 @> 包含查询：
+-- 关键词的所有二元组都出现在消息中是子串匹配的必要条件，再由 ILIKE 精确过滤。
+-- 与 pg_bigm 效果相近，但不依赖额外扩展。单个字符的关键词仍无法使用索引，应用层要求其限定聊天室。
+CREATE FUNCTION message_bigrams(content text) RETURNS text[]
+    LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+AS $$
+    SELECT COALESCE(array_agg(DISTINCT substr(lower(content), i, 2)), '{}')
+    FROM generate_series(1, char_length(content) - 1) AS i
+$$;
+
+CREATE INDEX "idx_messages_content_bigrams" ON "messages" USING gin (message_bigrams("content"))
+    WHERE "deleted_at" IS NULL;
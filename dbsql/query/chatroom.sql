@@ -14,9 +14,10 @@ INSERT INTO chatrooms (
     description,
     icon_url,
     room_type,
-    access_password
+    access_password,
+    require_approval
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6
 ) RETURNING 
     room_id,
     room_name,
@@ -28,7 +29,8 @@ INSERT INTO chatrooms (
     online_count,
     room_status,
     created_at,
-    last_active_at;
+    last_active_at,
+    require_approval;
 
 -- name: GetChatroomByID :one
 -- 获取聊天室详情 GET /chatrooms/:roomId
@@ -43,12 +45,31 @@ SELECT
     online_count,
     room_status,
     created_at,
-    last_active_at
+    last_active_at,
+    require_approval
 FROM chatrooms 
 WHERE room_id = $1 AND room_status = 'active';
 
+-- name: GetChatroomIncludingArchived :one
+-- 获取聊天室详情，包含已归档的聊天室（用于查看成员、退出等只读或离开操作）
+SELECT 
+    room_id,
+    room_name,
+    description,
+    icon_url,
+    room_type,
+    access_password,
+    member_count,
+    online_count,
+    room_status,
+    created_at,
+    last_active_at,
+    require_approval
+FROM chatrooms 
+WHERE room_id = $1 AND room_status IN ('active', 'archived');
+
 -- name: GetChatroomWithoutPassword :one
--- 获取聊天室详情（不含密码，用于公开展示）
+-- 获取聊天室详情（不含密码，用于公开展示），包含已归档的聊天室
 SELECT 
     room_id,
     room_name,
@@ -59,9 +80,10 @@ SELECT
     online_count,
     room_status,
     created_at,
-    last_active_at
+    last_active_at,
+    require_approval
 FROM chatrooms 
-WHERE room_id = $1 AND room_status = 'active';
+WHERE room_id = $1 AND room_status IN ('active', 'archived');
 
 -- name: UpdateChatroom :one
 -- 更新聊天室信息 PUT /chatrooms/:roomId
@@ -85,7 +107,14 @@ RETURNING
     online_count,
     room_status,
     created_at,
-    last_active_at;
+    last_active_at,
+    require_approval;
+
+-- name: SetChatroomRequireApproval :exec
+-- 设置加入是否需要审核 POST /chatroom/:roomid/update
+UPDATE chatrooms 
+SET require_approval = $2
+WHERE room_id = $1 AND room_status = 'active';
 
 -- name: DeleteChatroom :exec
 -- 删除聊天室（软删除）DELETE /chatrooms/:roomId
@@ -93,18 +122,26 @@ UPDATE chatrooms
 SET room_status = 'deleted'
 WHERE room_id = $1;
 
--- name: ArchiveChatroom :exec
--- 归档聊天室
+-- name: ArchiveChatroom :execrows
+-- 归档聊天室（只读），仅对正常状态的聊天室生效
 UPDATE chatrooms 
 SET room_status = 'archived'
-WHERE room_id = $1;
+WHERE room_id = $1 AND room_status = 'active';
+
+-- name: UnarchiveChatroom :execrows
+-- 取消归档，恢复为正常状态
+UPDATE chatrooms 
+SET 
+    room_status = 'active',
+    last_active_at = NOW()
+WHERE room_id = $1 AND room_status = 'archived';
 
--- name: VerifyChatroomPassword :one
--- 验证聊天室密码
+-- name: IsChatroomArchived :one
+-- 检查聊天室是否已归档
 SELECT EXISTS(
     SELECT 1 FROM chatrooms 
-    WHERE room_id = $1 AND access_password = $2 AND room_status = 'active'
-) AS is_valid;
+    WHERE room_id = $1 AND room_status = 'archived'
+) AS is_archived;
 
 -- name: IsChatroomPublic :one
 -- 检查聊天室是否为公开
@@ -117,7 +154,7 @@ WHERE room_id = $1 AND room_status = 'active';
 -- =============================================
 
 -- name: ListUserChatrooms :many
--- 获取用户的聊天室列表 GET /users/me/chatrooms
+-- 获取用户的聊天室列表 GET /users/me/chatrooms（包含已归档的聊天室）
 SELECT 
     cr.room_id,
     cr.room_name,
@@ -138,7 +175,7 @@ SELECT
     cm.is_active
 FROM chatrooms cr
 JOIN chatroom_members cm ON cr.room_id = cm.room_id
-WHERE cm.user_id = $1 AND cm.is_active = true AND cr.room_status = 'active'
+WHERE cm.user_id = $1 AND cm.is_active = true AND cr.room_status IN ('active', 'archived')
 ORDER BY cr.last_active_at DESC NULLS LAST
 LIMIT $2 OFFSET $3;
 
@@ -147,7 +184,7 @@ LIMIT $2 OFFSET $3;
 SELECT COUNT(*) 
 FROM chatrooms cr
 JOIN chatroom_members cm ON cr.room_id = cm.room_id
-WHERE cm.user_id = $1 AND cm.is_active = true AND cr.room_status = 'active';
+WHERE cm.user_id = $1 AND cm.is_active = true AND cr.room_status IN ('active', 'archived');
 
 -- name: ListPublicChatrooms :many
 -- 获取公开聊天室列表
@@ -194,20 +231,22 @@ LIMIT $2 OFFSET $3;
 -- =============================================
 
 -- name: JoinChatroom :one
--- 加入聊天室 POST /chatrooms/:roomId/join
+-- 加入聊天室 POST /chatrooms/:roomId/join（invited_by 为邀请人，直接加入时为 NULL）
 INSERT INTO chatroom_members (
     user_id,
     room_id,
-    member_role
+    member_role,
+    invited_by
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4
 ) 
 ON CONFLICT (user_id, room_id) 
 DO UPDATE SET 
     is_active = true,
     joined_at = NOW(),
     left_at = NULL,
-    member_role = EXCLUDED.member_role
+    member_role = EXCLUDED.member_role,
+    invited_by = EXCLUDED.invited_by
 RETURNING 
     member_rel_id,
     user_id,
@@ -218,7 +257,8 @@ RETURNING
     member_role,
     mute_status,
     mute_expires_at,
-    is_active;
+    is_active,
+    invited_by;
 
 -- name: LeaveChatroom :exec
 -- 退出聊天室 POST /chatrooms/:roomId/leave
@@ -248,7 +288,8 @@ SELECT
     member_role,
     mute_status,
     mute_expires_at,
-    is_active
+    is_active,
+    invited_by
 FROM chatroom_members 
 WHERE user_id = $1 AND room_id = $2;
 
@@ -264,7 +305,8 @@ SELECT
     member_role,
     mute_status,
     mute_expires_at,
-    is_active
+    is_active,
+    invited_by
 FROM chatroom_members 
 WHERE user_id = $1 AND room_id = $2 AND is_active = true;
 
@@ -287,7 +329,8 @@ SELECT
     member_role,
     mute_status,
     mute_expires_at,
-    is_active
+    is_active,
+    invited_by
 FROM chatroom_members 
 WHERE member_rel_id = $1;
 
@@ -309,9 +352,11 @@ SELECT
     cm.mute_status,
     cm.mute_expires_at,
     cm.last_read_at,
-    cm.is_active
+    cm.is_active,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
 FROM chatroom_members cm
 JOIN users u ON cm.user_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE cm.room_id = $1 AND cm.is_active = true
 ORDER BY 
     CASE cm.member_role
@@ -368,9 +413,11 @@ SELECT
     u.username,
     u.nickname,
     u.avatar_url,
-    u.online_status
+    u.online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
 FROM chatroom_members cm
 JOIN users u ON cm.user_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE cm.room_id = $1 
     AND cm.is_active = true
     AND (
@@ -454,15 +501,45 @@ UPDATE chatroom_members
 SET member_role = 'member'
 WHERE user_id = $1 AND room_id = $2 AND is_active = true;
 
--- name: TransferOwnership :exec
--- 转让房主
+-- name: TransferOwnership :execrows
+-- 转让房主：原房主 $1 降为管理员，$2 成为房主。
+-- $1 须仍是房主且两人都须为有效成员，影响行数不为 2 时调用方应回滚事务
 UPDATE chatroom_members 
 SET member_role = CASE 
-    WHEN user_id = $1 THEN 'member'
+    WHEN user_id = $1 THEN 'admin'
     WHEN user_id = $2 THEN 'owner'
     ELSE member_role
 END
-WHERE room_id = $3 AND user_id IN ($1, $2) AND is_active = true;
+WHERE room_id = $3 AND user_id IN ($1, $2) AND is_active = true
+    AND EXISTS (
+        SELECT 1 FROM chatroom_members o
+        WHERE o.room_id = $3 AND o.user_id = $1 AND o.member_role = 'owner' AND o.is_active = true
+    );
+
+-- name: GetLongestTenuredAdmin :one
+-- 获取加入最早的管理员（账号状态正常），用于房主退出或注销时自动接任
+SELECT cm.user_id
+FROM chatroom_members cm
+JOIN users u ON cm.user_id = u.user_id
+WHERE cm.room_id = $1
+    AND cm.member_role = 'admin'
+    AND cm.is_active = true
+    AND u.account_status = 'active'
+ORDER BY cm.joined_at ASC, cm.member_rel_id ASC
+LIMIT 1;
+
+-- name: ListOwnedChatrooms :many
+-- 获取用户担任房主的聊天室（不含已删除的聊天室）
+SELECT 
+    c.room_id,
+    c.room_name
+FROM chatroom_members cm
+JOIN chatrooms c ON cm.room_id = c.room_id
+WHERE cm.user_id = $1
+    AND cm.member_role = 'owner'
+    AND cm.is_active = true
+    AND c.room_status <> 'deleted'
+ORDER BY c.room_id;
 
 -- name: GetMemberRole :one
 -- 获取成员角色
@@ -548,11 +625,14 @@ UPDATE chatroom_members
 SET last_read_at = NOW()
 WHERE user_id = $1 AND room_id = $2 AND is_active = true;
 
--- name: UpdateMemberLastReadToMessage :exec
--- 更新最后阅读到指定消息
-UPDATE chatroom_members 
-SET last_read_at = (SELECT sent_at FROM messages WHERE message_id = $3)
-WHERE user_id = $1 AND room_id = $2 AND is_active = true;
+-- name: UpdateMemberLastReadToMessage :one
+-- 更新最后阅读到指定消息（消息须属于该聊天室，已读位置只前进不后退）
+UPDATE chatroom_members cm
+SET last_read_at = GREATEST(cm.last_read_at, m.sent_at)
+FROM messages m
+WHERE m.message_id = sqlc.arg(message_id) AND m.room_id = cm.room_id
+    AND cm.user_id = sqlc.arg(user_id) AND cm.room_id = sqlc.arg(room_id) AND cm.is_active = true
+RETURNING cm.last_read_at, m.sent_at;
 
 -- name: GetMemberLastReadTime :one
 -- 获取成员最后阅读时间
@@ -588,6 +668,23 @@ UPDATE chatrooms
 SET online_count = GREATEST(online_count - 1, 0)
 WHERE room_id = $1;
 
+-- name: SyncUserChatroomsOnlineCount :exec
+-- 重新统计用户所在聊天室的在线人数（用户上线、离线或修改在线状态可见性后调用，各实例重复执行结果相同）。
+-- 关闭了"显示在线状态"的成员不计入
+UPDATE chatrooms 
+SET online_count = (
+    SELECT COUNT(*) FROM chatroom_members cm
+    JOIN users u ON cm.user_id = u.user_id
+    LEFT JOIN user_settings us ON us.user_id = u.user_id
+    WHERE cm.room_id = chatrooms.room_id AND cm.is_active = true 
+    AND u.online_status IN ('online', 'away', 'do_not_disturb')
+    AND COALESCE(us.show_online_status, true)
+)
+WHERE room_id IN (
+    SELECT room_id FROM chatroom_members 
+    WHERE user_id = $1 AND is_active = true
+);
+
 -- name: UpdateChatroomLastActiveTime :exec
 -- 更新最后活跃时间
 UPDATE chatrooms 
@@ -604,12 +701,14 @@ SET member_count = (
 WHERE room_id = $1;
 
 -- name: SyncChatroomOnlineCount :exec
--- 同步在线人数（用于数据修复）
+-- 同步在线人数（用于数据修复），关闭了"显示在线状态"的成员不计入
 UPDATE chatrooms 
 SET online_count = (
     SELECT COUNT(*) FROM chatroom_members cm
     JOIN users u ON cm.user_id = u.user_id
+    LEFT JOIN user_settings us ON us.user_id = u.user_id
     WHERE cm.room_id = chatrooms.room_id AND cm.is_active = true 
     AND u.online_status IN ('online', 'away', 'do_not_disturb')
+    AND COALESCE(us.show_online_status, true)
 )
 WHERE room_id = $1;
\ No newline at end of file
@@ -0,0 +1,54 @@
+-- =============================================
+-- 私聊会话相关SQL查询 (Direct Conversation Queries)
+-- 对应API: POST /users/:userid/dm
+-- 表结构见 migration 000018_direct_conversations
+-- =============================================
+
+-- name: CreateDirectConversation :execrows
+-- 记录私聊会话，两名成员已有会话时不做处理（返回 0）
+INSERT INTO direct_conversations (
+    room_id,
+    user_low,
+    user_high
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_low, user_high) DO NOTHING;
+
+-- name: GetDirectConversation :one
+-- 获取两名成员之间的私聊会话（user_low < user_high）
+SELECT 
+    room_id,
+    user_low,
+    user_high,
+    created_at
+FROM direct_conversations 
+WHERE user_low = $1 AND user_high = $2;
+
+-- name: IsDirectChatroom :one
+-- 检查聊天室是否为私聊会话
+SELECT EXISTS(
+    SELECT 1 FROM direct_conversations WHERE room_id = $1
+) AS is_direct;
+
+-- name: GetDirectConversationPeer :one
+-- 获取私聊会话中另一名成员的信息
+SELECT 
+    u.user_id,
+    u.username,
+    u.nickname,
+    u.avatar_url
+FROM direct_conversations d
+JOIN users u ON u.user_id = CASE WHEN d.user_low = sqlc.arg(user_id) THEN d.user_high ELSE d.user_low END
+WHERE d.room_id = sqlc.arg(room_id)
+    AND (d.user_low = sqlc.arg(user_id) OR d.user_high = sqlc.arg(user_id));
+
+-- name: IsDirectConversationBlocked :one
+-- 检查私聊会话的任一成员是否屏蔽了另一方（非私聊会话返回 false）
+SELECT EXISTS(
+    SELECT 1 
+    FROM direct_conversations d
+    JOIN user_blocks b ON (b.blocker_id = d.user_low AND b.blocked_id = d.user_high)
+        OR (b.blocker_id = d.user_high AND b.blocked_id = d.user_low)
+    WHERE d.room_id = $1
+) AS is_blocked;
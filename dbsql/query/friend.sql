@@ -1,31 +1,9 @@
 -- =============================================
 -- 好友关系相关SQL查询 (Friend Queries)
 -- 对应API: 好友关系接口
--- 注意: 需要先创建 friends 和 friend_requests 表
+-- 表结构见 migration 000005_friends
 -- =============================================
 
--- =============================================
--- 表结构参考 (需要在migration中创建)
--- =============================================
--- CREATE TABLE friend_requests (
---     request_id VARCHAR(10) PRIMARY KEY,
---     sender_id VARCHAR(10) NOT NULL REFERENCES users(user_id),
---     receiver_id VARCHAR(10) NOT NULL REFERENCES users(user_id),
---     message TEXT,
---     status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, accepted, rejected
---     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
---     handled_at TIMESTAMPTZ,
---     CONSTRAINT unique_friend_request UNIQUE (sender_id, receiver_id)
--- );
--- 
--- CREATE TABLE friends (
---     id SERIAL PRIMARY KEY,
---     user_id VARCHAR(10) NOT NULL REFERENCES users(user_id),
---     friend_id VARCHAR(10) NOT NULL REFERENCES users(user_id),
---     friend_since TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
---     CONSTRAINT unique_friendship UNIQUE (user_id, friend_id)
--- );
-
 -- =============================================
 -- 1. 好友请求操作 (Friend Request Operations)
 -- =============================================
@@ -128,12 +106,22 @@ SELECT
     u.username AS sender_username,
     u.nickname AS sender_nickname,
     u.avatar_url AS sender_avatar,
-    u.online_status AS sender_online_status
+    u.online_status AS sender_online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
 FROM friend_requests fr
 JOIN users u ON fr.sender_id = u.user_id
-WHERE fr.receiver_id = $1
+LEFT JOIN user_settings us ON us.user_id = u.user_id
+WHERE fr.receiver_id = sqlc.arg(receiver_id)
+    AND (sqlc.arg(status)::text = '' OR fr.status = sqlc.arg(status)::text)
 ORDER BY fr.created_at DESC
-LIMIT $2 OFFSET $3;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountReceivedFriendRequests :one
+-- 统计收到的好友请求数量（status 为空时不过滤）
+SELECT COUNT(*) 
+FROM friend_requests 
+WHERE receiver_id = sqlc.arg(receiver_id)
+    AND (sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text);
 
 -- name: GetSentFriendRequests :many
 -- 获取发送的好友请求 GET /users/me/friend-requests?type=sent
@@ -148,12 +136,22 @@ SELECT
     u.username AS receiver_username,
     u.nickname AS receiver_nickname,
     u.avatar_url AS receiver_avatar,
-    u.online_status AS receiver_online_status
+    u.online_status AS receiver_online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
 FROM friend_requests fr
 JOIN users u ON fr.receiver_id = u.user_id
-WHERE fr.sender_id = $1
+LEFT JOIN user_settings us ON us.user_id = u.user_id
+WHERE fr.sender_id = sqlc.arg(sender_id)
+    AND (sqlc.arg(status)::text = '' OR fr.status = sqlc.arg(status)::text)
 ORDER BY fr.created_at DESC
-LIMIT $2 OFFSET $3;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountSentFriendRequests :one
+-- 统计发送的好友请求数量（status 为空时不过滤）
+SELECT COUNT(*) 
+FROM friend_requests 
+WHERE sender_id = sqlc.arg(sender_id)
+    AND (sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text);
 
 -- name: GetPendingReceivedRequests :many
 -- 获取待处理的收到的好友请求
@@ -168,9 +166,11 @@ SELECT
     u.username AS sender_username,
     u.nickname AS sender_nickname,
     u.avatar_url AS sender_avatar,
-    u.online_status AS sender_online_status
+    u.online_status AS sender_online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
 FROM friend_requests fr
 JOIN users u ON fr.sender_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE fr.receiver_id = $1 AND fr.status = 'pending'
 ORDER BY fr.created_at DESC
 LIMIT $2 OFFSET $3;
@@ -216,15 +216,17 @@ SELECT
     u.nickname,
     u.avatar_url,
     u.bio,
-    u.online_status
+    u.online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
 FROM friends f
 JOIN users u ON f.friend_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE f.user_id = $1 AND u.account_status = 'active'
-ORDER BY u.online_status DESC, f.friend_since DESC
+ORDER BY CASE WHEN COALESCE(us.show_online_status, true) THEN u.online_status ELSE 'offline' END DESC, f.friend_since DESC
 LIMIT $2 OFFSET $3;
 
 -- name: GetOnlineFriends :many
--- 获取在线好友列表 GET /users/me/friends?status=online
+-- 获取在线好友列表（不含隐藏在线状态的好友）
 SELECT 
     f.friend_id,
     f.friend_since,
@@ -232,15 +234,42 @@ SELECT
     u.nickname,
     u.avatar_url,
     u.bio,
-    u.online_status
+    u.online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
 FROM friends f
 JOIN users u ON f.friend_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE f.user_id = $1 
     AND u.account_status = 'active'
     AND u.online_status IN ('online', 'away', 'do_not_disturb')
+    AND COALESCE(us.show_online_status, true)
 ORDER BY f.friend_since DESC
 LIMIT $2 OFFSET $3;
 
+-- name: GetFriendsByStatus :many
+-- 按在线状态获取好友列表 GET /users/me/friends?status=online|away|offline
+-- 隐藏在线状态的好友视为 offline
+SELECT 
+    f.friend_id,
+    f.friend_since,
+    u.username,
+    u.nickname,
+    u.avatar_url,
+    u.bio,
+    u.online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
+FROM friends f
+JOIN users u ON f.friend_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
+WHERE f.user_id = $1 
+    AND u.account_status = 'active'
+    AND (
+        (COALESCE(us.show_online_status, true) AND u.online_status = $2)
+        OR (NOT COALESCE(us.show_online_status, true) AND $2 = 'offline')
+    )
+ORDER BY f.friend_since DESC
+LIMIT $3 OFFSET $4;
+
 -- name: CountFriends :one
 -- 统计好友数量
 SELECT COUNT(*) 
@@ -253,9 +282,24 @@ WHERE f.user_id = $1 AND u.account_status = 'active';
 SELECT COUNT(*) 
 FROM friends f
 JOIN users u ON f.friend_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE f.user_id = $1 
     AND u.account_status = 'active'
-    AND u.online_status IN ('online', 'away', 'do_not_disturb');
+    AND u.online_status IN ('online', 'away', 'do_not_disturb')
+    AND COALESCE(us.show_online_status, true);
+
+-- name: CountFriendsByStatus :one
+-- 按在线状态统计好友数量
+SELECT COUNT(*) 
+FROM friends f
+JOIN users u ON f.friend_id = u.user_id
+LEFT JOIN user_settings us ON us.user_id = u.user_id
+WHERE f.user_id = $1 
+    AND u.account_status = 'active'
+    AND (
+        (COALESCE(us.show_online_status, true) AND u.online_status = $2)
+        OR (NOT COALESCE(us.show_online_status, true) AND $2 = 'offline')
+    );
 
 -- name: SearchFriends :many
 -- 搜索好友
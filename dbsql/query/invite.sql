@@ -0,0 +1,109 @@
+-- =============================================
+-- 聊天室邀请相关SQL查询 (Room Invite Queries)
+-- 对应API: /chatroom/:roomid/invites, POST /chatroom/joinroom
+-- 表结构见 migration 000019_room_invites
+-- =============================================
+
+-- name: CreateRoomInvite :one
+-- 创建邀请码
+INSERT INTO room_invites (
+    invite_code,
+    room_id,
+    created_by,
+    member_role,
+    max_uses,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING 
+    invite_code,
+    room_id,
+    created_by,
+    member_role,
+    max_uses,
+    use_count,
+    expires_at,
+    revoked_at,
+    revoked_by,
+    created_at;
+
+-- name: GetRoomInvite :one
+-- 通过邀请码获取邀请
+SELECT 
+    invite_code,
+    room_id,
+    created_by,
+    member_role,
+    max_uses,
+    use_count,
+    expires_at,
+    revoked_at,
+    revoked_by,
+    created_at
+FROM room_invites 
+WHERE invite_code = $1;
+
+-- name: ListRoomInvites :many
+-- 获取聊天室的邀请列表（最新的在前），include_inactive 为 false 时仅返回仍可使用的邀请
+SELECT 
+    i.invite_code,
+    i.room_id,
+    i.created_by,
+    i.member_role,
+    i.max_uses,
+    i.use_count,
+    i.expires_at,
+    i.revoked_at,
+    i.revoked_by,
+    i.created_at,
+    u.username,
+    u.nickname
+FROM room_invites i
+LEFT JOIN users u ON i.created_by = u.user_id
+WHERE i.room_id = sqlc.arg(room_id)
+    AND (sqlc.arg(include_inactive)::boolean OR (
+        i.revoked_at IS NULL
+        AND (i.expires_at IS NULL OR i.expires_at > NOW())
+        AND (i.max_uses IS NULL OR i.use_count < i.max_uses)
+    ))
+ORDER BY i.created_at DESC;
+
+-- name: RevokeRoomInvite :one
+-- 撤销邀请，已撤销的邀请不做处理（无返回行）
+UPDATE room_invites 
+SET 
+    revoked_at = NOW(),
+    revoked_by = $3
+WHERE invite_code = $1 AND room_id = $2 AND revoked_at IS NULL
+RETURNING 
+    invite_code,
+    room_id,
+    created_by,
+    member_role,
+    max_uses,
+    use_count,
+    expires_at,
+    revoked_at,
+    revoked_by,
+    created_at;
+
+-- name: RedeemRoomInvite :one
+-- 使用邀请，邀请已撤销、过期或次数用尽时无返回行（并发使用时不会超出次数）
+UPDATE room_invites 
+SET use_count = use_count + 1
+WHERE invite_code = $1 
+    AND room_id = $2
+    AND revoked_at IS NULL
+    AND (expires_at IS NULL OR expires_at > NOW())
+    AND (max_uses IS NULL OR use_count < max_uses)
+RETURNING 
+    invite_code,
+    room_id,
+    created_by,
+    member_role,
+    max_uses,
+    use_count,
+    expires_at,
+    revoked_at,
+    revoked_by,
+    created_at;
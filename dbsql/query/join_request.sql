@@ -0,0 +1,78 @@
+-- =============================================
+-- 加入申请相关SQL查询 (Join Request Queries)
+-- 对应API: POST /chatroom/joinroom, /chatroom/:roomid/join-requests
+-- 表结构见 migration 000020_join_requests
+-- =============================================
+
+-- name: CreateJoinRequest :one
+-- 提交加入申请
+INSERT INTO room_join_requests (
+    room_id,
+    user_id,
+    message
+) VALUES (
+    $1, $2, $3
+) RETURNING 
+    request_id,
+    room_id,
+    user_id,
+    message,
+    status,
+    created_at,
+    handled_by,
+    handled_at;
+
+-- name: GetPendingJoinRequest :one
+-- 获取用户在聊天室的待处理申请
+SELECT 
+    request_id,
+    room_id,
+    user_id,
+    message,
+    status,
+    created_at,
+    handled_by,
+    handled_at
+FROM room_join_requests 
+WHERE room_id = $1 AND user_id = $2 AND status = 'pending';
+
+-- name: ListPendingJoinRequests :many
+-- 获取聊天室的待处理申请（最早的在前）GET /chatroom/:roomid/join-requests
+SELECT 
+    r.request_id,
+    r.room_id,
+    r.user_id,
+    r.message,
+    r.status,
+    r.created_at,
+    u.username,
+    u.nickname,
+    u.avatar_url
+FROM room_join_requests r
+JOIN users u ON r.user_id = u.user_id
+WHERE r.room_id = $1 AND r.status = 'pending'
+ORDER BY r.created_at ASC
+LIMIT $2 OFFSET $3;
+
+-- name: CountPendingJoinRequests :one
+-- 统计聊天室的待处理申请数
+SELECT COUNT(*) FROM room_join_requests 
+WHERE room_id = $1 AND status = 'pending';
+
+-- name: HandleJoinRequest :one
+-- 处理加入申请（approved / rejected），已处理的申请不返回行
+UPDATE room_join_requests 
+SET 
+    status = $3,
+    handled_by = $4,
+    handled_at = NOW()
+WHERE request_id = $1 AND room_id = $2 AND status = 'pending'
+RETURNING 
+    request_id,
+    room_id,
+    user_id,
+    message,
+    status,
+    created_at,
+    handled_by,
+    handled_at;
@@ -0,0 +1,95 @@
+-- =============================================
+-- 消息提及相关SQL查询 (Message Mention Queries)
+-- 对应API: @提及、提及收件箱
+-- 表结构见 migration 000016_message_mentions
+-- 提及的已读状态由成员在聊天室的已读位置 (chatroom_members.last_read_at) 决定
+-- =============================================
+
+-- name: CreateMessageMentions :many
+-- 将消息中的提及解析为聊天室有效成员并保存（不含发送者本人），返回被提及的成员及提及方式
+-- 同时命中多种提及方式时优先为 user；提及时间取消息发送时间，与已读位置比较
+INSERT INTO message_mentions (
+    message_id,
+    user_id,
+    room_id,
+    mention_type,
+    created_at
+)
+SELECT 
+    m.message_id,
+    cm.user_id,
+    cm.room_id,
+    (CASE 
+        WHEN u.username = ANY(sqlc.arg(usernames)::varchar[]) THEN 'user'
+        WHEN sqlc.arg(mention_all)::boolean THEN 'all'
+        ELSE 'admins'
+    END)::mention_type,
+    m.sent_at
+FROM messages m
+JOIN chatroom_members cm ON cm.room_id = m.room_id AND cm.is_active = true
+JOIN users u ON u.user_id = cm.user_id
+WHERE m.message_id = sqlc.arg(message_id)
+    AND cm.user_id IS DISTINCT FROM m.sender_id
+    AND (
+        u.username = ANY(sqlc.arg(usernames)::varchar[])
+        OR sqlc.arg(mention_all)::boolean
+        OR (sqlc.arg(mention_admins)::boolean AND cm.member_role IN ('owner', 'admin'))
+    )
+ON CONFLICT (message_id, user_id) DO NOTHING
+RETURNING user_id, mention_type;
+
+-- name: GetUserMentions :many
+-- 获取提及当前用户的消息 GET /users/me/mentions?roomId=&status=
+-- room_id 为空时不过滤聊天室；status: unread | 空（全部）。不含已撤回的消息和已退出的聊天室
+SELECT 
+    mm.message_id,
+    mm.room_id,
+    mm.mention_type,
+    mm.created_at,
+    m.content,
+    m.message_type,
+    m.sender_id,
+    m.seq,
+    m.thread_root_id,
+    u.username,
+    u.nickname,
+    u.avatar_url,
+    c.room_name,
+    (mm.created_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ))::boolean AS is_unread
+FROM message_mentions mm
+JOIN messages m ON m.message_id = mm.message_id
+JOIN chatrooms c ON c.room_id = mm.room_id
+JOIN chatroom_members cm ON cm.room_id = mm.room_id AND cm.user_id = mm.user_id AND cm.is_active = true
+LEFT JOIN users u ON m.sender_id = u.user_id
+WHERE mm.user_id = sqlc.arg(user_id)
+    AND m.deleted_at IS NULL
+    AND (sqlc.arg(room_id)::text = '' OR mm.room_id = sqlc.arg(room_id)::text)
+    AND (sqlc.arg(status)::text = ''
+        OR (sqlc.arg(status)::text = 'unread' AND mm.created_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ)))
+ORDER BY mm.created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountUserMentions :one
+-- 统计提及当前用户的消息数（过滤条件同 GetUserMentions）
+SELECT COUNT(*) 
+FROM message_mentions mm
+JOIN messages m ON m.message_id = mm.message_id
+JOIN chatroom_members cm ON cm.room_id = mm.room_id AND cm.user_id = mm.user_id AND cm.is_active = true
+WHERE mm.user_id = sqlc.arg(user_id)
+    AND m.deleted_at IS NULL
+    AND (sqlc.arg(room_id)::text = '' OR mm.room_id = sqlc.arg(room_id)::text)
+    AND (sqlc.arg(status)::text = ''
+        OR (sqlc.arg(status)::text = 'unread' AND mm.created_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ)));
+
+-- name: GetUserUnreadMentionCountsInAllRooms :many
+-- 获取用户在各聊天室的未读提及数（仅返回有未读提及的聊天室，不含已撤回的消息）
+SELECT 
+    mm.room_id,
+    COUNT(*) AS unread_count
+FROM message_mentions mm
+JOIN messages m ON m.message_id = mm.message_id
+JOIN chatroom_members cm ON cm.room_id = mm.room_id AND cm.user_id = mm.user_id AND cm.is_active = true
+WHERE mm.user_id = $1
+    AND m.deleted_at IS NULL
+    AND mm.created_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ)
+GROUP BY mm.room_id;
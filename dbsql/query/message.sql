@@ -14,9 +14,13 @@ INSERT INTO messages (
     message_type,
     quoted_message_id,
     sender_id,
-    room_id
+    room_id,
+    media_url,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING 
     message_id,
     sent_at,
@@ -24,7 +28,18 @@ INSERT INTO messages (
     message_type,
     quoted_message_id,
     sender_id,
-    room_id;
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at;
 
 -- name: GetMessageByID :one
 -- 获取单条消息
@@ -35,10 +50,45 @@ SELECT
     message_type,
     quoted_message_id,
     sender_id,
-    room_id
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at
 FROM messages 
 WHERE message_id = $1;
 
+-- name: GetMessageByClientMsgID :one
+-- 按客户端消息ID获取发送者已发送的消息，用于重试发送时的幂等处理
+SELECT 
+    message_id,
+    sent_at,
+    content,
+    message_type,
+    quoted_message_id,
+    sender_id,
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at
+FROM messages 
+WHERE sender_id = $1 AND client_msg_id = $2;
+
 -- name: GetMessageWithSender :one
 -- 获取消息及发送者信息
 SELECT 
@@ -49,6 +99,17 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
@@ -57,12 +118,14 @@ LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.message_id = $1;
 
 -- name: UpdateMessage :one
--- 编辑消息 PUT /chatrooms/:roomId/messages/:messageId
+-- 编辑消息 POST /chatrooms/:roomId/messages/:messageId/edit
+-- 发送时间保持不变，记录最后编辑时间并递增编辑次数；已撤回的消息不会被更新
 UPDATE messages 
 SET 
     content = $2,
-    sent_at = NOW()
-WHERE message_id = $1
+    edited_at = NOW(),
+    edit_count = edit_count + 1
+WHERE message_id = $1 AND deleted_at IS NULL
 RETURNING 
     message_id,
     sent_at,
@@ -70,7 +133,18 @@ RETURNING
     message_type,
     quoted_message_id,
     sender_id,
-    room_id;
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at;
 
 -- name: DeleteMessage :exec
 -- 删除消息 DELETE /chatrooms/:roomId/messages/:messageId
@@ -78,12 +152,14 @@ DELETE FROM messages
 WHERE message_id = $1;
 
 -- name: DeleteMessageSoft :one
--- 软删除消息（将内容置为系统消息提示）
+-- 软删除消息（将内容置为系统消息提示，已撤回的消息不会重复处理）
 UPDATE messages 
 SET 
     content = '该消息已被撤回',
-    message_type = 'system_notification'
-WHERE message_id = $1
+    message_type = 'system_notification',
+    media_url = NULL,
+    deleted_at = NOW()
+WHERE message_id = $1 AND deleted_at IS NULL
 RETURNING 
     message_id,
     sent_at,
@@ -91,7 +167,62 @@ RETURNING
     message_type,
     quoted_message_id,
     sender_id,
-    room_id;
+    room_id,
+    edited_at,
+    edit_count,
+    media_url,
+    seq,
+    change_seq,
+    deleted_at,
+    client_msg_id,
+    thread_root_id,
+    also_send_to_room,
+    reply_count,
+    last_reply_at;
+
+-- name: CreateMessageEdit :one
+-- 记录消息修订（须与 UpdateMessage 在同一事务中、且在其之前执行，以保存编辑前内容）
+-- 并发编辑同一消息时，修订号唯一约束保证只有一方成功
+INSERT INTO message_edits (
+    message_id,
+    revision,
+    old_content,
+    new_content,
+    edited_by
+)
+SELECT 
+    message_id,
+    edit_count + 1,
+    content,
+    sqlc.arg(new_content)::text,
+    sqlc.arg(edited_by)
+FROM messages
+WHERE message_id = sqlc.arg(message_id) AND deleted_at IS NULL
+RETURNING 
+    id,
+    message_id,
+    revision,
+    old_content,
+    new_content,
+    edited_by,
+    edited_at;
+
+-- name: GetMessageEdits :many
+-- 获取消息修订历史（按修订号正序）
+SELECT 
+    e.id,
+    e.message_id,
+    e.revision,
+    e.old_content,
+    e.new_content,
+    e.edited_by,
+    e.edited_at,
+    u.username,
+    u.nickname
+FROM message_edits e
+LEFT JOIN users u ON e.edited_by = u.user_id
+WHERE e.message_id = $1
+ORDER BY e.revision ASC;
 
 -- =============================================
 -- 2. 消息列表查询 (Message List Queries)
@@ -99,6 +230,7 @@ RETURNING
 
 -- name: GetMessagesByRoom :many
 -- 获取聊天室消息历史 GET /chatrooms/:roomId/messages
+-- 聊天室时间线不含仅发送到话题中的回复（下同）
 SELECT 
     m.message_id,
     m.sent_at,
@@ -107,12 +239,24 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
 FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at DESC
 LIMIT $2 OFFSET $3;
 
@@ -126,12 +270,24 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
 FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at ASC
 LIMIT $2 OFFSET $3;
 
@@ -145,6 +301,17 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
@@ -152,6 +319,7 @@ FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1 
     AND m.sent_at < (SELECT sent_at FROM messages WHERE message_id = $2)
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at DESC
 LIMIT $3;
 
@@ -165,6 +333,17 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
@@ -172,6 +351,7 @@ FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1 
     AND m.sent_at > (SELECT sent_at FROM messages WHERE message_id = $2)
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at ASC
 LIMIT $3;
 
@@ -185,12 +365,24 @@ SELECT
     m.quoted_message_id,
     m.sender_id,
     m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
     u.username,
     u.nickname,
     u.avatar_url
 FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at DESC
 LIMIT $2;
 
@@ -219,18 +411,20 @@ ORDER BY m.sent_at ASC;
 -- =============================================
 
 -- name: CountMessagesInRoom :one
--- 统计聊天室消息数量
+-- 统计聊天室时间线消息数量
 SELECT COUNT(*) 
 FROM messages 
-WHERE room_id = $1;
+WHERE room_id = $1 AND (thread_root_id IS NULL OR also_send_to_room);
 
 -- name: GetUnreadMessageCount :one
--- 获取未读消息数量
+-- 获取未读消息数量（不含自己发送的消息和仅发送到话题中的回复）
 SELECT COUNT(*) 
 FROM messages m
 LEFT JOIN chatroom_members cm ON m.room_id = cm.room_id AND cm.user_id = $1
 WHERE m.room_id = $2 
-    AND m.sent_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ);
+    AND m.sent_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ)
+    AND m.sender_id IS DISTINCT FROM $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room);
 
 -- name: GetUnreadMessages :many
 -- 获取未读消息列表
@@ -250,6 +444,7 @@ LEFT JOIN users u ON m.sender_id = u.user_id
 LEFT JOIN chatroom_members cm ON m.room_id = cm.room_id AND cm.user_id = $1
 WHERE m.room_id = $2 
     AND m.sent_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ)
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at ASC
 LIMIT $3;
 
@@ -269,43 +464,97 @@ SELECT
 FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.room_id = $1
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 ORDER BY m.sent_at DESC
 LIMIT 1;
 
 -- name: GetUserUnreadCountsInAllRooms :many
--- 获取用户在所有聊天室的未读消息数
+-- 获取用户在所有聊天室的未读消息数（不含自己发送的消息和仅发送到话题中的回复）
 SELECT 
     cm.room_id,
     COUNT(m.message_id) AS unread_count
 FROM chatroom_members cm
 LEFT JOIN messages m ON m.room_id = cm.room_id 
     AND m.sent_at > COALESCE(cm.last_read_at, '1970-01-01'::TIMESTAMPTZ)
+    AND m.sender_id IS DISTINCT FROM cm.user_id
+    AND (m.thread_root_id IS NULL OR m.also_send_to_room)
 WHERE cm.user_id = $1 AND cm.is_active = true
 GROUP BY cm.room_id;
 
+-- name: GetMessageReadBy :many
+-- 获取已读指定消息的成员列表（已读位置不早于该消息发送时间，不含发送者本人）
+SELECT 
+    u.user_id,
+    u.username,
+    u.nickname,
+    u.avatar_url,
+    cm.last_read_at
+FROM messages m
+JOIN chatroom_members cm ON cm.room_id = m.room_id AND cm.is_active = true
+JOIN users u ON cm.user_id = u.user_id
+WHERE m.message_id = $1 
+    AND cm.last_read_at >= m.sent_at
+    AND cm.user_id IS DISTINCT FROM m.sender_id
+ORDER BY cm.last_read_at ASC;
+
 -- =============================================
 -- 4. 消息搜索 (Message Search)
 -- =============================================
 
--- name: SearchMessagesInRoom :many
--- 在聊天室中搜索消息
+-- name: SearchMessages :many
+-- 在用户所在的所有聊天室中搜索消息 GET /chatroom/search
+-- pattern 为最长关键词的 ILIKE 模式（使用三元组索引），term_patterns 为全部关键词的模式（须全部匹配），
+-- bigrams 为全部关键词的二元组（使用二元组索引，支持少于 3 个字符的关键词）；
+-- 按与查询的三元组相似度、发送时间倒序排列，has_cursor 为 true 时返回游标之后的结果。
+-- room_id / sender_id / message_type 为空时不过滤，from_time / to_time 为 NULL 时不限
 SELECT 
-    m.message_id,
-    m.sent_at,
-    m.content,
-    m.message_type,
-    m.quoted_message_id,
-    m.sender_id,
-    m.room_id,
-    u.username,
-    u.nickname,
-    u.avatar_url
-FROM messages m
-LEFT JOIN users u ON m.sender_id = u.user_id
-WHERE m.room_id = $1 
-    AND m.content ILIKE '%' || $2 || '%'
-ORDER BY m.sent_at DESC
-LIMIT $3 OFFSET $4;
+    r.message_id,
+    r.sent_at,
+    r.content,
+    r.message_type,
+    r.sender_id,
+    r.room_id,
+    r.seq,
+    r.thread_root_id,
+    r.username,
+    r.nickname,
+    r.avatar_url,
+    r.room_name,
+    r.rank
+FROM (
+    SELECT 
+        m.message_id,
+        m.sent_at,
+        m.content,
+        m.message_type,
+        m.sender_id,
+        m.room_id,
+        m.seq,
+        m.thread_root_id,
+        u.username,
+        u.nickname,
+        u.avatar_url,
+        c.room_name,
+        similarity(m.content, sqlc.arg(query)::text)::real AS rank
+    FROM messages m
+    JOIN chatroom_members cm ON cm.room_id = m.room_id AND cm.user_id = sqlc.arg(user_id) AND cm.is_active = true
+    JOIN chatrooms c ON c.room_id = m.room_id
+    LEFT JOIN users u ON m.sender_id = u.user_id
+    WHERE m.deleted_at IS NULL
+        AND m.message_type <> 'system_notification'
+        AND m.content ILIKE sqlc.arg(pattern)::text
+        AND m.content ILIKE ALL(sqlc.arg(term_patterns)::text[])
+        AND message_bigrams(m.content) @> sqlc.arg(bigrams)::text[]
+        AND (sqlc.arg(room_id)::text = '' OR m.room_id = sqlc.arg(room_id)::text)
+        AND (sqlc.arg(sender_id)::text = '' OR m.sender_id = sqlc.arg(sender_id)::text)
+        AND (sqlc.arg(message_type)::text = '' OR m.message_type::text = sqlc.arg(message_type)::text)
+        AND (sqlc.narg(from_time)::timestamptz IS NULL OR m.sent_at >= sqlc.narg(from_time)::timestamptz)
+        AND (sqlc.narg(to_time)::timestamptz IS NULL OR m.sent_at < sqlc.narg(to_time)::timestamptz)
+) r
+WHERE NOT sqlc.arg(has_cursor)::boolean
+    OR (r.rank, r.sent_at, r.message_id) < (sqlc.arg(cursor_rank)::real, sqlc.arg(cursor_sent_at)::timestamptz, sqlc.arg(cursor_message_id)::text)
+ORDER BY r.rank DESC, r.sent_at DESC, r.message_id DESC
+LIMIT sqlc.arg('limit');
 
 -- name: GetMessagesByUser :many
 -- 获取用户发送的消息
@@ -316,7 +565,18 @@ SELECT
     m.message_type,
     m.quoted_message_id,
     m.sender_id,
-    m.room_id
+    m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at
 FROM messages m
 WHERE m.sender_id = $1
 ORDER BY m.sent_at DESC
@@ -331,7 +591,18 @@ SELECT
     m.message_type,
     m.quoted_message_id,
     m.sender_id,
-    m.room_id
+    m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at
 FROM messages m
 WHERE m.sender_id = $1 AND m.room_id = $2
 ORDER BY m.sent_at DESC
@@ -433,4 +704,72 @@ SELECT
 FROM messages m
 LEFT JOIN users u ON m.sender_id = u.user_id
 WHERE m.message_id = ANY($1::varchar[])
-ORDER BY m.sent_at ASC;
\ No newline at end of file
+ORDER BY m.sent_at ASC;
+
+-- =============================================
+-- 8. 消息同步 (Message Sync)
+-- =============================================
+
+-- name: GetRoomMessageChanges :many
+-- 获取聊天室中变更序号大于指定值的消息（新消息、编辑、撤回），按变更顺序返回
+SELECT 
+    m.message_id,
+    m.sent_at,
+    m.content,
+    m.message_type,
+    m.quoted_message_id,
+    m.sender_id,
+    m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
+    u.username,
+    u.nickname,
+    u.avatar_url
+FROM messages m
+LEFT JOIN users u ON m.sender_id = u.user_id
+WHERE m.room_id = $1 AND m.change_seq > $2
+ORDER BY m.change_seq ASC
+LIMIT $3;
+
+-- =============================================
+-- 9. 消息话题 (Message Threads)
+-- =============================================
+
+-- name: GetThreadReplies :many
+-- 获取话题回复（按序号正序，序号大于指定值）GET /chatroom/:roomId/messages/:messageId/thread
+SELECT 
+    m.message_id,
+    m.sent_at,
+    m.content,
+    m.message_type,
+    m.quoted_message_id,
+    m.sender_id,
+    m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
+    u.username,
+    u.nickname,
+    u.avatar_url
+FROM messages m
+LEFT JOIN users u ON m.sender_id = u.user_id
+WHERE m.thread_root_id = $1 AND m.seq > $2
+ORDER BY m.seq ASC
+LIMIT $3;
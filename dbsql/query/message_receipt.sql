@@ -0,0 +1,92 @@
+-- =============================================
+-- 消息送达与已读状态相关SQL查询 (Message Receipt Queries)
+-- 对应API: 消息投递状态接口
+-- 表结构见 migration 000012_message_receipts
+-- =============================================
+
+-- name: CreateMessageReceipts :execrows
+-- 为聊天室其他成员创建消息投递记录（状态 sent），成员数超过上限的聊天室不记录
+INSERT INTO message_receipts (
+    message_id,
+    user_id
+)
+SELECT 
+    sqlc.arg(message_id)::varchar,
+    cm.user_id
+FROM chatroom_members cm
+WHERE cm.room_id = sqlc.arg(room_id) 
+    AND cm.is_active = true
+    AND cm.user_id <> sqlc.arg(sender_id)
+    AND (
+        SELECT COUNT(*) FROM chatroom_members 
+        WHERE room_id = sqlc.arg(room_id) AND is_active = true
+    ) <= sqlc.arg(max_members)::int;
+
+-- name: CreateThreadReplyReceipts :execrows
+-- 为话题关注者创建话题回复的投递记录（未同时发送到聊天室的回复只投递给关注者），成员数超过上限的聊天室不记录
+INSERT INTO message_receipts (
+    message_id,
+    user_id
+)
+SELECT 
+    sqlc.arg(message_id)::varchar,
+    f.user_id
+FROM message_thread_followers f
+JOIN chatroom_members cm ON cm.user_id = f.user_id 
+    AND cm.room_id = sqlc.arg(room_id) 
+    AND cm.is_active = true
+WHERE f.root_message_id = sqlc.arg(root_message_id)
+    AND f.user_id <> sqlc.arg(sender_id)
+    AND (
+        SELECT COUNT(*) FROM chatroom_members 
+        WHERE room_id = sqlc.arg(room_id) AND is_active = true
+    ) <= sqlc.arg(max_members)::int;
+
+-- name: MarkMessageDelivered :one
+-- 标记消息已送达指定接收者（仅 sent 状态会被更新）
+UPDATE message_receipts r
+SET 
+    status = 'delivered',
+    delivered_at = NOW()
+FROM messages m
+WHERE r.message_id = $1 
+    AND r.user_id = $2 
+    AND r.status = 'sent'
+    AND m.message_id = r.message_id
+RETURNING 
+    m.room_id,
+    m.sender_id,
+    r.delivered_at;
+
+-- name: MarkMessagesReadUpTo :many
+-- 将接收者在聊天室中指定时间及之前的消息标记为已读（已读位置前进时调用）
+UPDATE message_receipts r
+SET 
+    status = 'read',
+    read_at = NOW(),
+    delivered_at = COALESCE(r.delivered_at, NOW())
+FROM messages m
+WHERE r.message_id = m.message_id 
+    AND r.user_id = $1 
+    AND m.room_id = $2 
+    AND m.sent_at <= $3
+    AND r.status IN ('sent', 'delivered')
+RETURNING 
+    r.message_id,
+    m.sender_id,
+    r.read_at;
+
+-- name: GetMessageReceipts :many
+-- 获取消息各接收者的投递状态
+SELECT 
+    r.user_id,
+    r.status,
+    r.delivered_at,
+    r.read_at,
+    u.username,
+    u.nickname,
+    u.avatar_url
+FROM message_receipts r
+JOIN users u ON r.user_id = u.user_id
+WHERE r.message_id = $1
+ORDER BY r.user_id ASC;
@@ -1,23 +1,9 @@
 -- =============================================
 -- 通知系统相关SQL查询 (Notification Queries)
 -- 对应API: 通知系统接口
--- 注意: 需要先创建 notifications 表
+-- 表结构见 migration 000006_notifications
 -- =============================================
 
--- =============================================
--- 表结构参考 (需要在migration中创建)
--- =============================================
--- CREATE TABLE notifications (
---     notification_id VARCHAR(10) PRIMARY KEY,
---     receiver_id VARCHAR(10) NOT NULL REFERENCES users(user_id),
---     notification_type VARCHAR(50) NOT NULL, -- friend, chatroom, system
---     title VARCHAR(255) NOT NULL,
---     content TEXT NOT NULL,
---     data JSONB,
---     is_read BOOLEAN NOT NULL DEFAULT FALSE,
---     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
--- );
-
 -- =============================================
 -- 1. 通知创建 (Notification Creation)
 -- =============================================
@@ -102,18 +88,6 @@ INSERT INTO notifications (
     is_read,
     created_at;
 
--- name: CreateBatchNotifications :copyfrom
--- 批量创建通知（用于群发系统通知）
-INSERT INTO notifications (
-    receiver_id,
-    notification_type,
-    title,
-    content,
-    data
-) VALUES (
-    $1, $2, $3, $4, $5
-);
-
 -- =============================================
 -- 2. 通知查询 (Notification Queries)
 -- =============================================
@@ -133,7 +107,8 @@ FROM notifications
 WHERE notification_id = $1;
 
 -- name: GetUserNotifications :many
--- 获取用户通知列表 GET /users/me/notifications
+-- 获取用户通知列表 GET /users/me/notifications?type=&status=
+-- notification_type 为空时不过滤类型；status: unread | read | 空（全部）
 SELECT 
     notification_id,
     receiver_id,
@@ -144,81 +119,37 @@ SELECT
     is_read,
     created_at
 FROM notifications 
-WHERE receiver_id = $1
+WHERE receiver_id = sqlc.arg(receiver_id)
+    AND (sqlc.arg(notification_type)::text = '' OR notification_type = sqlc.arg(notification_type)::text)
+    AND (sqlc.arg(status)::text = ''
+        OR (sqlc.arg(status)::text = 'unread' AND is_read = false)
+        OR (sqlc.arg(status)::text = 'read' AND is_read = true))
 ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
-
--- name: GetUserNotificationsByType :many
--- 按类型获取用户通知 GET /users/me/notifications?type=friend
-SELECT 
-    notification_id,
-    receiver_id,
-    notification_type,
-    title,
-    content,
-    data,
-    is_read,
-    created_at
-FROM notifications 
-WHERE receiver_id = $1 AND notification_type = $2
-ORDER BY created_at DESC
-LIMIT $3 OFFSET $4;
-
--- name: GetUnreadNotifications :many
--- 获取未读通知 GET /users/me/notifications?status=unread
-SELECT 
-    notification_id,
-    receiver_id,
-    notification_type,
-    title,
-    content,
-    data,
-    is_read,
-    created_at
-FROM notifications 
-WHERE receiver_id = $1 AND is_read = false
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
-
--- name: GetUnreadNotificationsByType :many
--- 按类型获取未读通知
-SELECT 
-    notification_id,
-    receiver_id,
-    notification_type,
-    title,
-    content,
-    data,
-    is_read,
-    created_at
-FROM notifications 
-WHERE receiver_id = $1 AND notification_type = $2 AND is_read = false
-ORDER BY created_at DESC
-LIMIT $3 OFFSET $4;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- =============================================
 -- 3. 通知状态管理 (Notification Status Management)
 -- =============================================
 
--- name: MarkNotificationAsRead :exec
+-- name: MarkNotificationAsRead :execrows
 -- 标记通知已读 POST /notifications/:notificationId/read
 UPDATE notifications 
 SET is_read = true
 WHERE notification_id = $1 AND receiver_id = $2;
 
--- name: MarkAllNotificationsAsRead :exec
+-- name: MarkAllNotificationsAsRead :execrows
 -- 标记所有通知已读 POST /users/me/notifications/read-all
 UPDATE notifications 
 SET is_read = true
 WHERE receiver_id = $1 AND is_read = false;
 
--- name: MarkNotificationsByTypeAsRead :exec
+-- name: MarkNotificationsByTypeAsRead :execrows
 -- 按类型标记通知已读
 UPDATE notifications 
 SET is_read = true
 WHERE receiver_id = $1 AND notification_type = $2 AND is_read = false;
 
--- name: DeleteNotification :exec
+-- name: DeleteNotification :execrows
 -- 删除通知
 DELETE FROM notifications 
 WHERE notification_id = $1 AND receiver_id = $2;
@@ -229,19 +160,23 @@ DELETE FROM notifications
 WHERE receiver_id = $1 AND is_read = true;
 
 -- name: DeleteOldNotifications :exec
--- 删除超过指定天数的通知
+-- 删除指定时间之前的通知
 DELETE FROM notifications 
-WHERE created_at < NOW() - ($1 || ' days')::INTERVAL;
+WHERE created_at < $1;
 
 -- =============================================
 -- 4. 通知统计 (Notification Statistics)
 -- =============================================
 
 -- name: CountUserNotifications :one
--- 统计用户通知总数
+-- 统计用户通知总数（过滤条件同 GetUserNotifications）
 SELECT COUNT(*) 
 FROM notifications 
-WHERE receiver_id = $1;
+WHERE receiver_id = sqlc.arg(receiver_id)
+    AND (sqlc.arg(notification_type)::text = '' OR notification_type = sqlc.arg(notification_type)::text)
+    AND (sqlc.arg(status)::text = ''
+        OR (sqlc.arg(status)::text = 'unread' AND is_read = false)
+        OR (sqlc.arg(status)::text = 'read' AND is_read = true));
 
 -- name: CountUnreadNotifications :one
 -- 统计未读通知数量
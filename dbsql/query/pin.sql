@@ -0,0 +1,61 @@
+-- =============================================
+-- 置顶消息相关SQL查询 (Pinned Message Queries)
+-- 对应API: 置顶消息接口
+-- 表结构见 migration 000015_pinned_messages
+-- =============================================
+
+-- name: PinMessage :one
+-- 置顶消息，已置顶时不返回行
+INSERT INTO pinned_messages (
+    message_id,
+    room_id,
+    pinned_by
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (message_id) DO NOTHING
+RETURNING message_id, room_id, pinned_by, pinned_at;
+
+-- name: UnpinMessage :execrows
+-- 取消置顶
+DELETE FROM pinned_messages 
+WHERE message_id = $1 AND room_id = $2;
+
+-- name: CountPinnedMessages :one
+-- 统计聊天室置顶消息数量（不含已撤回的消息）
+SELECT COUNT(*) 
+FROM pinned_messages p
+JOIN messages m ON m.message_id = p.message_id
+WHERE p.room_id = $1 AND m.deleted_at IS NULL;
+
+-- name: GetPinnedMessages :many
+-- 获取聊天室置顶消息（最近置顶的在前，不含已撤回的消息）
+SELECT 
+    m.message_id,
+    m.sent_at,
+    m.content,
+    m.message_type,
+    m.quoted_message_id,
+    m.sender_id,
+    m.room_id,
+    m.edited_at,
+    m.edit_count,
+    m.media_url,
+    m.seq,
+    m.change_seq,
+    m.deleted_at,
+    m.client_msg_id,
+    m.thread_root_id,
+    m.also_send_to_room,
+    m.reply_count,
+    m.last_reply_at,
+    u.username,
+    u.nickname,
+    u.avatar_url,
+    p.pinned_by,
+    p.pinned_at
+FROM pinned_messages p
+JOIN messages m ON m.message_id = p.message_id
+LEFT JOIN users u ON m.sender_id = u.user_id
+WHERE p.room_id = $1 AND m.deleted_at IS NULL
+ORDER BY p.pinned_at DESC;
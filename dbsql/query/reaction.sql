@@ -0,0 +1,59 @@
+-- =============================================
+-- 消息表情回应相关SQL查询 (Message Reaction Queries)
+-- 对应API: 消息表情回应接口
+-- 表结构见 migration 000013_message_reactions
+-- =============================================
+
+-- name: AddMessageReaction :execrows
+-- 添加表情回应，已存在时不做处理（返回 0）
+INSERT INTO message_reactions (
+    message_id,
+    user_id,
+    emoji
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (message_id, user_id, emoji) DO NOTHING;
+
+-- name: RemoveMessageReaction :execrows
+-- 取消表情回应
+DELETE FROM message_reactions 
+WHERE message_id = $1 AND user_id = $2 AND emoji = $3;
+
+-- name: CountMessageReactionsByEmoji :one
+-- 统计消息某个表情的回应数
+SELECT COUNT(*) 
+FROM message_reactions 
+WHERE message_id = $1 AND emoji = $2;
+
+-- name: CountUserReactionsOnMessage :one
+-- 统计用户对消息使用的不同表情数
+SELECT COUNT(*) 
+FROM message_reactions 
+WHERE message_id = $1 AND user_id = $2;
+
+-- name: GetMessageReactions :many
+-- 获取消息的所有表情回应及回应者信息（按回应时间正序）
+SELECT 
+    r.emoji,
+    r.user_id,
+    r.created_at,
+    u.username,
+    u.nickname,
+    u.avatar_url
+FROM message_reactions r
+JOIN users u ON r.user_id = u.user_id
+WHERE r.message_id = $1
+ORDER BY r.created_at ASC;
+
+-- name: GetReactionSummaries :many
+-- 批量统计多条消息的表情回应数及当前用户是否回应（同一消息内按首次回应时间排序）
+SELECT 
+    message_id,
+    emoji,
+    COUNT(*) AS count,
+    COALESCE(BOOL_OR(user_id = sqlc.arg(user_id)), false)::boolean AS reacted
+FROM message_reactions
+WHERE message_id = ANY(sqlc.arg(message_ids)::varchar[])
+GROUP BY message_id, emoji
+ORDER BY message_id, MIN(created_at) ASC;
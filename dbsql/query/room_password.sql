@@ -0,0 +1,55 @@
+-- =============================================
+-- 聊天室密码错误限制相关SQL查询 (Room Password Attempt Queries)
+-- 对应API: POST /chatroom/joinroom
+-- 表结构见 migration 000021_room_password_hash
+-- =============================================
+
+-- name: ReserveRoomPasswordAttempt :one
+-- 在校验密码之前原子地占用一次尝试次数，并发请求依次递增，不会同时读到旧的计数。
+-- 锁定期间继续递增（计数保持大于上限）；锁定已过期或统计窗口开始时间早于 window_started_at 时重新计数；
+-- 计数超过上限 max_failures 时锁定到 locked_until。返回的 failed_count 大于上限表示本次尝试应被拒绝
+INSERT INTO room_password_attempts (
+    room_id,
+    user_id,
+    failed_count,
+    window_started_at
+) VALUES (
+    sqlc.arg(room_id), sqlc.arg(user_id), 1, NOW()
+)
+ON CONFLICT (room_id, user_id) DO UPDATE SET
+    failed_count = CASE
+        WHEN room_password_attempts.locked_until > NOW() THEN room_password_attempts.failed_count + 1
+        WHEN room_password_attempts.locked_until IS NOT NULL
+            OR room_password_attempts.window_started_at < sqlc.arg(window_started_at) THEN 1
+        ELSE room_password_attempts.failed_count + 1
+    END,
+    window_started_at = CASE
+        WHEN room_password_attempts.locked_until > NOW() THEN room_password_attempts.window_started_at
+        WHEN room_password_attempts.locked_until IS NOT NULL
+            OR room_password_attempts.window_started_at < sqlc.arg(window_started_at) THEN NOW()
+        ELSE room_password_attempts.window_started_at
+    END,
+    locked_until = CASE
+        WHEN room_password_attempts.locked_until > NOW() THEN room_password_attempts.locked_until
+        WHEN room_password_attempts.locked_until IS NULL
+            AND room_password_attempts.window_started_at >= sqlc.arg(window_started_at)
+            AND room_password_attempts.failed_count + 1 > sqlc.arg(max_failures)::int THEN sqlc.arg(locked_until)
+        ELSE NULL
+    END
+RETURNING 
+    room_id,
+    user_id,
+    failed_count,
+    window_started_at,
+    locked_until;
+
+-- name: LockRoomPasswordAttempts :exec
+-- 错误次数达到上限后锁定，锁定期间的尝试在校验密码前即被拒绝
+UPDATE room_password_attempts
+SET locked_until = $3
+WHERE room_id = $1 AND user_id = $2;
+
+-- name: ClearRoomPasswordAttempts :exec
+-- 密码验证成功后清除错误记录
+DELETE FROM room_password_attempts
+WHERE room_id = $1 AND user_id = $2;
@@ -0,0 +1,114 @@
+-- =============================================
+-- 登录会话相关SQL查询 (Session Queries)
+-- 对应API: 认证接口 / 会话管理接口
+-- =============================================
+
+-- name: CreateUserSession :one
+-- 创建登录会话（登录/注册时）
+INSERT INTO user_sessions (
+    session_id,
+    user_id,
+    device,
+    user_agent,
+    ip_address,
+    expires_at,
+    refresh_jti
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING 
+    session_id,
+    user_id,
+    device,
+    user_agent,
+    ip_address,
+    created_at,
+    last_active_at,
+    expires_at,
+    revoked_at,
+    refresh_jti;
+
+-- name: GetActiveUserSession :one
+-- 获取未吊销且未过期的会话（鉴权时校验）
+SELECT 
+    session_id,
+    user_id,
+    device,
+    user_agent,
+    ip_address,
+    created_at,
+    last_active_at,
+    expires_at,
+    revoked_at,
+    refresh_jti
+FROM user_sessions
+WHERE session_id = $1
+    AND revoked_at IS NULL
+    AND expires_at > NOW();
+
+-- name: ListActiveUserSessions :many
+-- 获取用户所有有效会话 GET /users/me/sessions
+SELECT 
+    session_id,
+    user_id,
+    device,
+    user_agent,
+    ip_address,
+    created_at,
+    last_active_at,
+    expires_at,
+    revoked_at,
+    refresh_jti
+FROM user_sessions
+WHERE user_id = $1
+    AND revoked_at IS NULL
+    AND expires_at > NOW()
+ORDER BY last_active_at DESC;
+
+-- name: GetUserSessionByID :one
+-- 获取会话（含已吊销/已过期，用于 refresh token 重放检测）
+SELECT 
+    session_id,
+    user_id,
+    device,
+    user_agent,
+    ip_address,
+    created_at,
+    last_active_at,
+    expires_at,
+    revoked_at,
+    refresh_jti
+FROM user_sessions
+WHERE session_id = $1;
+
+-- name: RotateSessionRefreshToken :execrows
+-- 轮换 refresh token：仅当旧 jti 仍为当前有效 jti 时成功 POST /auth/refresh
+UPDATE user_sessions
+SET refresh_jti = sqlc.arg(new_refresh_jti),
+    expires_at = sqlc.arg(expires_at),
+    last_active_at = NOW()
+WHERE session_id = sqlc.arg(session_id)
+    AND refresh_jti = sqlc.arg(refresh_jti)
+    AND revoked_at IS NULL
+    AND expires_at > NOW();
+
+-- name: TouchUserSession :exec
+-- 更新会话最后活跃时间
+UPDATE user_sessions
+SET last_active_at = NOW()
+WHERE session_id = $1;
+
+-- name: RevokeUserSession :execrows
+-- 吊销用户的指定会话 POST /users/me/sessions/:sessionid/revoke
+UPDATE user_sessions
+SET revoked_at = NOW()
+WHERE session_id = $1
+    AND user_id = $2
+    AND revoked_at IS NULL;
+
+-- name: RevokeAllUserSessions :execrows
+-- 吊销用户的所有会话 POST /users/me/sessions/revokeall
+UPDATE user_sessions
+SET revoked_at = NOW()
+WHERE user_id = $1
+    AND revoked_at IS NULL;
+
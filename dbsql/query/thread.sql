@@ -0,0 +1,35 @@
+-- =============================================
+-- 消息话题相关SQL查询 (Message Thread Queries)
+-- 对应API: 消息话题接口
+-- 表结构见 migration 000014_message_threads
+-- =============================================
+
+-- name: FollowThread :exec
+-- 关注话题，已关注时不做处理
+INSERT INTO message_thread_followers (
+    root_message_id,
+    user_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (root_message_id, user_id) DO NOTHING;
+
+-- name: UnfollowThread :execrows
+-- 取消关注话题
+DELETE FROM message_thread_followers 
+WHERE root_message_id = $1 AND user_id = $2;
+
+-- name: IsThreadFollower :one
+-- 检查用户是否关注话题
+SELECT EXISTS(
+    SELECT 1 FROM message_thread_followers 
+    WHERE root_message_id = $1 AND user_id = $2
+) AS is_follower;
+
+-- name: GetThreadFollowerIDs :many
+-- 获取话题关注者中仍在聊天室内的用户
+SELECT f.user_id
+FROM message_thread_followers f
+JOIN messages m ON m.message_id = f.root_message_id
+JOIN chatroom_members cm ON cm.room_id = m.room_id AND cm.user_id = f.user_id AND cm.is_active = true
+WHERE f.root_message_id = $1;
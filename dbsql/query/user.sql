@@ -180,39 +180,49 @@ WHERE user_id = $1;
 
 -- name: SearchUsers :many
 -- 搜索用户 GET /users/search
+-- 用户名、昵称模糊匹配；邮箱、手机号仅精确匹配，且需对方在隐私设置中允许
 SELECT 
-    user_id,
-    username,
-    nickname,
-    avatar_url,
-    bio,
-    online_status
-FROM users 
+    u.user_id,
+    u.username,
+    u.nickname,
+    u.avatar_url,
+    u.bio,
+    u.online_status,
+    COALESCE(us.show_online_status, true)::boolean AS show_online_status
+FROM users u
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE 
-    account_status = 'active'
+    u.account_status = 'active'
     AND (
-        username ILIKE '%' || $1 || '%' 
-        OR nickname ILIKE '%' || $1 || '%'
+        u.username ILIKE '%' || sqlc.arg(keyword)::text || '%' 
+        OR u.nickname ILIKE '%' || sqlc.arg(keyword)::text || '%'
+        OR (u.email = sqlc.arg(keyword)::text AND COALESCE(us.allow_search_by_email, true))
+        OR (u.phone_number = sqlc.arg(keyword)::text AND COALESCE(us.allow_search_by_phone, true))
     )
 ORDER BY 
-    CASE WHEN username = $1 THEN 0
-         WHEN username ILIKE $1 || '%' THEN 1
-         WHEN nickname = $1 THEN 2
-         WHEN nickname ILIKE $1 || '%' THEN 3
+    CASE WHEN u.username = sqlc.arg(keyword)::text THEN 0
+         WHEN u.email = sqlc.arg(keyword)::text AND COALESCE(us.allow_search_by_email, true) THEN 0
+         WHEN u.phone_number = sqlc.arg(keyword)::text AND COALESCE(us.allow_search_by_phone, true) THEN 0
+         WHEN u.username ILIKE sqlc.arg(keyword)::text || '%' THEN 1
+         WHEN u.nickname = sqlc.arg(keyword)::text THEN 2
+         WHEN u.nickname ILIKE sqlc.arg(keyword)::text || '%' THEN 3
          ELSE 4
     END,
-    username
-LIMIT $2 OFFSET $3;
+    u.username
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountSearchUsers :one
--- 搜索用户计数
+-- 搜索用户计数（匹配规则同 SearchUsers）
 SELECT COUNT(*) 
-FROM users 
+FROM users u
+LEFT JOIN user_settings us ON us.user_id = u.user_id
 WHERE 
-    account_status = 'active'
+    u.account_status = 'active'
     AND (
-        username ILIKE '%' || $1 || '%' 
-        OR nickname ILIKE '%' || $1 || '%'
+        u.username ILIKE '%' || sqlc.arg(keyword)::text || '%' 
+        OR u.nickname ILIKE '%' || sqlc.arg(keyword)::text || '%'
+        OR (u.email = sqlc.arg(keyword)::text AND COALESCE(us.allow_search_by_email, true))
+        OR (u.phone_number = sqlc.arg(keyword)::text AND COALESCE(us.allow_search_by_phone, true))
     );
 
 -- name: GetUserPublicInfo :one
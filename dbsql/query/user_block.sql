@@ -0,0 +1,41 @@
+-- =============================================
+-- 用户屏蔽相关SQL查询 (User Block Queries)
+-- 对应API: POST /users/:userid/block, POST /users/:userid/unblock, GET /users/me/blocks
+-- 表结构见 migration 000018_direct_conversations
+-- =============================================
+
+-- name: BlockUser :execrows
+-- 屏蔽用户，已屏蔽时不做处理（返回 0）
+INSERT INTO user_blocks (
+    blocker_id,
+    blocked_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (blocker_id, blocked_id) DO NOTHING;
+
+-- name: UnblockUser :execrows
+-- 取消屏蔽
+DELETE FROM user_blocks 
+WHERE blocker_id = $1 AND blocked_id = $2;
+
+-- name: IsBlockedBetween :one
+-- 检查两名用户之间是否存在任一方向的屏蔽
+SELECT EXISTS(
+    SELECT 1 FROM user_blocks 
+    WHERE (blocker_id = sqlc.arg(user_a) AND blocked_id = sqlc.arg(user_b))
+        OR (blocker_id = sqlc.arg(user_b) AND blocked_id = sqlc.arg(user_a))
+) AS is_blocked;
+
+-- name: GetBlockedUsers :many
+-- 获取用户屏蔽的用户列表 GET /users/me/blocks
+SELECT 
+    u.user_id,
+    u.username,
+    u.nickname,
+    u.avatar_url,
+    b.created_at
+FROM user_blocks b
+JOIN users u ON u.user_id = b.blocked_id
+WHERE b.blocker_id = $1
+ORDER BY b.created_at DESC;
@@ -1,30 +1,9 @@
 -- =============================================
 -- 用户设置相关SQL查询 (User Settings Queries)
 -- 对应API: 用户设置接口
--- 注意: 需要先创建 user_settings 表
+-- 表结构见 migration 000007_user_settings
 -- =============================================
 
--- =============================================
--- 表结构参考 (需要在migration中创建)
--- =============================================
--- CREATE TABLE user_settings (
---     user_id VARCHAR(10) PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
---     -- 通知设置
---     enable_friend_request BOOLEAN NOT NULL DEFAULT TRUE,
---     enable_chatroom_message BOOLEAN NOT NULL DEFAULT TRUE,
---     enable_system_notice BOOLEAN NOT NULL DEFAULT TRUE,
---     enable_sound BOOLEAN NOT NULL DEFAULT TRUE,
---     enable_desktop_notification BOOLEAN NOT NULL DEFAULT TRUE,
---     -- 隐私设置
---     allow_search_by_phone BOOLEAN NOT NULL DEFAULT TRUE,
---     allow_search_by_email BOOLEAN NOT NULL DEFAULT TRUE,
---     show_online_status BOOLEAN NOT NULL DEFAULT TRUE,
---     -- 其他设置
---     theme VARCHAR(20) DEFAULT 'light',
---     language VARCHAR(10) DEFAULT 'zh-CN',
---     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
--- );
-
 -- =============================================
 -- 1. 用户设置操作 (User Settings Operations)
 -- =============================================
@@ -66,21 +45,21 @@ FROM user_settings
 WHERE user_id = $1;
 
 -- name: UpdateUserSettings :one
--- 更新用户设置 PUT /users/me/settings
+-- 更新用户设置 PUT /users/me/settings（未提供的字段保持不变）
 UPDATE user_settings 
 SET 
-    enable_friend_request = COALESCE($2, enable_friend_request),
-    enable_chatroom_message = COALESCE($3, enable_chatroom_message),
-    enable_system_notice = COALESCE($4, enable_system_notice),
-    enable_sound = COALESCE($5, enable_sound),
-    enable_desktop_notification = COALESCE($6, enable_desktop_notification),
-    allow_search_by_phone = COALESCE($7, allow_search_by_phone),
-    allow_search_by_email = COALESCE($8, allow_search_by_email),
-    show_online_status = COALESCE($9, show_online_status),
-    theme = COALESCE($10, theme),
-    language = COALESCE($11, language),
+    enable_friend_request = COALESCE(sqlc.narg(enable_friend_request), enable_friend_request),
+    enable_chatroom_message = COALESCE(sqlc.narg(enable_chatroom_message), enable_chatroom_message),
+    enable_system_notice = COALESCE(sqlc.narg(enable_system_notice), enable_system_notice),
+    enable_sound = COALESCE(sqlc.narg(enable_sound), enable_sound),
+    enable_desktop_notification = COALESCE(sqlc.narg(enable_desktop_notification), enable_desktop_notification),
+    allow_search_by_phone = COALESCE(sqlc.narg(allow_search_by_phone), allow_search_by_phone),
+    allow_search_by_email = COALESCE(sqlc.narg(allow_search_by_email), allow_search_by_email),
+    show_online_status = COALESCE(sqlc.narg(show_online_status), show_online_status),
+    theme = COALESCE(sqlc.narg(theme), theme),
+    language = COALESCE(sqlc.narg(language), language),
     updated_at = NOW()
-WHERE user_id = $1
+WHERE user_id = sqlc.arg(user_id)
 RETURNING 
     user_id,
     enable_friend_request,
@@ -95,28 +74,6 @@ RETURNING
     language,
     updated_at;
 
--- name: UpdateNotificationSettings :exec
--- 更新通知设置
-UPDATE user_settings 
-SET 
-    enable_friend_request = COALESCE($2, enable_friend_request),
-    enable_chatroom_message = COALESCE($3, enable_chatroom_message),
-    enable_system_notice = COALESCE($4, enable_system_notice),
-    enable_sound = COALESCE($5, enable_sound),
-    enable_desktop_notification = COALESCE($6, enable_desktop_notification),
-    updated_at = NOW()
-WHERE user_id = $1;
-
--- name: UpdatePrivacySettings :exec
--- 更新隐私设置
-UPDATE user_settings 
-SET 
-    allow_search_by_phone = COALESCE($2, allow_search_by_phone),
-    allow_search_by_email = COALESCE($3, allow_search_by_email),
-    show_online_status = COALESCE($4, show_online_status),
-    updated_at = NOW()
-WHERE user_id = $1;
-
 -- name: UpdateThemeSettings :exec
 -- 更新主题设置
 UPDATE user_settings 
@@ -155,10 +112,11 @@ FROM user_settings
 WHERE user_id = $1;
 
 -- name: ShouldShowOnlineStatus :one
--- 检查用户是否显示在线状态
-SELECT show_online_status 
-FROM user_settings 
-WHERE user_id = $1;
+-- 检查用户是否显示在线状态（没有设置记录时默认显示）
+SELECT COALESCE(
+    (SELECT show_online_status FROM user_settings WHERE user_id = $1),
+    true
+)::boolean AS show_online_status;
 
 -- name: GetUserNotificationPreferences :one
 -- 获取用户通知偏好
@@ -181,16 +139,9 @@ FROM user_settings
 WHERE user_id = $1;
 
 -- =============================================
--- 3. 批量操作 (Batch Operations)
+-- 3. 搜索辅助 (Search Helpers)
 -- =============================================
 
--- name: GetUsersWithFriendRequestEnabled :many
--- 获取允许接收好友请求的用户ID列表
-SELECT user_id 
-FROM user_settings 
-WHERE enable_friend_request = true 
-    AND user_id = ANY($1::varchar[]);
-
 -- name: GetSearchableUsersByPhone :many
 -- 获取允许通过手机号搜索的用户
 SELECT u.user_id, u.username, u.nickname, u.avatar_url, u.online_status
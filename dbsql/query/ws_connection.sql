@@ -0,0 +1,43 @@
+-- =============================================
+-- WebSocket 连接登记相关SQL查询 (WS Connection Queries)
+-- 多实例部署时按用户统计全局连接数，维护在线状态
+-- 表结构见 migration 000022_ws_connections
+-- =============================================
+
+-- name: LockUserPresence :exec
+-- 在事务中锁定用户的在线状态变更（事务结束时释放），串行化各实例的连接与断开
+SELECT pg_advisory_xact_lock(hashtext('ws_presence:' || sqlc.arg(user_id)::text));
+
+-- name: CreateWSConnection :exec
+-- 登记连接
+INSERT INTO ws_connections (
+    conn_id,
+    user_id,
+    instance_id
+) VALUES (
+    $1, $2, $3
+);
+
+-- name: DeleteWSConnection :exec
+-- 注销连接
+DELETE FROM ws_connections
+WHERE instance_id = $1 AND conn_id = $2;
+
+-- name: CountLiveUserConnections :one
+-- 统计用户在所有实例上的有效连接数（超过 timeout_seconds 未刷新的视为已失效）
+SELECT COUNT(*)
+FROM ws_connections
+WHERE user_id = sqlc.arg(user_id) 
+    AND last_seen_at >= NOW() - sqlc.arg(timeout_seconds)::int * INTERVAL '1 second';
+
+-- name: TouchInstanceConnections :exec
+-- 刷新实例持有的所有连接
+UPDATE ws_connections
+SET last_seen_at = NOW()
+WHERE instance_id = $1;
+
+-- name: DeleteStaleWSConnections :many
+-- 清理超过 timeout_seconds 未刷新的连接（实例已退出），返回受影响的用户
+DELETE FROM ws_connections
+WHERE last_seen_at < NOW() - sqlc.arg(timeout_seconds)::int * INTERVAL '1 second'
+RETURNING user_id;
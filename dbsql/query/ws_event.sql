@@ -0,0 +1,27 @@
+-- =============================================
+-- WebSocket 跨实例事件相关SQL查询 (WS Event Queries)
+-- 用于 PostgreSQL LISTEN/NOTIFY 背板
+-- =============================================
+
+-- name: NotifyChannel :exec
+-- 向指定频道发送通知
+SELECT pg_notify(sqlc.arg(channel)::text, sqlc.arg(payload)::text);
+
+-- name: CreateWSEvent :one
+-- 保存超出 NOTIFY 负载上限的事件
+INSERT INTO ws_events (
+    payload
+) VALUES (
+    $1
+) RETURNING event_id;
+
+-- name: GetWSEvent :one
+-- 获取事件内容
+SELECT payload
+FROM ws_events
+WHERE event_id = $1;
+
+-- name: DeleteExpiredWSEvents :exec
+-- 清理过期事件
+DELETE FROM ws_events
+WHERE created_at < $1;
@@ -23,12 +23,13 @@ const (
 
 // DBConfig 数据库配置
 type DBConfig struct {
-	Driver          string        // 数据库驱动 (mysql, postgres, sqlite3)
-	DSN             string        // 数据库连接字符串
-	MaxOpenConns    int           // 最大打开连接数
-	MaxIdleConns    int           // 最大空闲连接数
-	ConnMaxLifetime time.Duration // 连接最大生命周期
-	ConnMaxIdleTime time.Duration // 连接最大空闲时间
+	Driver          string                 // 数据库驱动 (mysql, postgres, sqlite3)
+	DSN             string                 // 数据库连接字符串
+	MaxOpenConns    int                    // 最大打开连接数
+	MaxIdleConns    int                    // 最大空闲连接数
+	ConnMaxLifetime time.Duration          // 连接最大生命周期
+	ConnMaxIdleTime time.Duration          // 连接最大空闲时间
+	Logger          *logger.DBLoggerConfig // 数据库日志配置，为空时使用默认配置
 }
 
 // DefaultDBConfig 返回默认数据库配置
@@ -82,7 +83,7 @@ func NewDBManager(config *DBConfig) (*DBManager, error) {
 	}
 
 	// 初始化数据库日志
-	if err := logger.InitDBLogger(nil); err != nil {
+	if err := logger.InitDBLogger(config.Logger); err != nil {
 		log.Printf("初始化数据库日志失败: %v", err)
 	}
 
@@ -117,6 +118,13 @@ func (m *DBManager) GetQueries() *sqlcdb.Queries {
 	return m.queries
 }
 
+// GetDSN 获取数据库连接字符串（用于 LISTEN/NOTIFY 等需要独立连接的场景）
+func (m *DBManager) GetDSN() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config.DSN
+}
+
 // Close 关闭数据库连接
 func (m *DBManager) Close() error {
 	m.mu.Lock()
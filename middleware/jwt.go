@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"chatroombackend/utils"
 	"errors"
 	"net/http"
 	"strings"
@@ -10,32 +11,48 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// JWT 配置
+// JWT 配置（启动时由 config 包根据 config.yaml 与环境变量覆盖）
 var (
-	JWTSecretKey    = []byte("your-secret-key-change-in-production") // 生产环境应从环境变量读取
-	JWTExpireHours  = 24                                             // Token 过期时间（小时）
-	JWTRefreshHours = 168                                            // Refresh Token 过期时间（7天）
-	JWTIssuer       = "chatroom-backend"
+	JWTSecretKey        = []byte("your-secret-key-change-in-production")         // 仅供开发使用，生产模式下拒绝启动
+	JWTRefreshSecretKey = []byte("your-refresh-secret-key-change-in-production") // Refresh Token 使用独立的签名密钥
+	JWTExpireHours      = 24                                                     // Token 过期时间（小时）
+	JWTRefreshHours     = 168                                                    // Refresh Token 过期时间（7天）
+	JWTIssuer           = "chatroom-backend"
+)
+
+// Token 类型与受众，access token 与 refresh token 互不通用
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+
+	accessAudience  = "chatroom-api"
+	refreshAudience = "chatroom-refresh"
 )
 
 // CustomClaims 自定义JWT Claims
 type CustomClaims struct {
-	UserID   string `json:"userId"`
-	Username string `json:"username"`
+	UserID    string `json:"userId"`
+	Username  string `json:"username"`
+	SessionID string `json:"sid"` // 登录会话编号，吊销会话后该会话签发的所有 token 失效
+	TokenType string `json:"typ"` // token 类型: access | refresh
 	jwt.RegisteredClaims
 }
 
 // GenerateToken 生成JWT Token
-func GenerateToken(userID, username string) (string, error) {
+func GenerateToken(userID, username, sessionID string) (string, error) {
 	claims := CustomClaims{
-		UserID:   userID,
-		Username: username,
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
+		TokenType: TokenTypeAccess,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        utils.GenerateTokenID(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(JWTExpireHours) * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    JWTIssuer,
 			Subject:   userID,
+			Audience:  jwt.ClaimStrings{accessAudience},
 		},
 	}
 
@@ -44,41 +61,59 @@ func GenerateToken(userID, username string) (string, error) {
 }
 
 // GenerateRefreshToken 生成Refresh Token
-func GenerateRefreshToken(userID, username string) (string, error) {
+// tokenID 为 refresh token 的 jti，需记录到会话中用于一次性轮换校验
+func GenerateRefreshToken(userID, username, sessionID, tokenID string) (string, error) {
 	claims := CustomClaims{
-		UserID:   userID,
-		Username: username,
+		UserID:    userID,
+		Username:  username,
+		SessionID: sessionID,
+		TokenType: TokenTypeRefresh,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(JWTRefreshHours) * time.Hour)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    JWTIssuer,
 			Subject:   userID,
+			Audience:  jwt.ClaimStrings{refreshAudience},
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(JWTSecretKey)
+	return token.SignedString(JWTRefreshSecretKey)
 }
 
-// ParseToken 解析JWT Token
+// ParseToken 解析JWT Token（仅接受 access token）
 func ParseToken(tokenString string) (*CustomClaims, error) {
+	return parseToken(tokenString, JWTSecretKey, accessAudience, TokenTypeAccess)
+}
+
+// ParseRefreshToken 解析Refresh Token（仅接受 refresh token）
+func ParseRefreshToken(tokenString string) (*CustomClaims, error) {
+	return parseToken(tokenString, JWTRefreshSecretKey, refreshAudience, TokenTypeRefresh)
+}
+
+func parseToken(tokenString string, key []byte, audience, tokenType string) (*CustomClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("无效的签名方法")
 		}
-		return JWTSecretKey, nil
-	})
+		return key, nil
+	}, jwt.WithAudience(audience), jwt.WithIssuer(JWTIssuer))
 
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*CustomClaims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*CustomClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("无效的token")
+	}
+	if claims.TokenType != tokenType {
+		return nil, errors.New("token类型错误")
 	}
 
-	return nil, errors.New("无效的token")
+	return claims, nil
 }
 
 // JWTAuthMiddleware JWT认证中间件
@@ -118,9 +153,38 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// 校验会话是否已被吊销
+		queries, err := GetQueriesFromContext(c)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"code":    500,
+				"message": "获取数据库连接失败",
+				"error":   err.Error(),
+			})
+			c.Abort()
+			return
+		}
+		if err := ValidateSession(c.Request.Context(), queries, claims); err != nil {
+			if errors.Is(err, ErrSessionRevoked) {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"code":    401,
+					"message": "登录已失效，请重新登录",
+				})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"code":    500,
+					"message": "校验登录会话失败",
+					"error":   err.Error(),
+				})
+			}
+			c.Abort()
+			return
+		}
+
 		// 将用户信息存入上下文
 		c.Set("userId", claims.UserID)
 		c.Set("username", claims.Username)
+		c.Set("sessionId", claims.SessionID)
 		c.Set("claims", claims)
 
 		c.Next()
@@ -143,9 +207,16 @@ func OptionalJWTAuthMiddleware() gin.HandlerFunc {
 		}
 
 		claims, err := ParseToken(parts[1])
-		if err == nil {
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		// 会话已吊销时按未登录处理
+		if queries, err := GetQueriesFromContext(c); err == nil && ValidateSession(c.Request.Context(), queries, claims) == nil {
 			c.Set("userId", claims.UserID)
 			c.Set("username", claims.Username)
+			c.Set("sessionId", claims.SessionID)
 			c.Set("claims", claims)
 		}
 
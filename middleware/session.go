@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"chatroombackend/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// ErrSessionRevoked 会话不存在、已吊销或已过期
+	ErrSessionRevoked = errors.New("会话已失效")
+	// ErrRefreshTokenReused 已轮换过的 refresh token 被再次使用（整个会话已被吊销）
+	ErrRefreshTokenReused = errors.New("refresh token 已被使用")
+)
+
+// sessionTouchInterval 会话最后活跃时间的最小更新间隔，避免每个请求都写库
+const sessionTouchInterval = time.Minute
+
+// CreateSession 创建登录会话并签发关联该会话的 access token 与 refresh token
+// 设备类型取自请求头 X-Device（web / ios / android / desktop 等）
+func CreateSession(c *gin.Context, queries *sqlcdb.Queries, userID, username string) (token, refreshToken string, err error) {
+	sessionID := utils.GenerateSessionID()
+	refreshJti := utils.GenerateTokenID()
+	_, err = queries.CreateUserSession(c.Request.Context(), sqlcdb.CreateUserSessionParams{
+		SessionID:  sessionID,
+		UserID:     userID,
+		Device:     nullString(c.GetHeader("X-Device")),
+		UserAgent:  nullString(c.Request.UserAgent()),
+		IpAddress:  nullString(c.ClientIP()),
+		ExpiresAt:  time.Now().Add(time.Duration(JWTRefreshHours) * time.Hour),
+		RefreshJti: refreshJti,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("创建登录会话失败: %w", err)
+	}
+
+	token, err = GenerateToken(userID, username, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+	refreshToken, err = GenerateRefreshToken(userID, username, sessionID, refreshJti)
+	if err != nil {
+		return "", "", err
+	}
+	return token, refreshToken, nil
+}
+
+// RotateSession 使用 refresh token 签发新的 token 对，旧 refresh token 随即失效
+// 会话即 token 家族：已轮换过的 refresh token 再次使用时吊销整个会话并返回 ErrRefreshTokenReused
+func RotateSession(ctx context.Context, queries *sqlcdb.Queries, claims *CustomClaims) (token, refreshToken string, err error) {
+	newRefreshJti := utils.GenerateTokenID()
+	affected, err := queries.RotateSessionRefreshToken(ctx, sqlcdb.RotateSessionRefreshTokenParams{
+		NewRefreshJti: newRefreshJti,
+		ExpiresAt:     time.Now().Add(time.Duration(JWTRefreshHours) * time.Hour),
+		SessionID:     claims.SessionID,
+		RefreshJti:    claims.ID,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("轮换 refresh token 失败: %w", err)
+	}
+
+	if affected == 0 {
+		session, err := queries.GetUserSessionByID(ctx, claims.SessionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", "", ErrSessionRevoked
+			}
+			return "", "", err
+		}
+		if session.UserID != claims.UserID || session.RevokedAt.Valid || !session.ExpiresAt.After(time.Now()) {
+			return "", "", ErrSessionRevoked
+		}
+
+		// 会话仍有效但 jti 不匹配：旧 refresh token 被重放，吊销整个会话
+		if _, err := queries.RevokeUserSession(ctx, sqlcdb.RevokeUserSessionParams{
+			SessionID: session.SessionID,
+			UserID:    session.UserID,
+		}); err != nil {
+			return "", "", fmt.Errorf("吊销登录会话失败: %w", err)
+		}
+		logger.Warn("Session", fmt.Sprintf("Refresh token reuse detected for session %s of user %s, session revoked", session.SessionID, session.UserID))
+		return "", "", ErrRefreshTokenReused
+	}
+
+	token, err = GenerateToken(claims.UserID, claims.Username, claims.SessionID)
+	if err != nil {
+		return "", "", err
+	}
+	refreshToken, err = GenerateRefreshToken(claims.UserID, claims.Username, claims.SessionID, newRefreshJti)
+	if err != nil {
+		return "", "", err
+	}
+	return token, refreshToken, nil
+}
+
+// ValidateSession 校验 token 所属会话仍然有效，并按需更新会话活跃时间
+func ValidateSession(ctx context.Context, queries *sqlcdb.Queries, claims *CustomClaims) error {
+	if claims.SessionID == "" {
+		return ErrSessionRevoked
+	}
+
+	session, err := queries.GetActiveUserSession(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrSessionRevoked
+		}
+		return err
+	}
+	if session.UserID != claims.UserID {
+		return ErrSessionRevoked
+	}
+
+	if time.Since(session.LastActiveAt) > sessionTouchInterval {
+		if err := queries.TouchUserSession(ctx, session.SessionID); err != nil {
+			logger.Warn("Session", fmt.Sprintf("Failed to touch session %s: %v", session.SessionID, err))
+		}
+	}
+	return nil
+}
+
+func nullString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{Valid: false}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
@@ -3,12 +3,12 @@ package models
 import "time"
 
 type Notification struct {
-	notificationId string
-	receiverId     string
-	noteType       string
-	title          string
-	content        string
-	data           map[string]interface{}
-	isRead         bool
-	createdAt      time.Time
+	NotificationId string                 `json:"notificationId"` // 通知ID
+	ReceiverId     string                 `json:"userId"`         // 接收者ID
+	NoteType       string                 `json:"type"`           // 类型: 'friend' | 'chatroom' | 'system'
+	Title          string                 `json:"title"`          // 标题
+	Content        string                 `json:"content"`        // 内容
+	Data           map[string]interface{} `json:"data,omitempty"` // 附加数据
+	IsRead         bool                   `json:"isRead"`         // 是否已读
+	CreatedAt      time.Time              `json:"createdAt"`      // 创建时间
 }
@@ -0,0 +1,104 @@
+package msgservice
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// 单条消息最多解析的 @用户名 数量，超出部分忽略
+const maxMentionNames = 50
+
+var ErrMentionAll = &Error{Status: http.StatusForbidden, Code: "mention_forbidden", Message: "只有管理员和房主可以使用 @all"}
+
+// @ 须位于开头或非字母数字之后（避免匹配邮箱），用户名到空白或下一个 @ 为止
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.])@([^\s@]+)`)
+
+// 用户名后常见的标点，匹配时同时尝试去除后的形式
+const mentionTrailing = ".,!?;:)]}>'\"，。！？；：、）】》…"
+
+// Mention 一次提及，保存后推送给被提及的成员
+type Mention struct {
+	UserID      string  `json:"-"`
+	MentionType string  `json:"mentionType"` // user | all | admins
+	Message     Payload `json:"message"`
+}
+
+// mentions 消息文本中解析出的提及
+type mentions struct {
+	usernames []string
+	all       bool
+	admins    bool
+}
+
+func (m mentions) empty() bool {
+	return len(m.usernames) == 0 && !m.all && !m.admins
+}
+
+// parseMentions 解析文本中的 @用户名、@all 与 @admins（关键字不区分大小写）
+func parseMentions(text string) mentions {
+	m := mentions{usernames: []string{}}
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		name := match[1]
+		trimmed := strings.TrimRight(name, mentionTrailing)
+		switch strings.ToLower(trimmed) {
+		case "all":
+			m.all = true
+			continue
+		case "admins":
+			m.admins = true
+			continue
+		}
+		for _, candidate := range []string{name, trimmed} {
+			if candidate != "" && !seen[candidate] && len(m.usernames) < maxMentionNames {
+				seen[candidate] = true
+				m.usernames = append(m.usernames, candidate)
+			}
+		}
+	}
+	return m
+}
+
+// MentionFilter 发送前过滤器：@all 仅管理员和房主可用
+func MentionFilter(ctx context.Context, queries *sqlcdb.Queries, in *Input) error {
+	if !parseMentions(in.Text).all {
+		return nil
+	}
+	isAdmin, err := queries.IsUserAdminOrOwner(ctx, sqlcdb.IsUserAdminOrOwnerParams{UserID: in.SenderID, RoomID: in.RoomID})
+	if err != nil {
+		return fmt.Errorf("检查成员角色失败: %w", err)
+	}
+	if !isAdmin {
+		return ErrMentionAll
+	}
+	return nil
+}
+
+// MentionHook 返回保存消息提及的钩子；notify 对每个被提及的成员调用一次（如推送 mention 事件）
+func MentionHook(notify func(Mention)) Hook {
+	return func(ctx context.Context, queries *sqlcdb.Queries, msg Payload) {
+		parsed := parseMentions(msg.Text)
+		if parsed.empty() {
+			return
+		}
+		rows, err := queries.CreateMessageMentions(ctx, sqlcdb.CreateMessageMentionsParams{
+			Usernames:     parsed.usernames,
+			MentionAll:    parsed.all,
+			MessageID:     msg.MessageID,
+			MentionAdmins: parsed.admins,
+		})
+		if err != nil {
+			logger.Error("Message", fmt.Sprintf("Failed to save mentions of message %s", msg.MessageID), err)
+			return
+		}
+		logger.Info("Message", fmt.Sprintf("Message %s mentioned %d members in room %s", msg.MessageID, len(rows), msg.RoomID))
+		for _, r := range rows {
+			notify(Mention{UserID: r.UserID, MentionType: string(r.MentionType), Message: msg})
+		}
+	}
+}
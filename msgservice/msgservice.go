@@ -0,0 +1,375 @@
+// Package msgservice 聊天消息发送的统一流程。
+// HTTP 接口与 WebSocket 均通过 Send 发送消息，保证校验、持久化、补全发送者信息与广播的行为一致。
+package msgservice
+
+import (
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// 消息内容长度上限（字符数）
+	maxTextLength = 5000
+	// 客户端消息ID长度上限
+	maxClientMsgIDLength = 64
+	// 记录逐个接收者投递状态的聊天室成员数上限
+	MaxReceiptRoomSize = 50
+)
+
+// Input 发送消息的参数，各入口解析请求后转换为此结构
+type Input struct {
+	RoomID          string
+	SenderID        string
+	MessageType     string // text | image | file
+	Text            string
+	QuotedMessageID string
+	MediaURL        string
+	ClientMsgID     string // 客户端生成的消息ID，同一发送者重复提交时返回已保存的消息
+	ThreadRootID    string // 话题根消息ID，非空时为话题回复
+	AlsoSendToRoom  bool   // 话题回复是否同时出现在聊天室时间线
+}
+
+// Payload 消息的对外表示，HTTP 响应与 WebSocket 广播共用
+type Payload struct {
+	MessageID       string `json:"messageId"`
+	RoomID          string `json:"roomId"`
+	UserID          string `json:"userId"`
+	UserName        string `json:"userName"`
+	AvatarURL       string `json:"avatarUrl,omitempty"`
+	Type            string `json:"type"`
+	Text            string `json:"text"`
+	MediaURL        string `json:"mediaUrl,omitempty"`
+	QuotedMessageID string `json:"quotedMessageId,omitempty"`
+	Time            string `json:"time"`
+	Seq             int64  `json:"seq"` // 聊天室内消息序号
+	ClientMsgID     string `json:"clientMsgId,omitempty"`
+	ThreadRootID    string `json:"threadRootId,omitempty"`
+	AlsoSendToRoom  bool   `json:"alsoSendToRoom,omitempty"`
+	TrackStatus     bool   `json:"trackStatus,omitempty"` // 是否记录各接收者的投递状态（见 message_status 事件）
+	IsDeleted       bool   `json:"isDeleted,omitempty"`   // 仅同步结果中出现：消息已被撤回
+	DeletedAt       string `json:"deletedAt,omitempty"`   // 撤回时间
+	Duplicate       bool   `json:"-"`                     // 重试发送时为 true，此时未执行钩子（未广播）
+}
+
+// Error 发送失败的原因。Code 用作 WebSocket error 事件的 action，Status 用作 HTTP 状态码
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+var (
+	ErrNotInRoom     = &Error{Status: http.StatusForbidden, Code: "not_in_room", Message: "您不在该聊天室中"}
+	ErrMuted         = &Error{Status: http.StatusForbidden, Code: "muted", Message: "您已被禁言，无法发送消息"}
+	ErrBlocked       = &Error{Status: http.StatusForbidden, Code: "blocked", Message: "你们之间存在屏蔽关系，无法发送消息"}
+	ErrArchived      = &Error{Status: http.StatusForbidden, Code: "room_archived", Message: "聊天室已归档，只能查看历史消息"}
+	ErrInvalidType   = &Error{Status: http.StatusBadRequest, Code: "invalid_type", Message: "消息类型无效，可选值: text|image|file"}
+	ErrEmptyText     = &Error{Status: http.StatusBadRequest, Code: "invalid_data", Message: "消息内容不能为空"}
+	ErrTextTooLong   = &Error{Status: http.StatusBadRequest, Code: "invalid_data", Message: fmt.Sprintf("消息内容不能超过%d个字符", maxTextLength)}
+	ErrMissingMedia  = &Error{Status: http.StatusBadRequest, Code: "missing_media", Message: "图片和文件消息需要提供 mediaUrl"}
+	ErrQuoteNotFound = &Error{Status: http.StatusBadRequest, Code: "invalid_quote", Message: "引用的消息不存在或不属于该聊天室"}
+	ErrClientMsgID   = &Error{Status: http.StatusBadRequest, Code: "invalid_data", Message: fmt.Sprintf("clientMsgId 不能超过%d个字符", maxClientMsgIDLength)}
+	ErrClientMsgUsed = &Error{Status: http.StatusConflict, Code: "duplicate_client_msg_id", Message: "clientMsgId 已用于其他聊天室的消息"}
+)
+
+// Filter 在消息持久化前执行，可修改 Input 或返回错误拒绝发送（如敏感词过滤）
+type Filter func(ctx context.Context, queries *sqlcdb.Queries, in *Input) error
+
+// Hook 在消息持久化后执行（如实时广播、@提及、Webhook），失败不影响发送结果
+type Hook func(ctx context.Context, queries *sqlcdb.Queries, msg Payload)
+
+var (
+	mu      sync.RWMutex
+	filters []Filter
+	hooks   []Hook
+)
+
+// RegisterFilter 注册发送前过滤器，按注册顺序执行
+func RegisterFilter(f Filter) {
+	mu.Lock()
+	defer mu.Unlock()
+	filters = append(filters, f)
+}
+
+// RegisterHook 注册发送后钩子，按注册顺序执行
+func RegisterHook(h Hook) {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks = append(hooks, h)
+}
+
+// Send 校验并持久化一条用户消息，随后依次执行已注册的钩子。
+// 提供了 ClientMsgID 且该消息已保存时直接返回已保存的消息，不再执行钩子。
+// 校验失败时返回 *Error，其他错误为数据库错误
+func Send(ctx context.Context, queries *sqlcdb.Queries, in Input) (Payload, error) {
+	in.Text = strings.TrimSpace(in.Text)
+	in.MediaURL = strings.TrimSpace(in.MediaURL)
+	in.ClientMsgID = strings.TrimSpace(in.ClientMsgID)
+
+	if err := Validate(in); err != nil {
+		return Payload{}, err
+	}
+
+	// 重试发送：返回已保存的消息
+	if in.ClientMsgID != "" {
+		if p, found, err := findSent(ctx, queries, in); found || err != nil {
+			return p, err
+		}
+	}
+
+	// 检查用户是否在聊天室中
+	inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{UserID: in.SenderID, RoomID: in.RoomID})
+	if err != nil {
+		return Payload{}, fmt.Errorf("验证聊天室成员失败: %w", err)
+	}
+	if !inRoom {
+		return Payload{}, ErrNotInRoom
+	}
+
+	// 已归档的聊天室只读
+	if err := CheckWritable(ctx, queries, in.RoomID); err != nil {
+		return Payload{}, err
+	}
+
+	// 检查是否被禁言（全局或聊天室）
+	canSend, err := queries.CanUserSendMessageInRoom(ctx, sqlcdb.CanUserSendMessageInRoomParams{MutedUserID: in.SenderID, RoomID: in.RoomID})
+	if err != nil {
+		return Payload{}, fmt.Errorf("检查禁言状态失败: %w", err)
+	}
+	if !canSend.Valid || !canSend.Bool {
+		return Payload{}, ErrMuted
+	}
+
+	// 私聊会话中任一方屏蔽了另一方时双方都不能发送
+	blocked, err := queries.IsDirectConversationBlocked(ctx, in.RoomID)
+	if err != nil {
+		return Payload{}, fmt.Errorf("检查屏蔽状态失败: %w", err)
+	}
+	if blocked {
+		return Payload{}, ErrBlocked
+	}
+
+	// 话题回复：回复话题中的某条回复时归入同一话题
+	var threadRoot sqlcdb.Message
+	if in.ThreadRootID != "" {
+		threadRoot, err = resolveThreadRoot(ctx, queries, in.RoomID, in.ThreadRootID)
+		if err != nil {
+			return Payload{}, err
+		}
+		if threadRoot.DeletedAt.Valid {
+			return Payload{}, ErrMessageDeleted
+		}
+		in.ThreadRootID = threadRoot.MessageID
+	} else {
+		in.AlsoSendToRoom = false
+	}
+
+	// 引用的消息必须属于同一聊天室
+	if in.QuotedMessageID != "" {
+		quotedRoom, err := queries.GetMessageRoom(ctx, in.QuotedMessageID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && quotedRoom != in.RoomID) {
+			return Payload{}, ErrQuoteNotFound
+		}
+		if err != nil {
+			return Payload{}, fmt.Errorf("获取引用消息失败: %w", err)
+		}
+	}
+
+	mu.RLock()
+	fs := filters
+	mu.RUnlock()
+	for _, f := range fs {
+		if err := f(ctx, queries, &in); err != nil {
+			return Payload{}, err
+		}
+	}
+
+	m, err := queries.CreateMessage(ctx, sqlcdb.CreateMessageParams{
+		Content:         in.Text,
+		MessageType:     sqlcdb.MessageType(in.MessageType),
+		QuotedMessageID: nullString(in.QuotedMessageID),
+		SenderID:        sql.NullString{String: in.SenderID, Valid: true},
+		RoomID:          in.RoomID,
+		MediaUrl:        nullString(in.MediaURL),
+		ClientMsgID:     nullString(in.ClientMsgID),
+		ThreadRootID:    nullString(in.ThreadRootID),
+		AlsoSendToRoom:  in.AlsoSendToRoom,
+	})
+	var pqErr *pq.Error
+	if in.ClientMsgID != "" && errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		// 并发重试时另一请求已先保存
+		p, found, err := findSent(ctx, queries, in)
+		if !found && err == nil {
+			err = fmt.Errorf("保存消息失败: %w", pqErr)
+		}
+		return p, err
+	}
+	if err != nil {
+		return Payload{}, fmt.Errorf("保存消息失败: %w", err)
+	}
+
+	logger.Info("Message", fmt.Sprintf("Message created: %s from user %s in room %s", m.MessageID, in.SenderID, in.RoomID))
+
+	p := toPayload(ctx, queries, m)
+
+	// 回复者自动关注话题；话题收到第一条回复时根消息的发送者也自动关注
+	if in.ThreadRootID != "" {
+		followers := []string{in.SenderID}
+		if threadRoot.ReplyCount == 0 && threadRoot.SenderID.Valid && threadRoot.SenderID.String != in.SenderID {
+			followers = append(followers, threadRoot.SenderID.String)
+		}
+		for _, uid := range followers {
+			if err := queries.FollowThread(ctx, sqlcdb.FollowThreadParams{RootMessageID: in.ThreadRootID, UserID: uid}); err != nil {
+				logger.Error("Message", fmt.Sprintf("Failed to add user %s as follower of thread %s", uid, in.ThreadRootID), err)
+			}
+		}
+	}
+
+	// 小型聊天室记录各接收者的投递状态，失败时仍继续发送。
+	// 未同时发送到聊天室的话题回复只投递给话题关注者（在上面的自动关注之后创建）
+	var n int64
+	if in.ThreadRootID != "" && !in.AlsoSendToRoom {
+		n, err = queries.CreateThreadReplyReceipts(ctx, sqlcdb.CreateThreadReplyReceiptsParams{
+			MessageID:     m.MessageID,
+			RoomID:        m.RoomID,
+			RootMessageID: in.ThreadRootID,
+			SenderID:      in.SenderID,
+			MaxMembers:    MaxReceiptRoomSize,
+		})
+	} else {
+		n, err = queries.CreateMessageReceipts(ctx, sqlcdb.CreateMessageReceiptsParams{
+			MessageID:  m.MessageID,
+			RoomID:     m.RoomID,
+			SenderID:   in.SenderID,
+			MaxMembers: MaxReceiptRoomSize,
+		})
+	}
+	if err != nil {
+		logger.Error("Message", fmt.Sprintf("Failed to create receipts for message %s", m.MessageID), err)
+	}
+	p.TrackStatus = n > 0
+
+	// 更新房间最后活跃时间（异步）
+	go func(roomID string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if err := queries.UpdateChatroomLastActiveTime(ctx, roomID); err != nil {
+			logger.Error("Message", fmt.Sprintf("Failed to update last active time for room %s", roomID), err)
+		}
+	}(in.RoomID)
+
+	mu.RLock()
+	hs := hooks
+	mu.RUnlock()
+	for _, h := range hs {
+		h(ctx, queries, p)
+	}
+
+	return p, nil
+}
+
+// CheckWritable 检查聊天室是否允许写入消息（发送、编辑、撤回、置顶、表情回应）。
+// 已归档的聊天室只读，返回 ErrArchived；其他错误为数据库错误
+func CheckWritable(ctx context.Context, queries *sqlcdb.Queries, roomID string) error {
+	archived, err := queries.IsChatroomArchived(ctx, roomID)
+	if err != nil {
+		return fmt.Errorf("检查聊天室状态失败: %w", err)
+	}
+	if archived {
+		return ErrArchived
+	}
+	return nil
+}
+
+// findSent 查找发送者以 ClientMsgID 已保存的消息；该ID已用于其他聊天室时返回 ErrClientMsgUsed
+func findSent(ctx context.Context, queries *sqlcdb.Queries, in Input) (Payload, bool, error) {
+	m, err := queries.GetMessageByClientMsgID(ctx, sqlcdb.GetMessageByClientMsgIDParams{
+		SenderID:    sql.NullString{String: in.SenderID, Valid: true},
+		ClientMsgID: sql.NullString{String: in.ClientMsgID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return Payload{}, false, nil
+	}
+	if err != nil {
+		return Payload{}, false, fmt.Errorf("查询已发送消息失败: %w", err)
+	}
+	if m.RoomID != in.RoomID {
+		return Payload{}, true, ErrClientMsgUsed
+	}
+
+	logger.Info("Message", fmt.Sprintf("Duplicate send of message %s (clientMsgId %s) from user %s", m.MessageID, in.ClientMsgID, in.SenderID))
+	p := toPayload(ctx, queries, m)
+	p.Duplicate = true
+	return p, true, nil
+}
+
+// toPayload 将已保存的消息转换为对外表示，并补全发送者信息（失败时仅记录日志）
+func toPayload(ctx context.Context, queries *sqlcdb.Queries, m sqlcdb.Message) Payload {
+	p := Payload{
+		MessageID:       m.MessageID,
+		RoomID:          m.RoomID,
+		UserID:          m.SenderID.String,
+		Type:            string(m.MessageType),
+		Text:            m.Content,
+		MediaURL:        m.MediaUrl.String,
+		QuotedMessageID: m.QuotedMessageID.String,
+		Time:            m.SentAt.UTC().Format(time.RFC3339),
+		Seq:             m.Seq,
+		ClientMsgID:     m.ClientMsgID.String,
+		ThreadRootID:    m.ThreadRootID.String,
+		AlsoSendToRoom:  m.AlsoSendToRoom,
+	}
+
+	if u, err := queries.GetUserByID(ctx, m.SenderID.String); err == nil {
+		p.UserName = u.Username
+		if u.Nickname.Valid && u.Nickname.String != "" {
+			p.UserName = u.Nickname.String
+		}
+		p.AvatarURL = u.AvatarUrl.String
+	} else {
+		logger.Error("Message", fmt.Sprintf("Failed to load sender %s of message %s", m.SenderID.String, m.MessageID), err)
+	}
+	return p
+}
+
+// Validate 校验消息类型与内容（调用方应先去除首尾空白）；系统消息不能由用户发送或编辑。
+// 发送与编辑消息共用，保证两者的内容限制一致
+func Validate(in Input) error {
+	switch sqlcdb.MessageType(in.MessageType) {
+	case sqlcdb.MessageTypeText:
+		if in.Text == "" {
+			return ErrEmptyText
+		}
+	case sqlcdb.MessageTypeImage, sqlcdb.MessageTypeFile:
+		if in.MediaURL == "" {
+			return ErrMissingMedia
+		}
+	default:
+		return ErrInvalidType
+	}
+	if len([]rune(in.Text)) > maxTextLength {
+		return ErrTextTooLong
+	}
+	if len(in.ClientMsgID) > maxClientMsgIDLength {
+		return ErrClientMsgID
+	}
+	return nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,125 @@
+package msgservice
+
+import (
+	sqlcdb "chatroombackend/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// 单个表情的最大字符数（组合表情由多个码点组成）
+	maxEmojiLength = 16
+	// 每个用户对同一消息最多使用的不同表情数
+	maxReactionsPerUser = 20
+)
+
+var (
+	ErrInvalidEmoji     = &Error{Status: http.StatusBadRequest, Code: "invalid_emoji", Message: "表情无效"}
+	ErrMessageNotFound  = &Error{Status: http.StatusNotFound, Code: "invalid_message", Message: "消息不存在或不属于该聊天室"}
+	ErrMessageDeleted   = &Error{Status: http.StatusBadRequest, Code: "message_deleted", Message: "消息已被撤回"}
+	ErrTooManyReactions = &Error{Status: http.StatusBadRequest, Code: "too_many_reactions", Message: fmt.Sprintf("每条消息最多使用%d种表情", maxReactionsPerUser)}
+)
+
+// Reaction 一次表情回应变更的结果，HTTP 响应与 WebSocket reaction 事件共用
+type Reaction struct {
+	RoomID    string `json:"roomId"`
+	MessageID string `json:"messageId"`
+	UserID    string `json:"userId"`
+	Emoji     string `json:"emoji"`
+	Count     int64  `json:"count"` // 变更后该表情的回应数
+	Changed   bool   `json:"-"`     // 为 false 表示已回应过（添加）或未回应过（取消），无需广播
+	Added     bool   `json:"-"`     // 添加为 true，取消为 false
+}
+
+// ReactionSummary 单条消息某个表情的聚合结果
+type ReactionSummary struct {
+	Emoji   string `json:"emoji"`
+	Count   int64  `json:"count"`
+	Reacted bool   `json:"reacted"` // 当前用户是否回应
+}
+
+// React 添加或取消表情回应。校验失败时返回 *Error，其他错误为数据库错误
+func React(ctx context.Context, queries *sqlcdb.Queries, userID, roomID, messageID, emoji string, add bool) (Reaction, error) {
+	emoji = strings.TrimSpace(emoji)
+	if !validEmoji(emoji) {
+		return Reaction{}, ErrInvalidEmoji
+	}
+
+	inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{UserID: userID, RoomID: roomID})
+	if err != nil {
+		return Reaction{}, fmt.Errorf("验证聊天室成员失败: %w", err)
+	}
+	if !inRoom {
+		return Reaction{}, ErrNotInRoom
+	}
+	if err := CheckWritable(ctx, queries, roomID); err != nil {
+		return Reaction{}, err
+	}
+
+	m, err := queries.GetMessageByID(ctx, messageID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && m.RoomID != roomID) {
+		return Reaction{}, ErrMessageNotFound
+	}
+	if err != nil {
+		return Reaction{}, fmt.Errorf("获取消息失败: %w", err)
+	}
+
+	r := Reaction{RoomID: roomID, MessageID: messageID, UserID: userID, Emoji: emoji, Added: add}
+	var n int64
+	if add {
+		if m.DeletedAt.Valid {
+			return Reaction{}, ErrMessageDeleted
+		}
+		used, err := queries.CountUserReactionsOnMessage(ctx, sqlcdb.CountUserReactionsOnMessageParams{MessageID: messageID, UserID: userID})
+		if err != nil {
+			return Reaction{}, fmt.Errorf("统计表情回应失败: %w", err)
+		}
+		if used >= maxReactionsPerUser {
+			return Reaction{}, ErrTooManyReactions
+		}
+		n, err = queries.AddMessageReaction(ctx, sqlcdb.AddMessageReactionParams{MessageID: messageID, UserID: userID, Emoji: emoji})
+		if err != nil {
+			return Reaction{}, fmt.Errorf("添加表情回应失败: %w", err)
+		}
+	} else {
+		n, err = queries.RemoveMessageReaction(ctx, sqlcdb.RemoveMessageReactionParams{MessageID: messageID, UserID: userID, Emoji: emoji})
+		if err != nil {
+			return Reaction{}, fmt.Errorf("取消表情回应失败: %w", err)
+		}
+	}
+	r.Changed = n > 0
+
+	r.Count, err = queries.CountMessageReactionsByEmoji(ctx, sqlcdb.CountMessageReactionsByEmojiParams{MessageID: messageID, Emoji: emoji})
+	if err != nil {
+		return Reaction{}, fmt.Errorf("统计表情回应失败: %w", err)
+	}
+	return r, nil
+}
+
+// ReactionSummaries 批量获取多条消息的表情回应聚合结果，key 为消息ID
+func ReactionSummaries(ctx context.Context, queries *sqlcdb.Queries, userID string, messageIDs []string) (map[string][]ReactionSummary, error) {
+	result := make(map[string][]ReactionSummary)
+	if len(messageIDs) == 0 {
+		return result, nil
+	}
+	rows, err := queries.GetReactionSummaries(ctx, sqlcdb.GetReactionSummariesParams{UserID: userID, MessageIds: messageIDs})
+	if err != nil {
+		return nil, fmt.Errorf("获取表情回应失败: %w", err)
+	}
+	for _, r := range rows {
+		result[r.MessageID] = append(result[r.MessageID], ReactionSummary{Emoji: r.Emoji, Count: r.Count, Reacted: r.Reacted})
+	}
+	return result, nil
+}
+
+// validEmoji 表情必须恰好是一个表情字符或表情序列（含肤色、国旗、键帽与零宽连接组合），且长度有限
+func validEmoji(emoji string) bool {
+	if emoji == "" || len([]rune(emoji)) > maxEmojiLength {
+		return false
+	}
+	return isEmojiSequence(emoji)
+}
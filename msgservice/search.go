@@ -0,0 +1,293 @@
+package msgservice
+
+import (
+	sqlcdb "chatroombackend/db"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// 搜索关键词的最大字符数
+	maxSearchQueryLength = 100
+	// 搜索关键词最多拆分的词数
+	maxSearchTerms = 5
+	// 单次搜索返回的最大条数
+	MaxSearchLimit = 50
+	// 摘要在首个匹配前保留的字符数与摘要总长度
+	snippetLead   = 20
+	snippetLength = 80
+)
+
+var (
+	ErrSearchQuery  = &Error{Status: http.StatusBadRequest, Code: "invalid_query", Message: fmt.Sprintf("搜索关键词不能为空且不能超过%d个字符", maxSearchQueryLength)}
+	ErrSearchShort  = &Error{Status: http.StatusBadRequest, Code: "query_too_short", Message: "关键词只有一个字时请指定聊天室后搜索"}
+	ErrSearchCursor = &Error{Status: http.StatusBadRequest, Code: "invalid_cursor", Message: "分页游标无效"}
+)
+
+// SearchOptions 消息搜索条件，空字段表示不过滤
+type SearchOptions struct {
+	Query       string
+	RoomID      string
+	SenderID    string
+	MessageType string
+	From        time.Time // 包含
+	To          time.Time // 不包含
+	Cursor      string    // 上一页返回的 NextCursor
+	Limit       int
+}
+
+// SearchHit 一条搜索结果
+type SearchHit struct {
+	MessageID    string   `json:"messageId"`
+	RoomID       string   `json:"roomId"`
+	RoomName     string   `json:"roomName"`
+	UserID       string   `json:"userId"`
+	UserName     string   `json:"userName"`
+	AvatarURL    string   `json:"avatarUrl,omitempty"`
+	Type         string   `json:"type"`
+	Snippet      string   `json:"snippet"`
+	Highlights   [][2]int `json:"highlights"` // 摘要中匹配部分的 [起始, 长度]，以字符（rune）计
+	Time         string   `json:"time"`
+	Seq          int64    `json:"seq"`
+	ThreadRootID string   `json:"threadRootId,omitempty"`
+	Score        float32  `json:"score"` // 与关键词的相似度，越大越相关
+}
+
+// SearchResult 一页搜索结果
+type SearchResult struct {
+	Hits       []SearchHit `json:"hits"`
+	NextCursor string      `json:"nextCursor,omitempty"`
+	HasMore    bool        `json:"hasMore"`
+}
+
+// searchCursor 分页游标，对应排序键 (相似度, 发送时间, 消息ID)
+type searchCursor struct {
+	Rank      float32   `json:"r"`
+	SentAt    time.Time `json:"t"`
+	MessageID string    `json:"id"`
+}
+
+// Search 在用户所在的所有聊天室中搜索消息（不含已撤回的消息与系统消息），按相关度排序。
+// 关键词按空白拆分，须全部出现在消息中（不区分大小写）。所有关键词都只有一个字时无法使用索引，
+// 须指定聊天室。校验失败时返回 *Error，其他错误为数据库错误
+func Search(ctx context.Context, queries *sqlcdb.Queries, userID string, opts SearchOptions) (SearchResult, error) {
+	query := strings.Join(strings.Fields(opts.Query), " ")
+	if query == "" || len([]rune(query)) > maxSearchQueryLength {
+		return SearchResult{}, ErrSearchQuery
+	}
+	terms := searchTerms(query)
+	if opts.Limit < 1 || opts.Limit > MaxSearchLimit {
+		opts.Limit = 20
+	}
+
+	params := sqlcdb.SearchMessagesParams{
+		Query:        query,
+		UserID:       userID,
+		TermPatterns: make([]string, len(terms)),
+		Bigrams:      termBigrams(terms),
+		RoomID:       opts.RoomID,
+		SenderID:     opts.SenderID,
+		MessageType:  opts.MessageType,
+		FromTime:     sql.NullTime{Time: opts.From, Valid: !opts.From.IsZero()},
+		ToTime:       sql.NullTime{Time: opts.To, Valid: !opts.To.IsZero()},
+		Limit:        int64(opts.Limit) + 1,
+	}
+	// 最长的关键词最能缩小三元组索引的扫描范围
+	longest := ""
+	for i, t := range terms {
+		params.TermPatterns[i] = "%" + escapeLike(t) + "%"
+		if len([]rune(t)) > len([]rune(longest)) {
+			longest = t
+		}
+	}
+	params.Pattern = "%" + escapeLike(longest) + "%"
+	// 没有二元组时只能逐条匹配，限定在单个聊天室内
+	if len(params.Bigrams) == 0 && opts.RoomID == "" {
+		return SearchResult{}, ErrSearchShort
+	}
+
+	if opts.Cursor != "" {
+		cur, err := decodeSearchCursor(opts.Cursor)
+		if err != nil {
+			return SearchResult{}, ErrSearchCursor
+		}
+		params.HasCursor = true
+		params.CursorRank = cur.Rank
+		params.CursorSentAt = cur.SentAt
+		params.CursorMessageID = cur.MessageID
+	}
+
+	rows, err := queries.SearchMessages(ctx, params)
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("搜索消息失败: %w", err)
+	}
+
+	result := SearchResult{Hits: make([]SearchHit, 0, len(rows))}
+	if len(rows) > opts.Limit {
+		rows = rows[:opts.Limit]
+		result.HasMore = true
+	}
+	for _, r := range rows {
+		userName := r.Nickname.String
+		if userName == "" {
+			userName = r.Username.String
+		}
+		snippet, highlights := buildSnippet(r.Content, terms)
+		result.Hits = append(result.Hits, SearchHit{
+			MessageID:    r.MessageID,
+			RoomID:       r.RoomID,
+			RoomName:     r.RoomName,
+			UserID:       r.SenderID.String,
+			UserName:     userName,
+			AvatarURL:    r.AvatarUrl.String,
+			Type:         string(r.MessageType),
+			Snippet:      snippet,
+			Highlights:   highlights,
+			Time:         r.SentAt.UTC().Format(time.RFC3339),
+			Seq:          r.Seq,
+			ThreadRootID: r.ThreadRootID.String,
+			Score:        r.Rank,
+		})
+	}
+	if result.HasMore {
+		last := rows[len(rows)-1]
+		result.NextCursor = encodeSearchCursor(searchCursor{Rank: last.Rank, SentAt: last.SentAt, MessageID: last.MessageID})
+	}
+	return result, nil
+}
+
+// searchTerms 将关键词按空白拆分并去重，最多 maxSearchTerms 个
+func searchTerms(query string) []string {
+	seen := make(map[string]bool)
+	terms := make([]string, 0, maxSearchTerms)
+	for _, t := range strings.Fields(query) {
+		key := strings.ToLower(t)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		terms = append(terms, t)
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// termBigrams 返回关键词中所有相邻两字（小写、去重），与 message_bigrams 的切分方式一致。
+// 结果不为 nil，没有二元组时返回空数组（匹配所有消息）
+func termBigrams(terms []string) []string {
+	seen := make(map[string]bool)
+	bigrams := []string{}
+	for _, t := range terms {
+		rs := []rune(strings.ToLower(t))
+		for i := 0; i+1 < len(rs); i++ {
+			b := string(rs[i : i+2])
+			if seen[b] {
+				continue
+			}
+			seen[b] = true
+			bigrams = append(bigrams, b)
+		}
+	}
+	return bigrams
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// buildSnippet 截取首个匹配附近的内容作为摘要，并返回摘要中所有匹配的位置（合并重叠部分）
+func buildSnippet(content string, terms []string) (string, [][2]int) {
+	text := []rune(content)
+	lower := lowerRunes(text)
+
+	var matches [][2]int
+	for _, t := range terms {
+		needle := lowerRunes([]rune(t))
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if slices.Equal(lower[i:i+len(needle)], needle) {
+				matches = append(matches, [2]int{i, i + len(needle)})
+				i += len(needle) - 1
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i][0] < matches[j][0] })
+
+	start := 0
+	if len(matches) > 0 && matches[0][0] > snippetLead {
+		start = matches[0][0] - snippetLead
+	}
+	end := start + snippetLength
+	if end > len(text) {
+		end = len(text)
+	}
+
+	prefix := ""
+	if start > 0 {
+		prefix = "…"
+	}
+	suffix := ""
+	if end < len(text) {
+		suffix = "…"
+	}
+	offset := len([]rune(prefix))
+
+	highlights := [][2]int{}
+	for _, m := range matches {
+		if m[0] >= end {
+			break
+		}
+		s, e := m[0], m[1]
+		if e > end {
+			e = end
+		}
+		if n := len(highlights); n > 0 && s-start+offset <= highlights[n-1][0]+highlights[n-1][1] {
+			last := &highlights[n-1]
+			if e-start+offset > last[0]+last[1] {
+				last[1] = e - start + offset - last[0]
+			}
+			continue
+		}
+		highlights = append(highlights, [2]int{s - start + offset, e - s})
+	}
+	return prefix + string(text[start:end]) + suffix, highlights
+}
+
+func lowerRunes(rs []rune) []rune {
+	lower := make([]rune, len(rs))
+	for i, r := range rs {
+		lower[i] = unicode.ToLower(r)
+	}
+	return lower
+}
+
+func encodeSearchCursor(c searchCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSearchCursor(s string) (searchCursor, error) {
+	var c searchCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, err
+	}
+	if c.MessageID == "" {
+		return c, fmt.Errorf("missing message id")
+	}
+	return c, nil
+}
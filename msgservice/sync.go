@@ -0,0 +1,143 @@
+package msgservice
+
+import (
+	sqlcdb "chatroombackend/db"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// 单次同步最多处理的聊天室数量
+	MaxSyncRooms = 100
+	// 单个聊天室单次同步返回的最大变更条数，超出时 HasMore 为 true
+	syncBatchSize = 200
+)
+
+// EditItem 同步结果中已知消息的编辑
+type EditItem struct {
+	MessageID string `json:"messageId"`
+	Text      string `json:"text"`
+	MediaURL  string `json:"mediaUrl,omitempty"`
+	EditedAt  string `json:"editedAt"`
+	Revision  int32  `json:"revision"`
+	Seq       int64  `json:"seq"` // 变更序号
+}
+
+// DeletionItem 同步结果中已知消息的撤回
+type DeletionItem struct {
+	MessageID string `json:"messageId"`
+	DeletedAt string `json:"deletedAt"`
+	Seq       int64  `json:"seq"` // 变更序号
+}
+
+// RoomSync 单个聊天室的同步结果
+type RoomSync struct {
+	RoomID     string         `json:"roomId"`
+	Messages   []Payload      `json:"messages"`   // 消息序号大于新消息游标的消息（当前内容）
+	Edits      []EditItem     `json:"edits"`      // 客户端已收到的消息的编辑
+	Deletions  []DeletionItem `json:"deletions"`  // 客户端已收到的消息的撤回
+	LastSeq    int64          `json:"lastSeq"`    // 同步后的变更游标，下次同步时传入
+	MessageSeq int64          `json:"messageSeq"` // 新消息游标：分页期间保持不变，HasMore 为 true 时随 LastSeq 一同传入
+	HasMore    bool           `json:"hasMore"`
+}
+
+// Sync 返回用户在各聊天室中 lastSeq 之后遗漏的新消息、编辑与撤回。
+// cursors 为 roomId -> 客户端已收到的最大序号（变更游标）；用户不在其中的聊天室会被忽略。
+// messageCursors 为分页时上一页返回的 messageSeq，未提供时等于变更游标。
+// 消息序号大于新消息游标的变更一律作为新消息返回，避免分页期间被编辑或撤回的新消息只以编辑/撤回的形式出现
+func Sync(ctx context.Context, queries *sqlcdb.Queries, userID string, cursors, messageCursors map[string]int64) ([]RoomSync, error) {
+	result := make([]RoomSync, 0, len(cursors))
+	for roomID, lastSeq := range cursors {
+		msgSeq, ok := messageCursors[roomID]
+		if !ok || msgSeq > lastSeq {
+			msgSeq = lastSeq
+		}
+
+		inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{UserID: userID, RoomID: roomID})
+		if err != nil {
+			return nil, fmt.Errorf("验证聊天室成员失败: %w", err)
+		}
+		if !inRoom {
+			continue
+		}
+
+		rows, err := queries.GetRoomMessageChanges(ctx, sqlcdb.GetRoomMessageChangesParams{
+			RoomID:    roomID,
+			ChangeSeq: lastSeq,
+			Limit:     syncBatchSize + 1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("获取聊天室 %s 的消息变更失败: %w", roomID, err)
+		}
+
+		rs := RoomSync{
+			RoomID:    roomID,
+			Messages:  []Payload{},
+			Edits:     []EditItem{},
+			Deletions: []DeletionItem{},
+			LastSeq:   lastSeq,
+		}
+		if len(rows) > syncBatchSize {
+			rows = rows[:syncBatchSize]
+			rs.HasMore = true
+		}
+		for _, r := range rows {
+			switch {
+			case r.Seq > msgSeq:
+				rs.Messages = append(rs.Messages, payloadFromChange(r))
+			case r.DeletedAt.Valid:
+				rs.Deletions = append(rs.Deletions, DeletionItem{
+					MessageID: r.MessageID,
+					DeletedAt: r.DeletedAt.Time.UTC().Format(time.RFC3339),
+					Seq:       r.ChangeSeq,
+				})
+			default:
+				rs.Edits = append(rs.Edits, EditItem{
+					MessageID: r.MessageID,
+					Text:      r.Content,
+					MediaURL:  r.MediaUrl.String,
+					EditedAt:  r.EditedAt.Time.UTC().Format(time.RFC3339),
+					Revision:  r.EditCount,
+					Seq:       r.ChangeSeq,
+				})
+			}
+			rs.LastSeq = r.ChangeSeq
+		}
+		rs.MessageSeq = rs.LastSeq
+		if rs.HasMore {
+			rs.MessageSeq = msgSeq
+		}
+		result = append(result, rs)
+	}
+	return result, nil
+}
+
+// payloadFromChange 将新消息转换为对外表示；同步前已撤回的消息带有 isDeleted 与 deletedAt
+func payloadFromChange(r sqlcdb.GetRoomMessageChangesRow) Payload {
+	userName := r.Nickname.String
+	if userName == "" {
+		userName = r.Username.String
+	}
+	p := Payload{
+		MessageID:       r.MessageID,
+		RoomID:          r.RoomID,
+		UserID:          r.SenderID.String,
+		UserName:        userName,
+		AvatarURL:       r.AvatarUrl.String,
+		Type:            string(r.MessageType),
+		Text:            r.Content,
+		MediaURL:        r.MediaUrl.String,
+		QuotedMessageID: r.QuotedMessageID.String,
+		Time:            r.SentAt.UTC().Format(time.RFC3339),
+		Seq:             r.Seq,
+		ClientMsgID:     r.ClientMsgID.String,
+		ThreadRootID:    r.ThreadRootID.String,
+		AlsoSendToRoom:  r.AlsoSendToRoom,
+	}
+	if r.DeletedAt.Valid {
+		p.IsDeleted = true
+		p.DeletedAt = r.DeletedAt.Time.UTC().Format(time.RFC3339)
+	}
+	return p
+}
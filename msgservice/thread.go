@@ -0,0 +1,54 @@
+package msgservice
+
+import (
+	sqlcdb "chatroombackend/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var ErrThreadNotFound = &Error{Status: http.StatusNotFound, Code: "invalid_thread", Message: "话题不存在或不属于该聊天室"}
+
+// resolveThreadRoot 获取话题根消息；messageID 本身是话题回复时返回其所属话题的根消息
+func resolveThreadRoot(ctx context.Context, queries *sqlcdb.Queries, roomID, messageID string) (sqlcdb.Message, error) {
+	m, err := queries.GetMessageByID(ctx, messageID)
+	if err == nil && m.ThreadRootID.Valid {
+		m, err = queries.GetMessageByID(ctx, m.ThreadRootID.String)
+	}
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && m.RoomID != roomID) {
+		return sqlcdb.Message{}, ErrThreadNotFound
+	}
+	if err != nil {
+		return sqlcdb.Message{}, fmt.Errorf("获取话题根消息失败: %w", err)
+	}
+	return m, nil
+}
+
+// FollowThread 关注或取消关注话题，返回话题根消息ID。校验失败时返回 *Error，其他错误为数据库错误
+func FollowThread(ctx context.Context, queries *sqlcdb.Queries, userID, roomID, messageID string, follow bool) (string, error) {
+	inRoom, err := queries.IsUserInChatroom(ctx, sqlcdb.IsUserInChatroomParams{UserID: userID, RoomID: roomID})
+	if err != nil {
+		return "", fmt.Errorf("验证聊天室成员失败: %w", err)
+	}
+	if !inRoom {
+		return "", ErrNotInRoom
+	}
+
+	m, err := resolveThreadRoot(ctx, queries, roomID, messageID)
+	if err != nil {
+		return "", err
+	}
+
+	params := sqlcdb.FollowThreadParams{RootMessageID: m.MessageID, UserID: userID}
+	if follow {
+		err = queries.FollowThread(ctx, params)
+	} else {
+		_, err = queries.UnfollowThread(ctx, sqlcdb.UnfollowThreadParams(params))
+	}
+	if err != nil {
+		return "", fmt.Errorf("更新话题关注失败: %w", err)
+	}
+	return m.MessageID, nil
+}
@@ -0,0 +1,195 @@
+package notify
+
+import (
+	"chatroombackend/api/websocketmsg"
+	sqlcdb "chatroombackend/db"
+	"chatroombackend/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// 通知类型
+const (
+	TypeFriend   = "friend"
+	TypeChatroom = "chatroom"
+	TypeSystem   = "system"
+)
+
+// Item 通知的对外表示，HTTP 接口与 WebSocket 推送共用
+type Item struct {
+	NotificationId string          `json:"notificationId"`
+	Type           string          `json:"type"`
+	Title          string          `json:"title"`
+	Content        string          `json:"content"`
+	Data           json.RawMessage `json:"data"`
+	IsRead         bool            `json:"isRead"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// ToItem 将数据库记录转换为对外表示
+func ToItem(n sqlcdb.Notification) Item {
+	return Item{
+		NotificationId: n.NotificationID,
+		Type:           n.NotificationType,
+		Title:          n.Title,
+		Content:        n.Content,
+		Data:           n.Data,
+		IsRead:         n.IsRead,
+		CreatedAt:      n.CreatedAt,
+	}
+}
+
+// Send 持久化一条通知并通过 WebSocket 推送给接收者的所有在线设备。
+// 接收者离线时通知仍会保存，重新上线后可通过通知列表获取。
+func Send(ctx context.Context, queries *sqlcdb.Queries, receiverID, notificationType, title, content string, data any) (sqlcdb.Notification, error) {
+	raw := json.RawMessage("{}")
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return sqlcdb.Notification{}, fmt.Errorf("序列化通知数据失败: %w", err)
+		}
+		raw = b
+	}
+
+	n, err := queries.CreateNotification(ctx, sqlcdb.CreateNotificationParams{
+		ReceiverID:       receiverID,
+		NotificationType: notificationType,
+		Title:            title,
+		Content:          content,
+		Data:             raw,
+	})
+	if err != nil {
+		return sqlcdb.Notification{}, err
+	}
+
+	payload, _ := json.Marshal(ToItem(n))
+	websocketmsg.SendToUser(receiverID, websocketmsg.WSMessage{
+		Type:   "notification",
+		Action: "new",
+		Data:   payload,
+	})
+	return n, nil
+}
+
+// send 供业务场景调用，失败只记录日志，不影响主流程
+func send(ctx context.Context, queries *sqlcdb.Queries, receiverID, notificationType, title, content string, data any) {
+	if _, err := Send(ctx, queries, receiverID, notificationType, title, content, data); err != nil {
+		logger.Error("Notify", fmt.Sprintf("创建通知失败 (receiver: %s, title: %s)", receiverID, title), err)
+	}
+}
+
+// DisplayName 优先返回昵称，无则返回用户名
+func DisplayName(u sqlcdb.User) string {
+	if u.Nickname.Valid && u.Nickname.String != "" {
+		return u.Nickname.String
+	}
+	return u.Username
+}
+
+// FriendRequestReceived 通知接收者收到新的好友请求
+func FriendRequestReceived(ctx context.Context, queries *sqlcdb.Queries, request sqlcdb.FriendRequest, sender sqlcdb.User) {
+	send(ctx, queries, request.ReceiverID, TypeFriend,
+		"新好友请求",
+		fmt.Sprintf("%s想加你为好友", DisplayName(sender)),
+		map[string]any{
+			"requestId": request.RequestID,
+			"userId":    sender.UserID,
+			"message":   request.Message.String,
+		})
+}
+
+// FriendRequestAccepted 通知发送者其好友请求已被接受
+func FriendRequestAccepted(ctx context.Context, queries *sqlcdb.Queries, request sqlcdb.FriendRequest, receiver sqlcdb.User) {
+	send(ctx, queries, request.SenderID, TypeFriend,
+		"好友请求已通过",
+		fmt.Sprintf("%s接受了你的好友请求", DisplayName(receiver)),
+		map[string]any{
+			"requestId": request.RequestID,
+			"userId":    receiver.UserID,
+		})
+}
+
+// MemberMuted 通知成员在聊天室中被禁言，muteUntil 为 nil 表示永久禁言
+func MemberMuted(ctx context.Context, queries *sqlcdb.Queries, userID string, room sqlcdb.Chatroom, muteUntil *time.Time, reason, operatorID string) {
+	content := fmt.Sprintf("你在聊天室「%s」中被永久禁言", room.RoomName)
+	if muteUntil != nil {
+		content = fmt.Sprintf("你在聊天室「%s」中被禁言至 %s", room.RoomName, muteUntil.Local().Format("2006-01-02 15:04:05"))
+	}
+	if reason != "" {
+		content += "，原因：" + reason
+	}
+	send(ctx, queries, userID, TypeChatroom, "你已被禁言", content, map[string]any{
+		"roomId":     room.RoomID,
+		"muteUntil":  muteUntil,
+		"reason":     reason,
+		"operatorId": operatorID,
+	})
+}
+
+// MemberKicked 通知成员被移出聊天室
+func MemberKicked(ctx context.Context, queries *sqlcdb.Queries, userID string, room sqlcdb.Chatroom, reason, operatorID string) {
+	content := fmt.Sprintf("你已被移出聊天室「%s」", room.RoomName)
+	if reason != "" {
+		content += "，原因：" + reason
+	}
+	send(ctx, queries, userID, TypeChatroom, "你已被移出聊天室", content, map[string]any{
+		"roomId":     room.RoomID,
+		"reason":     reason,
+		"operatorId": operatorID,
+	})
+}
+
+// AdminPromoted 通知成员被设置为聊天室管理员
+func AdminPromoted(ctx context.Context, queries *sqlcdb.Queries, userID string, room sqlcdb.Chatroom, operatorID string) {
+	send(ctx, queries, userID, TypeChatroom,
+		"你已成为管理员",
+		fmt.Sprintf("你已被设置为聊天室「%s」的管理员", room.RoomName),
+		map[string]any{
+			"roomId":     room.RoomID,
+			"roomRole":   string(sqlcdb.MemberRoleAdmin),
+			"operatorId": operatorID,
+		})
+}
+
+// OwnershipTransferred 通知新房主，并在原房主仍可登录时通知原房主（已降为管理员或已退出）
+func OwnershipTransferred(ctx context.Context, queries *sqlcdb.Queries, room sqlcdb.Chatroom, fromUserID, toUserID, mode string) {
+	data := map[string]any{
+		"roomId":     room.RoomID,
+		"fromUserId": fromUserID,
+		"toUserId":   toUserID,
+		"mode":       mode,
+	}
+	send(ctx, queries, toUserID, TypeChatroom,
+		"你已成为房主",
+		fmt.Sprintf("你已成为聊天室「%s」的房主", room.RoomName),
+		data)
+
+	if mode == "account_deleted" {
+		return
+	}
+	newOwner := toUserID
+	if u, err := queries.GetUserByID(ctx, toUserID); err == nil {
+		newOwner = DisplayName(u)
+	}
+	content := fmt.Sprintf("你已将聊天室「%s」的房主转让给 %s，你现在是管理员", room.RoomName, newOwner)
+	if mode == "leave" {
+		content = fmt.Sprintf("你已退出聊天室「%s」，房主已自动转让给 %s", room.RoomName, newOwner)
+	}
+	send(ctx, queries, fromUserID, TypeChatroom, "房主已转让", content, data)
+}
+
+// JoinRequestHandled 通知申请人其加入申请已被处理
+func JoinRequestHandled(ctx context.Context, queries *sqlcdb.Queries, request sqlcdb.RoomJoinRequest, room sqlcdb.Chatroom) {
+	title, content := "加入申请已通过", fmt.Sprintf("你已加入聊天室「%s」", room.RoomName)
+	if request.Status == "rejected" {
+		title, content = "加入申请被拒绝", fmt.Sprintf("你加入聊天室「%s」的申请被拒绝", room.RoomName)
+	}
+	send(ctx, queries, request.UserID, TypeChatroom, title, content, map[string]any{
+		"requestId":  request.RequestID,
+		"roomId":     room.RoomID,
+		"status":     request.Status,
+		"operatorId": request.HandledBy.String,
+	})
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	crand "crypto/rand"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -65,3 +66,52 @@ func GenerateNotificationID() string {
 	// 取时间戳的后12位，如果不足12位则在前面补0
 	return fmt.Sprintf("N%012d", timestamp%1000000000000)
 }
+
+// GenerateConnectionID 生成 WebSocket 连接ID (C+12位时间戳+4位随机数)
+func GenerateConnectionID() string {
+	randMutex.Lock()
+	defer randMutex.Unlock()
+
+	timestamp := time.Now().UnixNano()
+	return fmt.Sprintf("C%012d%04d", timestamp%1000000000000, randGen.Intn(10000))
+}
+
+// GenerateInstanceID 生成服务实例ID (I+12位时间戳+4位随机数)
+func GenerateInstanceID() string {
+	randMutex.Lock()
+	defer randMutex.Unlock()
+
+	timestamp := time.Now().UnixNano()
+	return fmt.Sprintf("I%012d%04d", timestamp%1000000000000, randGen.Intn(10000))
+}
+
+// GenerateSessionID 生成登录会话ID (S+12位时间戳+4位随机数)
+func GenerateSessionID() string {
+	randMutex.Lock()
+	defer randMutex.Unlock()
+
+	timestamp := time.Now().UnixNano()
+	return fmt.Sprintf("S%012d%04d", timestamp%1000000000000, randGen.Intn(10000))
+}
+
+// GenerateTokenID 生成 JWT 的 jti (T+12位时间戳+4位随机数)
+func GenerateTokenID() string {
+	randMutex.Lock()
+	defer randMutex.Unlock()
+
+	timestamp := time.Now().UnixNano()
+	return fmt.Sprintf("T%012d%04d", timestamp%1000000000000, randGen.Intn(10000))
+}
+
+// 邀请码字符集，去除了易混淆的 0/O、1/I/L
+const inviteCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// GenerateInviteCode 生成10位聊天室邀请码（使用加密随机数，不可预测）
+func GenerateInviteCode() string {
+	b := make([]byte, 10)
+	crand.Read(b) // 出错时直接终止程序，不会返回错误
+	for i := range b {
+		b[i] = inviteCodeAlphabet[int(b[i])%len(inviteCodeAlphabet)]
+	}
+	return string(b)
+}
@@ -0,0 +1,9 @@
+package utils
+
+// VisibleOnlineStatus 返回对他人展示的在线状态；用户关闭"显示在线状态"时一律显示为离线
+func VisibleOnlineStatus(status string, show bool) string {
+	if !show {
+		return "offline"
+	}
+	return status
+}